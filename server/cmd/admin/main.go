@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/hr-platform-mosprom/internal"
+)
+
+const usage = `usage: admin create -login <login>
+
+Пароль читается из первой строки stdin:
+  echo "$ADMIN_PASSWORD" | go run ./cmd/admin create -login admin
+`
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] != "create" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	flags := flag.NewFlagSet("create", flag.ExitOnError)
+	login := flags.String("login", "", "admin login")
+	_ = flags.Parse(os.Args[2:])
+
+	if *login == "" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && password == "" {
+		slog.Error("error reading password from stdin", "err", err)
+		os.Exit(1)
+	}
+
+	env, err := internal.LoadEnv()
+	if err != nil {
+		slog.Error("error loading env", "err", err)
+		os.Exit(1)
+	}
+
+	err = internal.CreateAdmin(env, *login, strings.TrimRight(password, "\r\n"))
+	if err != nil {
+		slog.Error("error creating admin", "err", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,127 @@
+package argon2
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// prefix — начало хеша в формате PHC:
+// $argon2id$v=19$m=<KiB>,t=<iterations>,p=<parallelism>$<salt>$<key>
+const prefix = "$argon2id$"
+
+type Params struct {
+	// Память в KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+type argon2idPasswordService struct {
+	params Params
+}
+
+func NewArgon2idPasswordService(params Params) *argon2idPasswordService {
+	return &argon2idPasswordService{params}
+}
+
+func (s *argon2idPasswordService) Hash(raw string) (string, error) {
+	salt := make([]byte, s.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error generating salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(raw), salt, s.params.Iterations, s.params.Memory, s.params.Parallelism, s.params.KeyLength)
+
+	return encode(s.params, salt, key), nil
+}
+
+func (s *argon2idPasswordService) Check(raw, hash string) bool {
+	params, salt, key, err := decode(hash)
+	if err != nil {
+		return false
+	}
+
+	candidate := argon2.IDKey([]byte(raw), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return subtle.ConstantTimeCompare(key, candidate) == 1
+}
+
+// NeedsRehash сообщает, что хеш посчитан с другими параметрами.
+func (s *argon2idPasswordService) NeedsRehash(hash string) bool {
+	params, _, _, err := decode(hash)
+	if err != nil {
+		return true
+	}
+
+	return params != s.params
+}
+
+func (s *argon2idPasswordService) Owns(hash string) bool {
+	return strings.HasPrefix(hash, prefix)
+}
+
+func encode(params Params, salt, key []byte) string {
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		prefix,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func decode(hash string) (Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Params{}, nil, nil, errors.New("not an argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("error parsing version: %w", err)
+	}
+	if version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+
+	var params Params
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("error parsing params: %w", err)
+	}
+	// argon2.IDKey паникует при нулевых t и p: испорченная запись в БД не
+	// должна ронять вход
+	if params.Memory == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return Params{}, nil, nil, errors.New("zero argon2 params")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("error decoding salt: %w", err)
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("error decoding key: %w", err)
+	}
+	if len(salt) == 0 || len(key) == 0 {
+		return Params{}, nil, nil, errors.New("empty salt or key")
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
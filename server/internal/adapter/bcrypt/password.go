@@ -1,6 +1,10 @@
 package bcrypt
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
 
 type bcryptPasswordService struct {
 	cost int
@@ -19,3 +23,13 @@ func (s *bcryptPasswordService) Check(raw, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(raw))
 	return err == nil
 }
+
+func (s *bcryptPasswordService) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != s.cost
+}
+
+// Owns распознаёт хеши по префиксу $2a$, $2b$ или $2y$.
+func (s *bcryptPasswordService) Owns(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
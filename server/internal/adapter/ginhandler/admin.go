@@ -0,0 +1,420 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	adminHandlers struct {
+		adminService      port.AdminService
+		companyService    port.CompanyService
+		universityService port.UniversityService
+		vacancyService    port.VacancyService
+		logger            *slog.Logger
+		validator         *validator.Validate
+	}
+
+	adminWithTokenResponse struct {
+		ID           uuid.UUID `json:"id"`
+		Login        string    `json:"login"`
+		Token        string    `json:"token"`
+		RefreshToken string    `json:"refresh_token"`
+	}
+
+	adminCompaniesQuery struct {
+		pageQuery
+		Approved *bool `form:"approved"`
+	}
+
+	adminUniversitiesQuery struct {
+		pageQuery
+		Confirmed *bool `form:"confirmed"`
+	}
+
+	// adminVacanciesQuery — без state отдаётся очередь на модерацию
+	adminVacanciesQuery struct {
+		pageQuery
+		State string `form:"state" validate:"omitempty,oneof=draft on_review published rejected archived"`
+	}
+
+	moderationReasonRequest struct {
+		Reason string `json:"reason" validate:"required,max=2048"`
+	}
+)
+
+func RegisterAdminHandlers(
+	engine *gin.Engine,
+	adminService port.AdminService,
+	companyService port.CompanyService,
+	universityService port.UniversityService,
+	vacancyService port.VacancyService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := adminHandlers{adminService, companyService, universityService, vacancyService, logger, validator}
+
+	engine.POST("/admin/sign-in", handlers.SignIn)
+
+	group := engine.Group("/admin", auth.Authenticate(), auth.AdminOnly())
+
+	group.GET("/companies", handlers.ListCompanies)
+	group.POST("/companies/:id/approve", handlers.ApproveCompany)
+	group.POST("/companies/:id/reject", handlers.RejectCompany)
+	group.POST("/companies/:id/revoke", handlers.RevokeCompanyApproval)
+
+	group.GET("/universities", handlers.ListUniversities)
+	group.POST("/universities/:id/confirm", handlers.ConfirmUniversity)
+	group.POST("/universities/:id/reject", handlers.RejectUniversity)
+	group.POST("/universities/:id/revoke", handlers.RevokeUniversityConfirmation)
+
+	group.GET("/vacancies", handlers.ListVacancies)
+	group.POST("/vacancies/:id/publish", handlers.PublishVacancy)
+	group.POST("/vacancies/:id/reject", handlers.RejectVacancy)
+}
+
+func (h *adminHandlers) SignIn(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request signInRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	adminWithTokenResult, err := h.adminService.SignIn(ctx, port.SignInAdminData{
+		Login:    request.Login,
+		Password: request.Password,
+		IP:       c.ClientIP(),
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error during sign in", "err", err)
+
+		var throttleErr *domain.ThrottleError
+		switch {
+		case errors.As(err, &throttleErr):
+			writeTooManyRequests(c, throttleErr)
+		case errors.Is(err, domain.ErrUnauthorized):
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	if adminWithTokenResult.TwoFactorToken != "" {
+		c.JSON(http.StatusOK, newTwoFactorChallengeResponse(adminWithTokenResult.TwoFactorToken))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, adminWithTokenResponse{
+		ID:           adminWithTokenResult.ID,
+		Login:        adminWithTokenResult.Login,
+		Token:        adminWithTokenResult.Token,
+		RefreshToken: adminWithTokenResult.RefreshToken,
+	})
+}
+
+func (h *adminHandlers) ListCompanies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var query adminCompaniesQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return
+	}
+
+	page, err := query.pageRequest(domain.CompanySortFields)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing sort", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid sort"})
+
+		return
+	}
+
+	companies, err := h.companyService.List(ctx, actor, domain.CompanyFilter{
+		Approved: query.Approved,
+		Page:     page,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing companies", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(companies, func(r port.CompanyResult) companyResponse {
+		return newCompanyResponse(&r)
+	}))
+}
+
+func (h *adminHandlers) ApproveCompany(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, _ string) error {
+		return h.companyService.Approve(c.Request.Context(), actor, id)
+	}, false)
+}
+
+func (h *adminHandlers) RejectCompany(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, reason string) error {
+		return h.companyService.Reject(c.Request.Context(), actor, id, reason)
+	}, true)
+}
+
+func (h *adminHandlers) RevokeCompanyApproval(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, reason string) error {
+		return h.companyService.RevokeApproval(c.Request.Context(), actor, id, reason)
+	}, true)
+}
+
+func (h *adminHandlers) ListUniversities(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var query adminUniversitiesQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return
+	}
+
+	page, err := query.pageRequest(domain.UniversitySortFields)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing sort", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid sort"})
+
+		return
+	}
+
+	universities, err := h.universityService.List(ctx, actor, domain.UniversityFilter{
+		Confirmed: query.Confirmed,
+		Page:      page,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing universities", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(universities, func(r port.UniversityResult) universityResponse {
+		return newUniversityResponse(&r)
+	}))
+}
+
+func (h *adminHandlers) ConfirmUniversity(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, _ string) error {
+		return h.universityService.Confirm(c.Request.Context(), actor, id)
+	}, false)
+}
+
+func (h *adminHandlers) RejectUniversity(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, reason string) error {
+		return h.universityService.Reject(c.Request.Context(), actor, id, reason)
+	}, true)
+}
+
+func (h *adminHandlers) RevokeUniversityConfirmation(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, reason string) error {
+		return h.universityService.RevokeConfirmation(c.Request.Context(), actor, id, reason)
+	}, true)
+}
+
+// moderate разбирает id из пути и, если withReason, причину из тела запроса,
+// после чего выполняет переход модерации.
+func (h *adminHandlers) ListVacancies(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var query adminVacanciesQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return
+	}
+
+	page, err := query.pageRequest(domain.VacancySortFields)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing sort", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid sort"})
+
+		return
+	}
+
+	filter := domain.VacancyFilter{Page: page}
+	if query.State != "" {
+		state, err := domain.ParseVacancyState(query.State)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "error parsing vacancy state", "err", err)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy state"})
+
+			return
+		}
+		filter.State = &state
+	}
+
+	vacancies, err := h.vacancyService.ListForModeration(ctx, actor, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing vacancies", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(vacancies, newVacancyResponse))
+}
+
+func (h *adminHandlers) PublishVacancy(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, _ string) error {
+		_, err := h.vacancyService.Publish(c.Request.Context(), actor, id)
+		return err
+	}, false)
+}
+
+func (h *adminHandlers) RejectVacancy(c *gin.Context) {
+	h.moderate(c, func(actor domain.Actor, id uuid.UUID, reason string) error {
+		_, err := h.vacancyService.Reject(c.Request.Context(), actor, id, reason)
+		return err
+	}, true)
+}
+
+func (h *adminHandlers) moderate(
+	c *gin.Context,
+	transition func(actor domain.Actor, id uuid.UUID, reason string) error,
+	withReason bool,
+) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid id"})
+
+		return
+	}
+
+	var request moderationReasonRequest
+
+	if withReason {
+		err = c.ShouldBindJSON(&request)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+			return
+		}
+
+		err = h.validator.StructCtx(ctx, request)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "error validating body", "err", err)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+			return
+		}
+	}
+
+	err = transition(actor, id, request.Reason)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error during moderation", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *adminHandlers) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "not found"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "transition is not allowed in current state"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
@@ -0,0 +1,226 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	apiKeyHandlers struct {
+		apiKeyService port.APIKeyService
+		logger        *slog.Logger
+		validator     *validator.Validate
+	}
+
+	apiKeyResponse struct {
+		ID         uuid.UUID  `json:"id"`
+		Name       string     `json:"name"`
+		Prefix     string     `json:"prefix"`
+		Scopes     []string   `json:"scopes"`
+		LastUsedAt *time.Time `json:"last_used_at"`
+		CreatedAt  time.Time  `json:"created_at"`
+		RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	}
+
+	apiKeyWithSecretResponse struct {
+		apiKeyResponse
+		Key string `json:"key"`
+	}
+
+	createAPIKeyRequest struct {
+		Name   string   `json:"name" validate:"required,max=128"`
+		Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+	}
+)
+
+func RegisterAPIKeyHandlers(
+	engine *gin.Engine,
+	apiKeyService port.APIKeyService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := apiKeyHandlers{apiKeyService, logger, validator}
+
+	group := engine.Group("/universities/me/api-keys", auth.Authenticate(), auth.UniversityOnly())
+	group.GET("", handlers.List)
+	group.POST("", handlers.Create)
+	group.POST("/:id/rotate", handlers.Rotate)
+	group.DELETE("/:id", handlers.Revoke)
+}
+
+func (h *apiKeyHandlers) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	keys, err := h.apiKeyService.List(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing api keys", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	response := make([]apiKeyResponse, 0, len(keys))
+	for i := range keys {
+		response = append(response, newAPIKeyResponse(&keys[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *apiKeyHandlers) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request createAPIKeyRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	scopes := make([]domain.Permission, 0, len(request.Scopes))
+	for _, scope := range request.Scopes {
+		scopes = append(scopes, domain.Permission(scope))
+	}
+
+	key, err := h.apiKeyService.Create(ctx, actor, port.CreateAPIKeyData{
+		Name:   request.Name,
+		Scopes: scopes,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error creating api key", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newAPIKeyWithSecretResponse(key))
+}
+
+func (h *apiKeyHandlers) Rotate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid id"})
+
+		return
+	}
+
+	key, err := h.apiKeyService.Rotate(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error rotating api key", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newAPIKeyWithSecretResponse(key))
+}
+
+func (h *apiKeyHandlers) Revoke(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid id"})
+
+		return
+	}
+
+	err = h.apiKeyService.Revoke(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error revoking api key", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *apiKeyHandlers) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "api key not found"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "api key is revoked or key limit reached"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid api key data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+func newAPIKeyResponse(result *port.APIKeyResult) apiKeyResponse {
+	scopes := make([]string, 0, len(result.Scopes))
+	for _, scope := range result.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return apiKeyResponse{
+		ID:         result.ID,
+		Name:       result.Name,
+		Prefix:     result.Prefix,
+		Scopes:     scopes,
+		LastUsedAt: result.LastUsedAt,
+		CreatedAt:  result.CreatedAt,
+		RevokedAt:  result.RevokedAt,
+	}
+}
+
+func newAPIKeyWithSecretResponse(result *port.APIKeyWithSecretResult) apiKeyWithSecretResponse {
+	return apiKeyWithSecretResponse{
+		apiKeyResponse: newAPIKeyResponse(&result.APIKeyResult),
+		Key:            result.Key,
+	}
+}
@@ -0,0 +1,191 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	auditHandlers struct {
+		auditService port.AuditService
+		logger       *slog.Logger
+		validator    *validator.Validate
+	}
+
+	// auditEventsQuery — фильтры журнала; from и to в RFC 3339, to не включается
+	auditEventsQuery struct {
+		pageQuery
+		ActorID    string `form:"actor_id" validate:"omitempty,uuid"`
+		TargetType string `form:"target_type" validate:"max=32"`
+		TargetID   string `form:"target_id" validate:"omitempty,uuid"`
+		From       string `form:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+		To         string `form:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	}
+
+	auditEventResponse struct {
+		ID         uuid.UUID      `json:"id"`
+		OccurredAt time.Time      `json:"occurred_at"`
+		ActorID    *uuid.UUID     `json:"actor_id"`
+		ActorRole  string         `json:"actor_role"`
+		APIKeyID   *uuid.UUID     `json:"api_key_id"`
+		Action     string         `json:"action"`
+		TargetType string         `json:"target_type"`
+		TargetID   *uuid.UUID     `json:"target_id"`
+		Before     map[string]any `json:"before"`
+		After      map[string]any `json:"after"`
+		IP         string         `json:"ip"`
+		UserAgent  string         `json:"user_agent"`
+	}
+)
+
+func RegisterAuditHandlers(
+	engine *gin.Engine,
+	auditService port.AuditService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := auditHandlers{auditService, logger, validator}
+
+	engine.GET("/admin/audit-events", auth.Authenticate(), auth.AdminOnly(), handlers.List)
+}
+
+func (h *auditHandlers) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var query auditEventsQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return
+	}
+
+	filter, err := query.filter()
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing audit filter", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid audit filter"})
+
+		return
+	}
+
+	events, err := h.auditService.List(ctx, actor, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing audit events", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(events, newAuditEventResponse))
+}
+
+func (h *auditHandlers) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid audit filter"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+// filter переводит провалидированные параметры в фильтр журнала. Журнал
+// всегда отдаётся от новых событий к старым, поэтому sort не принимается.
+func (q auditEventsQuery) filter() (domain.AuditEventFilter, error) {
+	page, err := q.pageRequest(nil)
+	if err != nil {
+		return domain.AuditEventFilter{}, err
+	}
+
+	f := domain.AuditEventFilter{Page: page}
+
+	if q.ActorID != "" {
+		id, err := uuid.Parse(q.ActorID)
+		if err != nil {
+			return domain.AuditEventFilter{}, err
+		}
+		f.ActorID = &id
+	}
+	if q.TargetType != "" {
+		targetType, err := domain.ParseAuditTargetType(q.TargetType)
+		if err != nil {
+			return domain.AuditEventFilter{}, err
+		}
+		f.TargetType = &targetType
+	}
+	if q.TargetID != "" {
+		id, err := uuid.Parse(q.TargetID)
+		if err != nil {
+			return domain.AuditEventFilter{}, err
+		}
+		f.TargetID = &id
+	}
+	if q.From != "" {
+		from, err := time.Parse(time.RFC3339, q.From)
+		if err != nil {
+			return domain.AuditEventFilter{}, err
+		}
+		f.From = &from
+	}
+	if q.To != "" {
+		to, err := time.Parse(time.RFC3339, q.To)
+		if err != nil {
+			return domain.AuditEventFilter{}, err
+		}
+		f.To = &to
+	}
+
+	return f, nil
+}
+
+func newAuditEventResponse(result port.AuditEventResult) auditEventResponse {
+	return auditEventResponse{
+		ID:         result.ID,
+		OccurredAt: result.OccurredAt,
+		ActorID:    optionalID(result.ActorID),
+		ActorRole:  result.ActorRole,
+		APIKeyID:   optionalID(result.APIKeyID),
+		Action:     string(result.Action),
+		TargetType: string(result.TargetType),
+		TargetID:   optionalID(result.TargetID),
+		Before:     result.Before,
+		After:      result.After,
+		IP:         result.IP,
+		UserAgent:  result.UserAgent,
+	}
+}
+
+// optionalID отдаёт пустой идентификатор как null.
+func optionalID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
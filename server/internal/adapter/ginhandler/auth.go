@@ -0,0 +1,346 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	authHandlers struct {
+		sessionService       port.SessionService
+		passwordResetService port.PasswordResetService
+		emailVerification    port.EmailVerificationService
+		keyPublisher         port.KeyPublisher
+		logger               *slog.Logger
+		validator            *validator.Validate
+	}
+
+	refreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" validate:"required,max=256"`
+	}
+
+	passwordResetRequest struct {
+		Role  string `json:"role" validate:"required,oneof=company university"`
+		Login string `json:"login" validate:"required"`
+	}
+
+	confirmPasswordResetRequest struct {
+		Token    string `json:"token" validate:"required,max=256"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	emailVerificationRequest struct {
+		// Пустой — отправить ссылку повторно на текущий адрес
+		Email string `json:"email" validate:"omitempty,email,max=254"`
+	}
+
+	confirmEmailRequest struct {
+		Token string `json:"token" validate:"required,max=256"`
+	}
+
+	tokensResponse struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	jwkResponse struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+	}
+
+	jwksResponse struct {
+		Keys []jwkResponse `json:"keys"`
+	}
+)
+
+func RegisterAuthHandlers(
+	engine *gin.Engine,
+	sessionService port.SessionService,
+	passwordResetService port.PasswordResetService,
+	emailVerification port.EmailVerificationService,
+	keyPublisher port.KeyPublisher,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := authHandlers{
+		sessionService,
+		passwordResetService,
+		emailVerification,
+		keyPublisher,
+		logger,
+		validator,
+	}
+
+	engine.POST("/auth/refresh", handlers.Refresh)
+	engine.POST("/auth/logout", handlers.Logout)
+	engine.POST("/auth/password-reset", handlers.RequestPasswordReset)
+	engine.POST("/auth/password-reset/confirm", handlers.ConfirmPasswordReset)
+	engine.POST("/auth/email-verification", auth.Authenticate(), handlers.RequestEmailVerification)
+	engine.POST("/auth/email-verification/confirm", handlers.ConfirmEmail)
+	engine.GET("/.well-known/jwks.json", handlers.JWKS)
+}
+
+func (h *authHandlers) Refresh(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	request, ok := h.bindRefreshToken(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.sessionService.Refresh(ctx, request.RefreshToken)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error refreshing session", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokensResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+func (h *authHandlers) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	request, ok := h.bindRefreshToken(c)
+	if !ok {
+		return
+	}
+
+	err := h.sessionService.Logout(ctx, request.RefreshToken)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error during logout", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *authHandlers) RequestPasswordReset(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request passwordResetRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.passwordResetService.Request(ctx, port.RequestPasswordResetData{
+		Role:  port.Role(request.Role),
+		Login: request.Login,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error requesting password reset", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	// Ответ не зависит от того, найден ли аккаунт
+	c.Status(http.StatusAccepted)
+}
+
+func (h *authHandlers) ConfirmPasswordReset(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request confirmPasswordResetRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.passwordResetService.Confirm(ctx, port.ConfirmPasswordResetData{
+		Token:       request.Token,
+		NewPassword: request.Password,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error confirming password reset", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *authHandlers) RequestEmailVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request emailVerificationRequest
+
+	// Тело необязательно
+	if c.Request.ContentLength != 0 {
+		err := c.ShouldBindJSON(&request)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+			return
+		}
+	}
+
+	err := h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.emailVerification.Request(ctx, actor, request.Email)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error requesting email verification", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *authHandlers) ConfirmEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request confirmEmailRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.emailVerification.Confirm(ctx, request.Token)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error confirming email", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *authHandlers) JWKS(c *gin.Context) {
+	publicKeys := h.keyPublisher.PublicKeys()
+
+	response := jwksResponse{Keys: make([]jwkResponse, 0, len(publicKeys))}
+	for _, k := range publicKeys {
+		response.Keys = append(response.Keys, jwkResponse{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Alg: k.Alg,
+			Use: "sig",
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		})
+	}
+
+	// Ключи меняются только при перезапуске, клиентам можно их кешировать
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *authHandlers) bindRefreshToken(c *gin.Context) (refreshTokenRequest, bool) {
+	ctx := c.Request.Context()
+
+	var request refreshTokenRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return refreshTokenRequest{}, false
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return refreshTokenRequest{}, false
+	}
+
+	return request, true
+}
+
+func (h *authHandlers) writeError(c *gin.Context, err error) {
+	var policyErr *domain.PasswordPolicyError
+	switch {
+	case errors.As(err, &policyErr):
+		writePasswordPolicyError(c, policyErr)
+	case errors.Is(err, domain.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "not found"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "email is already verified"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
@@ -0,0 +1,367 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	companyHandlers struct {
+		companyService port.CompanyService
+		logger         *slog.Logger
+		validator      *validator.Validate
+	}
+
+	companyResponse struct {
+		ID              uuid.UUID `json:"id"`
+		Title           string    `json:"title"`
+		INN             string    `json:"inn"`
+		Description     string    `json:"description"`
+		Contacts        string    `json:"contacts"`
+		Address         string    `json:"address"`
+		LogoURL         string    `json:"logo_url"`
+		Email           string    `json:"email"`
+		EmailVerified   bool      `json:"email_verified"`
+		Approved        bool      `json:"approved"`
+		RejectionReason string    `json:"rejection_reason,omitempty"`
+	}
+
+	companyWithTokenResponse struct {
+		companyResponse
+		Member       companyMemberResponse `json:"member"`
+		Token        string                `json:"token"`
+		RefreshToken string                `json:"refresh_token"`
+	}
+
+	companySignUpRequest struct {
+		Login       string `json:"login" validate:"required"`
+		Password    string `json:"password" validate:"required"`
+		Title       string `json:"title" validate:"required"`
+		INN         string `json:"inn" validate:"required"`
+		Description string `json:"description"`
+		Contacts    string `json:"contacts"`
+		Address     string `json:"address"`
+		LogoURL     string `json:"logo_url" validate:"omitempty,url,max=2048"`
+		Email       string `json:"email" validate:"required,email,max=254"`
+		// Имя владельца, который регистрирует компанию
+		OwnerName string `json:"owner_name" validate:"omitempty,max=256"`
+	}
+
+	updateCompanyProfileRequest struct {
+		Title       string `json:"title" validate:"omitempty,max=512"`
+		Description string `json:"description"`
+		Contacts    string `json:"contacts"`
+		Address     string `json:"address"`
+		LogoURL     string `json:"logo_url" validate:"omitempty,url,max=2048"`
+	}
+
+	changeCompanyCredentialsRequest struct {
+		Login    string `json:"login" validate:"required_without=Password"`
+		Password string `json:"password" validate:"required_without=Login"`
+	}
+)
+
+func RegisterCompanyHandlers(
+	engine *gin.Engine,
+	companyService port.CompanyService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := companyHandlers{companyService, logger, validator}
+
+	group := engine.Group("/companies")
+	group.POST("/sign-in", handlers.SignIn)
+	group.POST("/sign-up", handlers.SignUp)
+
+	me := group.Group("/me", auth.Authenticate(), auth.CompanyOnly())
+	me.GET("", handlers.GetProfile)
+	me.PUT("", handlers.UpdateProfile)
+	me.PUT("/credentials", handlers.ChangeCredentials)
+}
+
+func (h *companyHandlers) SignIn(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request signInRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	companyWithTokenResult, err := h.companyService.SignIn(ctx, port.SignInCompanyData{
+		Login:    request.Login,
+		Password: request.Password,
+		IP:       c.ClientIP(),
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error during sign in", "err", err)
+
+		var (
+			moderationErr *domain.ModerationError
+			throttleErr   *domain.ThrottleError
+		)
+		switch {
+		case errors.As(err, &throttleErr):
+			writeTooManyRequests(c, throttleErr)
+		case errors.Is(err, domain.ErrUnauthorized):
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		case errors.As(err, &moderationErr):
+			c.JSON(http.StatusForbidden, gin.H{
+				"message":          "company is not approved",
+				"rejection_reason": moderationErr.Reason,
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	if companyWithTokenResult.TwoFactorToken != "" {
+		c.JSON(http.StatusOK, newTwoFactorChallengeResponse(companyWithTokenResult.TwoFactorToken))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newCompanyWithTokenResponse(companyWithTokenResult))
+}
+
+func (h *companyHandlers) SignUp(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request companySignUpRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	companyWithTokenResult, err := h.companyService.SignUp(ctx, port.SignUpCompanyData{
+		Login:       request.Login,
+		Password:    request.Password,
+		Title:       request.Title,
+		INN:         request.INN,
+		Description: request.Description,
+		Contacts:    request.Contacts,
+		Address:     request.Address,
+		LogoURL:     request.LogoURL,
+		Email:       request.Email,
+		OwnerName:   request.OwnerName,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error during sign up", "err", err)
+
+		var policyErr *domain.PasswordPolicyError
+		switch {
+		case errors.As(err, &policyErr):
+			writePasswordPolicyError(c, policyErr)
+		case errors.Is(err, domain.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"message": "login or inn already taken"})
+		case errors.Is(err, domain.ErrInvariantViolated):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid company data"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newCompanyWithTokenResponse(companyWithTokenResult))
+}
+
+func (h *companyHandlers) GetProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	companyResult, err := h.companyService.GetProfile(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting company profile", "err", err)
+
+		switch {
+		case errors.Is(err, domain.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "company not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newCompanyResponse(companyResult))
+}
+
+func (h *companyHandlers) UpdateProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request updateCompanyProfileRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	companyResult, err := h.companyService.UpdateProfile(ctx, actor, port.UpdateCompanyProfileData{
+		Title:       request.Title,
+		Description: request.Description,
+		Contacts:    request.Contacts,
+		Address:     request.Address,
+		LogoURL:     request.LogoURL,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error updating company profile", "err", err)
+
+		switch {
+		case errors.Is(err, domain.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "company not found"})
+		case errors.Is(err, domain.ErrInvariantViolated):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid company data"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newCompanyResponse(companyResult))
+}
+
+func (h *companyHandlers) ChangeCredentials(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request changeCompanyCredentialsRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.companyService.ChangeCredentials(ctx, actor, port.ChangeCompanyCredentialsData{
+		Login:    request.Login,
+		Password: request.Password,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error changing company credentials", "err", err)
+
+		var policyErr *domain.PasswordPolicyError
+		switch {
+		case errors.As(err, &policyErr):
+			writePasswordPolicyError(c, policyErr)
+		case errors.Is(err, domain.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "company not found"})
+		case errors.Is(err, domain.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"message": "login already taken"})
+		case errors.Is(err, domain.ErrInvariantViolated):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid credentials data"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func newCompanyResponse(result *port.CompanyResult) companyResponse {
+	return companyResponse{
+		ID:              result.ID,
+		Title:           result.Title,
+		INN:             result.INN,
+		Description:     result.Description,
+		Contacts:        result.Contacts,
+		Address:         result.Address,
+		LogoURL:         result.LogoURL,
+		Email:           result.Email,
+		EmailVerified:   result.EmailVerified,
+		Approved:        result.Approved,
+		RejectionReason: result.RejectionReason,
+	}
+}
+
+func newCompanyWithTokenResponse(result *port.CompanyWithTokenResult) companyWithTokenResponse {
+	return companyWithTokenResponse{
+		companyResponse: newCompanyResponse(&result.CompanyResult),
+		Member:          newCompanyMemberResponse(&result.Member),
+		Token:           result.Token,
+		RefreshToken:    result.RefreshToken,
+	}
+}
@@ -0,0 +1,379 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	companyMemberHandlers struct {
+		memberService port.CompanyMemberService
+		logger        *slog.Logger
+		validator     *validator.Validate
+	}
+
+	companyMemberResponse struct {
+		ID            uuid.UUID `json:"id"`
+		CompanyID     uuid.UUID `json:"company_id"`
+		Role          string    `json:"role"`
+		Name          string    `json:"name"`
+		Email         string    `json:"email"`
+		EmailVerified bool      `json:"email_verified"`
+		Login         string    `json:"login"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+
+	companyMemberWithTokenResponse struct {
+		companyMemberResponse
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	companyInvitationResponse struct {
+		ID        uuid.UUID `json:"id"`
+		Email     string    `json:"email"`
+		Role      string    `json:"role"`
+		InvitedBy uuid.UUID `json:"invited_by"`
+		ExpiresAt time.Time `json:"expires_at"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	inviteCompanyMemberRequest struct {
+		Email string `json:"email" validate:"required,email,max=254"`
+		Role  string `json:"role" validate:"required,oneof=owner hiring_manager recruiter"`
+	}
+
+	acceptCompanyInvitationRequest struct {
+		Token    string `json:"token" validate:"required,max=256"`
+		Name     string `json:"name" validate:"max=256"`
+		Login    string `json:"login" validate:"required"`
+		Password string `json:"password" validate:"required"`
+	}
+
+	changeCompanyMemberRoleRequest struct {
+		Role string `json:"role" validate:"required,oneof=owner hiring_manager recruiter"`
+	}
+)
+
+func RegisterCompanyMemberHandlers(
+	engine *gin.Engine,
+	memberService port.CompanyMemberService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := companyMemberHandlers{memberService, logger, validator}
+
+	group := engine.Group("/companies")
+	group.POST("/invitations/accept", handlers.AcceptInvitation)
+
+	// Права на просмотр и управление командой проверяет сервис
+	me := group.Group("/me", auth.Authenticate(), auth.CompanyOnly())
+	me.GET("/members", handlers.ListMembers)
+	me.PUT("/members/:id/role", handlers.ChangeRole)
+	me.DELETE("/members/:id", handlers.RemoveMember)
+	me.GET("/invitations", handlers.ListInvitations)
+	me.POST("/invitations", handlers.Invite)
+	me.DELETE("/invitations/:id", handlers.RevokeInvitation)
+}
+
+func (h *companyMemberHandlers) ListMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	members, err := h.memberService.ListMembers(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing company members", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	response := make([]companyMemberResponse, 0, len(members))
+	for i := range members {
+		response = append(response, newCompanyMemberResponse(&members[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *companyMemberHandlers) Invite(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request inviteCompanyMemberRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	invitation, err := h.memberService.Invite(ctx, actor, port.InviteCompanyMemberData{
+		Email: request.Email,
+		Role:  domain.CompanyMemberRole(request.Role),
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error inviting company member", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newCompanyInvitationResponse(invitation))
+}
+
+func (h *companyMemberHandlers) ListInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	invitations, err := h.memberService.ListInvitations(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing company invitations", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	response := make([]companyInvitationResponse, 0, len(invitations))
+	for i := range invitations {
+		response = append(response, newCompanyInvitationResponse(&invitations[i]))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *companyMemberHandlers) RevokeInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid id"})
+
+		return
+	}
+
+	err = h.memberService.RevokeInvitation(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error revoking company invitation", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *companyMemberHandlers) AcceptInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request acceptCompanyInvitationRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	result, err := h.memberService.AcceptInvitation(ctx, port.AcceptCompanyInvitationData{
+		Token:    request.Token,
+		Name:     request.Name,
+		Login:    request.Login,
+		Password: request.Password,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error accepting company invitation", "err", err)
+
+		var moderationErr *domain.ModerationError
+		switch {
+		case errors.As(err, &moderationErr):
+			c.JSON(http.StatusForbidden, gin.H{
+				"message":          "company is not approved",
+				"rejection_reason": moderationErr.Reason,
+			})
+		case errors.Is(err, domain.ErrUnauthorized):
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "invitation is invalid, used or expired"})
+		case errors.Is(err, domain.ErrConflict):
+			c.JSON(http.StatusConflict, gin.H{"message": "login already taken"})
+		default:
+			h.writeError(c, err)
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, companyMemberWithTokenResponse{
+		companyMemberResponse: newCompanyMemberResponse(&result.CompanyMemberResult),
+		Token:                 result.Token,
+		RefreshToken:          result.RefreshToken,
+	})
+}
+
+func (h *companyMemberHandlers) ChangeRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid id"})
+
+		return
+	}
+
+	var request changeCompanyMemberRoleRequest
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	member, err := h.memberService.ChangeRole(ctx, actor, id, domain.CompanyMemberRole(request.Role))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error changing company member role", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newCompanyMemberResponse(member))
+}
+
+func (h *companyMemberHandlers) RemoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid id"})
+
+		return
+	}
+
+	err = h.memberService.RemoveMember(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error removing company member", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *companyMemberHandlers) writeError(c *gin.Context, err error) {
+	var policyErr *domain.PasswordPolicyError
+	switch {
+	case errors.As(err, &policyErr):
+		writePasswordPolicyError(c, policyErr)
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "not found"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "team state does not allow this action"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+func newCompanyMemberResponse(result *port.CompanyMemberResult) companyMemberResponse {
+	return companyMemberResponse{
+		ID:            result.ID,
+		CompanyID:     result.CompanyID,
+		Role:          string(result.Role),
+		Name:          result.Name,
+		Email:         result.Email,
+		EmailVerified: result.EmailVerified,
+		Login:         result.Login,
+		CreatedAt:     result.CreatedAt,
+	}
+}
+
+func newCompanyInvitationResponse(result *port.CompanyInvitationResult) companyInvitationResponse {
+	return companyInvitationResponse{
+		ID:        result.ID,
+		Email:     result.Email,
+		Role:      string(result.Role),
+		InvitedBy: result.InvitedBy,
+		ExpiresAt: result.ExpiresAt,
+		CreatedAt: result.CreatedAt,
+	}
+}
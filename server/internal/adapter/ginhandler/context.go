@@ -0,0 +1,18 @@
+package ginhandler
+
+import (
+	"context"
+
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type actorContextKey struct{}
+
+func contextWithActor(ctx context.Context, actor domain.Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+func actorFromContext(ctx context.Context) (domain.Actor, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(domain.Actor)
+	return actor, ok
+}
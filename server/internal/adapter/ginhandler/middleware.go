@@ -0,0 +1,216 @@
+package ginhandler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	apiKeyHeader = "X-API-Key"
+)
+
+type authMiddleware struct {
+	tokenService port.TokenService
+	apiKeys      port.APIKeyAuthenticator
+	twoFactor    port.TwoFactorService
+	logger       *slog.Logger
+}
+
+func NewAuthMiddleware(
+	tokenService port.TokenService,
+	apiKeys port.APIKeyAuthenticator,
+	twoFactor port.TwoFactorService,
+	logger *slog.Logger,
+) *authMiddleware {
+	return &authMiddleware{tokenService, apiKeys, twoFactor, logger}
+}
+
+// Authenticate проверяет bearer-токен или API-ключ из X-API-Key и кладёт
+// domain.Actor в контекст запроса. Запросы без валидного токена или ключа
+// отклоняются с 401.
+func (m *authMiddleware) Authenticate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if key := c.GetHeader(apiKeyHeader); key != "" {
+			actor, err := m.apiKeys.Authenticate(ctx, key)
+			if err != nil {
+				m.logger.InfoContext(ctx, "error validating api key", "err", err)
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+				return
+			}
+
+			c.Request = c.Request.WithContext(contextWithActor(ctx, actor))
+			c.Next()
+
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+			return
+		}
+
+		payload, err := m.tokenService.Validate(ctx, strings.TrimPrefix(header, bearerPrefix))
+		if err != nil {
+			m.logger.InfoContext(ctx, "error validating auth token", "err", err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+			return
+		}
+
+		actor, ok := actorFromTokenPayload(payload)
+		if !ok {
+			m.logger.InfoContext(ctx, "unknown role in auth token", "role", payload.Role)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+			return
+		}
+
+		// Токены компании, выданные до появления сотрудников, не знают компанию;
+		// клиент получит новый через refresh
+		if actor.Role == domain.RoleCompany && actor.CompanyID == uuid.Nil {
+			m.logger.InfoContext(ctx, "company auth token without company id")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+			return
+		}
+
+		c.Request = c.Request.WithContext(contextWithActor(ctx, actor))
+		c.Next()
+	}
+}
+
+func (m *authMiddleware) CompanyOnly() gin.HandlerFunc {
+	return requireRole(port.RoleCompany)
+}
+
+func (m *authMiddleware) UniversityOnly() gin.HandlerFunc {
+	return requireRole(port.RoleUniversity)
+}
+
+// AdminOnly также не пускает администратора без 2FA, если она обязательна;
+// настроить её можно через /auth/two-factor, куда эта проверка не ставится.
+func (m *authMiddleware) AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		actor, ok := checkRole(c, port.RoleAdmin)
+		if !ok {
+			return
+		}
+
+		satisfied, err := m.twoFactor.Satisfied(ctx, actor)
+		if err != nil {
+			m.logger.ErrorContext(ctx, "error checking two-factor requirement", "err", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+
+			return
+		}
+		if !satisfied {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "two-factor authentication must be enabled"})
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func requireRole(role port.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := checkRole(c, role); !ok {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// checkRole прерывает запрос с 401 или 403, если актор не в роли role.
+func checkRole(c *gin.Context, role port.Role) (domain.Actor, bool) {
+	expected, _ := actorFromTokenPayload(port.TokenPayload{Role: role})
+
+	actor, ok := actorFromContext(c.Request.Context())
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return domain.Actor{}, false
+	}
+
+	if actor.Role != expected.Role {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+
+		return domain.Actor{}, false
+	}
+
+	return actor, true
+}
+
+func actorFromTokenPayload(payload port.TokenPayload) (domain.Actor, bool) {
+	actor := domain.Actor{ID: payload.Sub}
+
+	switch payload.Role {
+	case port.RoleCompany:
+		actor.Role = domain.RoleCompany
+		actor.CompanyID = payload.CompanyID
+	case port.RoleUniversity:
+		actor.Role = domain.RoleUniversity
+	case port.RoleAdmin:
+		actor.Role = domain.RoleAdmin
+	default:
+		return domain.Actor{}, false
+	}
+
+	return actor, true
+}
+
+// maxUserAgentLength — сколько байт User-Agent попадает в журнал аудита.
+const maxUserAgentLength = 512
+
+// NewEngine создаёт gin с заданными доверенными прокси и только затем
+// подключает requestMeta: без списка прокси gin верит X-Forwarded-For от
+// любого клиента, и в журнал аудита попал бы адрес, выбранный клиентом.
+// Пустой список — адрес берётся из соединения.
+func NewEngine(trustedProxies []string) (*gin.Engine, error) {
+	engine := gin.Default()
+
+	err := engine.SetTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("error setting trusted proxies: %w", err)
+	}
+
+	engine.Use(requestMeta())
+
+	return engine, nil
+}
+
+// requestMeta кладёт в контекст адрес клиента и User-Agent, откуда их берёт
+// журнал аудита.
+func requestMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userAgent := c.Request.UserAgent()
+		if len(userAgent) > maxUserAgentLength {
+			userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+		}
+
+		ctx := port.ContextWithRequestMeta(c.Request.Context(), port.RequestMeta{
+			IP:        c.ClientIP(),
+			UserAgent: userAgent,
+		})
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
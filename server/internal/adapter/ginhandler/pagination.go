@@ -0,0 +1,91 @@
+package ginhandler
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+const defaultPageSize = 20
+
+type (
+	// pageQuery — общие для всех списков параметры ?page=&size=&sort=
+	pageQuery struct {
+		Page int    `form:"page" validate:"omitempty,min=1"`
+		Size int    `form:"size" validate:"omitempty,min=1,max=100"`
+		Sort string `form:"sort" validate:"max=64"`
+	}
+
+	paginatedResponse[T any] struct {
+		Data       []T `json:"data"`
+		Page       int `json:"page"`
+		Size       int `json:"size"`
+		Total      int `json:"total"`
+		TotalPages int `json:"total_pages"`
+	}
+)
+
+// pageRequest проверяет sort по списку разрешённых полей и подставляет
+// значения по умолчанию.
+func (q pageQuery) pageRequest(allowed []domain.SortField) (domain.PageRequest, error) {
+	sort, err := domain.ParseSort(q.Sort, allowed)
+	if err != nil {
+		return domain.PageRequest{}, err
+	}
+
+	page := domain.PageRequest{Page: q.Page, Size: q.Size, Sort: sort}
+	if page.Page == 0 {
+		page.Page = 1
+	}
+	if page.Size == 0 {
+		page.Size = defaultPageSize
+	}
+
+	return page, nil
+}
+
+func newPaginatedResponse[T, R any](p port.Paginated[T], convert func(T) R) paginatedResponse[R] {
+	mapped := port.MapPaginated(p, convert)
+	return paginatedResponse[R]{
+		Data:       mapped.Data,
+		Page:       mapped.Page,
+		Size:       mapped.Size,
+		Total:      mapped.Total,
+		TotalPages: mapped.TotalPages,
+	}
+}
+
+// encodeCursor упаковывает позицию в непрозрачную для клиента строку.
+func encodeCursor(c domain.Cursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "," + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (domain.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return domain.Cursor{}, err
+	}
+
+	createdAtRaw, idRaw, ok := strings.Cut(string(raw), ",")
+	if !ok {
+		return domain.Cursor{}, errors.New("malformed cursor")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtRaw)
+	if err != nil {
+		return domain.Cursor{}, err
+	}
+
+	id, err := uuid.Parse(idRaw)
+	if err != nil {
+		return domain.Cursor{}, err
+	}
+
+	return domain.Cursor{CreatedAt: createdAt, ID: id}, nil
+}
@@ -0,0 +1,17 @@
+package ginhandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// writePasswordPolicyError отвечает 422 с кодами всех нарушенных правил,
+// чтобы клиент мог показать, что именно не так с паролем.
+func writePasswordPolicyError(c *gin.Context, policyErr *domain.PasswordPolicyError) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"message":    "password does not satisfy policy",
+		"violations": policyErr.Violations,
+	})
+}
@@ -0,0 +1,219 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	pipelineHandlers struct {
+		pipelineService port.PipelineService
+		logger          *slog.Logger
+		validator       *validator.Validate
+	}
+
+	// pipelineResponse — действующий конвейер; id и created_at пусты у
+	// встроенного, vacancy_id — у конвейера компании
+	pipelineResponse struct {
+		ID        *uuid.UUID         `json:"id"`
+		VacancyID *uuid.UUID         `json:"vacancy_id"`
+		Stages    []pipelineStageDTO `json:"stages"`
+		CreatedAt *time.Time         `json:"created_at"`
+	}
+
+	pipelineStageDTO struct {
+		Key  string `json:"key" validate:"required,max=32"`
+		Name string `json:"name" validate:"required,max=256"`
+		Type string `json:"type" validate:"required,oneof=new screening interview offer hired rejected withdrawn"`
+	}
+
+	setPipelineRequest struct {
+		Stages []pipelineStageDTO `json:"stages" validate:"required,min=3,max=20,dive"`
+	}
+)
+
+func RegisterPipelineHandlers(
+	engine *gin.Engine,
+	pipelineService port.PipelineService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := pipelineHandlers{pipelineService, logger, validator}
+
+	company := engine.Group("/companies/me/pipeline", auth.Authenticate(), auth.CompanyOnly())
+	company.GET("", handlers.Get)
+	company.PUT("", handlers.Set)
+	company.DELETE("", handlers.Reset)
+
+	vacancy := engine.Group("/vacancies/:id/pipeline", auth.Authenticate(), auth.CompanyOnly())
+	vacancy.GET("", handlers.Get)
+	vacancy.PUT("", handlers.Set)
+	vacancy.DELETE("", handlers.Reset)
+}
+
+func (h *pipelineHandlers) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	vacancyID, ok := pipelineVacancyID(c)
+	if !ok {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	pipeline, err := h.pipelineService.Get(ctx, actor, vacancyID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting pipeline", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPipelineResponse(pipeline))
+}
+
+func (h *pipelineHandlers) Set(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	vacancyID, ok := pipelineVacancyID(c)
+	if !ok {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	var request setPipelineRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	stages := make([]domain.PipelineStage, 0, len(request.Stages))
+	for _, st := range request.Stages {
+		stages = append(stages, domain.PipelineStage{
+			Key:  st.Key,
+			Name: st.Name,
+			Type: domain.ResponseStatus(st.Type),
+		})
+	}
+
+	pipeline, err := h.pipelineService.Set(ctx, actor, vacancyID, stages)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error setting pipeline", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPipelineResponse(pipeline))
+}
+
+func (h *pipelineHandlers) Reset(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	vacancyID, ok := pipelineVacancyID(c)
+	if !ok {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	err := h.pipelineService.Reset(ctx, actor, vacancyID)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error resetting pipeline", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *pipelineHandlers) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "not found"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid pipeline"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+// pipelineVacancyID читает вакансию из пути; у маршрутов компании её нет,
+// и возвращается uuid.Nil.
+func pipelineVacancyID(c *gin.Context) (uuid.UUID, bool) {
+	param := c.Param("id")
+	if param == "" {
+		return uuid.Nil, true
+	}
+
+	id, err := uuid.Parse(param)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func newPipelineResponse(p *domain.Pipeline) pipelineResponse {
+	pi := p.Immutable()
+
+	stages := make([]pipelineStageDTO, 0, len(pi.Stages))
+	for _, st := range pi.Stages {
+		stages = append(stages, pipelineStageDTO{Key: st.Key, Name: st.Name, Type: string(st.Type)})
+	}
+
+	response := pipelineResponse{
+		ID:        optionalID(pi.ID),
+		VacancyID: optionalID(pi.VacancyID),
+		Stages:    stages,
+	}
+	if !pi.CreatedAt.IsZero() {
+		response.CreatedAt = &pi.CreatedAt
+	}
+	return response
+}
@@ -0,0 +1,357 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	responseHandlers struct {
+		responseService port.ResponseService
+		logger          *slog.Logger
+		validator       *validator.Validate
+	}
+
+	responseResponse struct {
+		ID          uuid.UUID  `json:"id"`
+		VacancyID   uuid.UUID  `json:"vacancy_id"`
+		FullName    string     `json:"full_name"`
+		Email       string     `json:"email"`
+		Phone       string     `json:"phone"`
+		CoverLetter string     `json:"cover_letter"`
+		ResumeURL   string     `json:"resume_url"`
+		Status      string     `json:"status"`
+		PipelineID  *uuid.UUID `json:"pipeline_id"`
+		Stage       string     `json:"stage"`
+		CreatedAt   time.Time  `json:"created_at"`
+		UpdatedAt   time.Time  `json:"updated_at"`
+	}
+
+	responseWithHistoryResponse struct {
+		responseResponse
+		History []responseStatusChangeResponse `json:"history"`
+	}
+
+	// responseStatusChangeResponse — запись истории; from и from_stage пусты
+	// у создания отклика, actor_id — у действий кандидата
+	responseStatusChangeResponse struct {
+		From      string     `json:"from"`
+		To        string     `json:"to"`
+		FromStage string     `json:"from_stage"`
+		ToStage   string     `json:"to_stage"`
+		ActorID   *uuid.UUID `json:"actor_id"`
+		ChangedAt time.Time  `json:"changed_at"`
+	}
+
+	createResponseRequest struct {
+		VacancyID   uuid.UUID `json:"vacancy_id" validate:"required"`
+		FullName    string    `json:"full_name" validate:"required,max=256"`
+		Email       string    `json:"email" validate:"required,email,max=256"`
+		Phone       string    `json:"phone" validate:"required,e164"`
+		CoverLetter string    `json:"cover_letter" validate:"max=10000"`
+		ResumeURL   string    `json:"resume_url" validate:"omitempty,url"`
+	}
+
+	// setResponseStatusRequest — прежний запрос смены этапа по его типу
+	setResponseStatusRequest struct {
+		Status string `json:"status" validate:"required,oneof=new screening interview offer hired rejected withdrawn"`
+	}
+
+	// moveResponseStageRequest — ключ этапа из конвейера отклика
+	moveResponseStageRequest struct {
+		Stage string `json:"stage" validate:"required,max=32"`
+	}
+)
+
+func RegisterResponseHandlers(
+	engine *gin.Engine,
+	responseService port.ResponseService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := responseHandlers{responseService, logger, validator}
+
+	group := engine.Group("/responses")
+	group.POST("", handlers.Create)
+
+	company := group.Group("", auth.Authenticate(), auth.CompanyOnly())
+	company.GET("/vacancy/:vacancyId", handlers.ListByVacancy)
+	company.GET("/:id", handlers.Get)
+	company.PUT("/:id/status", handlers.SetStatus)
+	company.PUT("/:id/stage", handlers.MoveToStage)
+}
+
+func (h *responseHandlers) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request createResponseRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	response, err := h.responseService.Create(ctx, port.CreateResponseInput{
+		VacancyID:   request.VacancyID,
+		FullName:    request.FullName,
+		Email:       request.Email,
+		Phone:       request.Phone,
+		CoverLetter: request.CoverLetter,
+		ResumeURL:   request.ResumeURL,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error creating response", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newResponseResponse(response))
+}
+
+func (h *responseHandlers) ListByVacancy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	vacancyID, err := uuid.Parse(c.Param("vacancyId"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	var query pageQuery
+
+	err = c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return
+	}
+
+	page, err := query.pageRequest(domain.ResponseSortFields)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing sort", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid sort"})
+
+		return
+	}
+
+	responses, err := h.responseService.ListByVacancy(ctx, actor, vacancyID, page)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing responses", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(responses, newResponseResponse))
+}
+
+func (h *responseHandlers) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid response id"})
+
+		return
+	}
+
+	response, err := h.responseService.Get(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting response", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newResponseWithHistoryResponse(response))
+}
+
+func (h *responseHandlers) SetStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid response id"})
+
+		return
+	}
+
+	var request setResponseStatusRequest
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	response, err := h.responseService.SetStatus(ctx, actor, id, domain.ResponseStatus(request.Status))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error setting response status", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newResponseResponse(response))
+}
+
+func (h *responseHandlers) MoveToStage(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid response id"})
+
+		return
+	}
+
+	var request moveResponseStageRequest
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	response, err := h.responseService.MoveToStage(ctx, actor, id, request.Stage)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error moving response to stage", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newResponseResponse(response))
+}
+
+func (h *responseHandlers) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "not found"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "stage transition is not allowed"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid response data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+func newResponseResponse(r *domain.Response) responseResponse {
+	ri := r.Immutable()
+	return responseResponse{
+		ID:          ri.ID,
+		VacancyID:   ri.VacancyID,
+		FullName:    ri.FullName,
+		Email:       ri.Email,
+		Phone:       ri.Phone,
+		CoverLetter: ri.CoverLetter,
+		ResumeURL:   ri.ResumeURL,
+		Status:      string(ri.Status),
+		PipelineID:  optionalID(ri.PipelineID),
+		Stage:       ri.Stage,
+		CreatedAt:   ri.CreatedAt,
+		UpdatedAt:   ri.UpdatedAt,
+	}
+}
+
+func newResponseWithHistoryResponse(result *port.ResponseWithHistory) responseWithHistoryResponse {
+	history := make([]responseStatusChangeResponse, 0, len(result.History))
+	for _, change := range result.History {
+		ci := change.Immutable()
+		history = append(history, responseStatusChangeResponse{
+			From:      string(ci.From),
+			To:        string(ci.To),
+			FromStage: ci.FromStage,
+			ToStage:   ci.ToStage,
+			ActorID:   optionalID(ci.Actor.ID),
+			ChangedAt: ci.ChangedAt,
+		})
+	}
+
+	return responseWithHistoryResponse{
+		responseResponse: newResponseResponse(result.Response),
+		History:          history,
+	}
+}
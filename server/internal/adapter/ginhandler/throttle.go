@@ -0,0 +1,18 @@
+package ginhandler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// writeTooManyRequests отвечает 429 и сообщает в Retry-After, через сколько
+// секунд (с округлением вверх) можно повторить попытку.
+func writeTooManyRequests(c *gin.Context, throttleErr *domain.ThrottleError) {
+	seconds := int(math.Ceil(throttleErr.RetryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(max(seconds, 1)))
+	c.JSON(http.StatusTooManyRequests, gin.H{"message": "too many sign in attempts"})
+}
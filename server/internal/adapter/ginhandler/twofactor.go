@@ -0,0 +1,328 @@
+package ginhandler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type (
+	twoFactorHandlers struct {
+		twoFactorService port.TwoFactorService
+		logger           *slog.Logger
+		validator        *validator.Validate
+	}
+
+	twoFactorChallengeResponse struct {
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		TwoFactorToken    string `json:"two_factor_token"`
+	}
+
+	verifyTwoFactorRequest struct {
+		TwoFactorToken string `json:"two_factor_token" validate:"required,max=256"`
+		Code           string `json:"code" validate:"required,max=32"`
+	}
+
+	twoFactorCodeRequest struct {
+		Code string `json:"code" validate:"required,max=32"`
+	}
+
+	twoFactorStatusResponse struct {
+		Enabled           bool `json:"enabled"`
+		RecoveryCodesLeft int  `json:"recovery_codes_left"`
+	}
+
+	twoFactorEnrollmentResponse struct {
+		Secret     string `json:"secret"`
+		OTPAuthURI string `json:"otpauth_uri"`
+	}
+
+	recoveryCodesResponse struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+
+	twoFactorRequirementRequest struct {
+		Required *bool `json:"required" validate:"required"`
+	}
+
+	twoFactorRequirementResponse struct {
+		Required bool `json:"required"`
+	}
+)
+
+func RegisterTwoFactorHandlers(
+	engine *gin.Engine,
+	twoFactorService port.TwoFactorService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := twoFactorHandlers{twoFactorService, logger, validator}
+
+	engine.POST("/auth/two-factor/verify", handlers.Verify)
+
+	group := engine.Group("/auth/two-factor", auth.Authenticate())
+	group.GET("", handlers.Status)
+	group.POST("/enroll", handlers.Enroll)
+	group.POST("/confirm", handlers.Confirm)
+	group.POST("/recovery-codes", handlers.RegenerateRecoveryCodes)
+	group.DELETE("", handlers.Disable)
+
+	settings := engine.Group("/admin/settings/two-factor", auth.Authenticate(), auth.AdminOnly())
+	settings.GET("", handlers.GetAdminRequirement)
+	settings.PUT("", handlers.SetAdminRequirement)
+}
+
+func (h *twoFactorHandlers) Verify(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var request verifyTwoFactorRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	tokens, err := h.twoFactorService.Verify(ctx, port.VerifyTwoFactorData{
+		Token: request.TwoFactorToken,
+		Code:  request.Code,
+		IP:    c.ClientIP(),
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error verifying two-factor code", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, tokensResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+func (h *twoFactorHandlers) Status(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	status, err := h.twoFactorService.Status(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting two-factor status", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, twoFactorStatusResponse{
+		Enabled:           status.Enabled,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+	})
+}
+
+func (h *twoFactorHandlers) Enroll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	enrollment, err := h.twoFactorService.Enroll(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error enrolling two-factor", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, twoFactorEnrollmentResponse{
+		Secret:     enrollment.Secret,
+		OTPAuthURI: enrollment.URI,
+	})
+}
+
+func (h *twoFactorHandlers) Confirm(c *gin.Context) {
+	h.withCode(c, func(c *gin.Context, actor domain.Actor, code string) {
+		codes, err := h.twoFactorService.Confirm(c.Request.Context(), actor, code)
+		if err != nil {
+			h.logger.ErrorContext(c.Request.Context(), "error confirming two-factor", "err", err)
+			h.writeError(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+	})
+}
+
+func (h *twoFactorHandlers) RegenerateRecoveryCodes(c *gin.Context) {
+	h.withCode(c, func(c *gin.Context, actor domain.Actor, code string) {
+		codes, err := h.twoFactorService.RegenerateRecoveryCodes(c.Request.Context(), actor, code)
+		if err != nil {
+			h.logger.ErrorContext(c.Request.Context(), "error regenerating recovery codes", "err", err)
+			h.writeError(c, err)
+
+			return
+		}
+
+		c.JSON(http.StatusOK, recoveryCodesResponse{RecoveryCodes: codes})
+	})
+}
+
+func (h *twoFactorHandlers) Disable(c *gin.Context) {
+	h.withCode(c, func(c *gin.Context, actor domain.Actor, code string) {
+		err := h.twoFactorService.Disable(c.Request.Context(), actor, code)
+		if err != nil {
+			h.logger.ErrorContext(c.Request.Context(), "error disabling two-factor", "err", err)
+			h.writeError(c, err)
+
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	})
+}
+
+func (h *twoFactorHandlers) GetAdminRequirement(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	required, err := h.twoFactorService.AdminRequired(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting two-factor requirement", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, twoFactorRequirementResponse{Required: required})
+}
+
+func (h *twoFactorHandlers) SetAdminRequirement(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request twoFactorRequirementRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.twoFactorService.SetAdminRequired(ctx, actor, *request.Required)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error setting two-factor requirement", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// withCode разбирает тело {"code": "..."} запроса аутентифицированного
+// пользователя и передаёт код в next.
+func (h *twoFactorHandlers) withCode(c *gin.Context, next func(*gin.Context, domain.Actor, string)) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request twoFactorCodeRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	next(c, actor, request.Code)
+}
+
+func (h *twoFactorHandlers) writeError(c *gin.Context, err error) {
+	var throttleErr *domain.ThrottleError
+	switch {
+	case errors.As(err, &throttleErr):
+		writeTooManyRequests(c, throttleErr)
+	case errors.Is(err, domain.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "invalid two-factor code or token"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "two-factor is not enabled"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "two-factor state does not allow this action"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+func newTwoFactorChallengeResponse(token string) twoFactorChallengeResponse {
+	return twoFactorChallengeResponse{
+		TwoFactorRequired: true,
+		TwoFactorToken:    token,
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github.com/hr-platform-mosprom/internal/core/application/port"
 	"github.com/hr-platform-mosprom/internal/core/domain"
 )
@@ -18,11 +19,21 @@ type (
 		validator         *validator.Validate
 	}
 
+	universityResponse struct {
+		ID              uuid.UUID `json:"id"`
+		Login           string    `json:"login"`
+		Title           string    `json:"title"`
+		INN             string    `json:"inn"`
+		Email           string    `json:"email"`
+		EmailVerified   bool      `json:"email_verified"`
+		Confirmed       bool      `json:"confirmed"`
+		RejectionReason string    `json:"rejection_reason,omitempty"`
+	}
+
 	universityWithTokenResponse struct {
-		Login string `json:"login"`
-		Title string `json:"title"`
-		INN   string `json:"inn"`
-		Token string `json:"token"`
+		universityResponse
+		Token        string `json:"token"`
+		RefreshToken string `json:"refresh_token"`
 	}
 
 	signInRequest struct {
@@ -35,12 +46,19 @@ type (
 		Password string `json:"password" validate:"required"`
 		Title    string `json:"title" validate:"required"`
 		INN      string `json:"inn" validate:"required"`
+		Email    string `json:"email" validate:"required,email,max=254"`
+	}
+
+	changePasswordRequest struct {
+		CurrentPassword string `json:"current_password" validate:"required"`
+		NewPassword     string `json:"new_password" validate:"required"`
 	}
 )
 
 func RegisterUniversityHandlers(
 	engine *gin.Engine,
 	userService port.UniversityService,
+	auth *authMiddleware,
 	logger *slog.Logger,
 	validator *validator.Validate,
 ) {
@@ -49,6 +67,39 @@ func RegisterUniversityHandlers(
 	group := engine.Group("/universities")
 	group.POST("/sign-in", handlers.SingIn)
 	group.POST("/sign-up", handlers.SignUp)
+
+	me := group.Group("/me", auth.Authenticate(), auth.UniversityOnly())
+	me.GET("", handlers.GetProfile)
+	me.PUT("/password", handlers.ChangePassword)
+}
+
+func (h *universityHandlers) GetProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	university, err := h.universityService.GetProfile(ctx, actor)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting university profile", "err", err)
+
+		switch {
+		case errors.Is(err, domain.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+		case errors.Is(err, domain.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"message": "university not found"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newUniversityResponse(university))
 }
 
 func (h *universityHandlers) SingIn(c *gin.Context) {
@@ -75,13 +126,18 @@ func (h *universityHandlers) SingIn(c *gin.Context) {
 	universityWithTokenResult, err := h.universityService.SignIn(ctx, port.SignInUniversityData{
 		Login:    request.Login,
 		Password: request.Password,
+		IP:       c.ClientIP(),
 	})
 	if err != nil {
 		h.logger.ErrorContext(ctx, "error during sign in", "err", err)
 
-		if errors.Is(err, domain.ErrUnauthorized) {
+		var throttleErr *domain.ThrottleError
+		switch {
+		case errors.As(err, &throttleErr):
+			writeTooManyRequests(c, throttleErr)
+		case errors.Is(err, domain.ErrUnauthorized):
 			c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
-		} else {
+		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
 		}
 
@@ -89,10 +145,9 @@ func (h *universityHandlers) SingIn(c *gin.Context) {
 	}
 
 	response := universityWithTokenResponse{
-		Login: universityWithTokenResult.Login,
-		Title: universityWithTokenResult.Title,
-		INN:   universityWithTokenResult.INN,
-		Token: universityWithTokenResult.Token,
+		universityResponse: newUniversityResponse(&universityWithTokenResult.UniversityResult),
+		Token:              universityWithTokenResult.Token,
+		RefreshToken:       universityWithTokenResult.RefreshToken,
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -124,13 +179,20 @@ func (h *universityHandlers) SignUp(c *gin.Context) {
 		Password: request.Password,
 		Title:    request.Title,
 		INN:      request.INN,
+		Email:    request.Email,
 	})
 	if err != nil {
 		h.logger.ErrorContext(ctx, "error during sign up", "err", err)
 
-		if errors.Is(err, domain.ErrConflict) {
+		var policyErr *domain.PasswordPolicyError
+		switch {
+		case errors.As(err, &policyErr):
+			writePasswordPolicyError(c, policyErr)
+		case errors.Is(err, domain.ErrConflict):
 			c.JSON(http.StatusConflict, gin.H{"message": "login already taken"})
-		} else {
+		case errors.Is(err, domain.ErrInvariantViolated):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid university data"})
+		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
 		}
 
@@ -138,11 +200,78 @@ func (h *universityHandlers) SignUp(c *gin.Context) {
 	}
 
 	response := universityWithTokenResponse{
-		Login: universityWithTokenResult.Login,
-		Title: universityWithTokenResult.Title,
-		INN:   universityWithTokenResult.INN,
-		Token: universityWithTokenResult.Token,
+		universityResponse: newUniversityResponse(&universityWithTokenResult.UniversityResult),
+		Token:              universityWithTokenResult.Token,
+		RefreshToken:       universityWithTokenResult.RefreshToken,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+func (h *universityHandlers) ChangePassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request changePasswordRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	err = h.universityService.ChangePassword(ctx, actor, port.ChangeUniversityPasswordData{
+		CurrentPassword: request.CurrentPassword,
+		NewPassword:     request.NewPassword,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error changing password", "err", err)
+
+		var policyErr *domain.PasswordPolicyError
+		switch {
+		case errors.As(err, &policyErr):
+			writePasswordPolicyError(c, policyErr)
+		case errors.Is(err, domain.ErrUnauthorized):
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "wrong current password"})
+		case errors.Is(err, domain.ErrForbidden):
+			c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+		case errors.Is(err, domain.ErrInvariantViolated):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid new password"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+		}
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func newUniversityResponse(result *port.UniversityResult) universityResponse {
+	return universityResponse{
+		ID:              result.ID,
+		Login:           result.Login,
+		Title:           result.Title,
+		INN:             result.INN,
+		Email:           result.Email,
+		EmailVerified:   result.EmailVerified,
+		Confirmed:       result.Confirmed,
+		RejectionReason: result.RejectionReason,
+	}
+}
@@ -0,0 +1,626 @@
+package ginhandler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+const defaultSalaryCurrency = "RUB"
+
+type (
+	vacancyHandlers struct {
+		vacancyService port.VacancyService
+		logger         *slog.Logger
+		validator      *validator.Validate
+	}
+
+	vacancyResponse struct {
+		ID                uuid.UUID  `json:"id"`
+		CompanyID         uuid.UUID  `json:"company_id"`
+		Title             string     `json:"title"`
+		Description       string     `json:"description"`
+		Contacts          string     `json:"contacts"`
+		Requirements      string     `json:"requirements"`
+		Responsibilities  string     `json:"responsibilities"`
+		Conditions        string     `json:"conditions"`
+		Salary            *salaryDTO `json:"salary"`
+		Employment        string     `json:"employment"`
+		Schedule          string     `json:"schedule"`
+		Experience        string     `json:"experience"`
+		Education         string     `json:"education"`
+		Location          string     `json:"location"`
+		State             string     `json:"state"`
+		ModerationComment string     `json:"moderation_comment,omitempty"`
+		CreatedAt         time.Time  `json:"created_at"`
+		UpdatedAt         time.Time  `json:"updated_at"`
+	}
+
+	createVacancyRequest struct {
+		Title            string     `json:"title" validate:"required,max=512"`
+		Description      string     `json:"description" validate:"required"`
+		Contacts         string     `json:"contacts"`
+		Requirements     string     `json:"requirements"`
+		Responsibilities string     `json:"responsibilities"`
+		Conditions       string     `json:"conditions"`
+		Salary           *salaryDTO `json:"salary"`
+		Employment       string     `json:"employment"`
+		Schedule         string     `json:"schedule"`
+		Experience       string     `json:"experience"`
+		Education        string     `json:"education"`
+		Location         string     `json:"location"`
+	}
+
+	updateVacancyRequest struct {
+		Title            string     `json:"title" validate:"omitempty,max=512"`
+		Description      string     `json:"description"`
+		Contacts         string     `json:"contacts"`
+		Requirements     string     `json:"requirements"`
+		Responsibilities string     `json:"responsibilities"`
+		Conditions       string     `json:"conditions"`
+		Salary           *salaryDTO `json:"salary"`
+		Employment       string     `json:"employment"`
+		Schedule         string     `json:"schedule"`
+		Experience       string     `json:"experience"`
+		Education        string     `json:"education"`
+		Location         string     `json:"location"`
+	}
+
+	salaryDTO struct {
+		Min      *int   `json:"min" validate:"omitempty,min=0"`
+		Max      *int   `json:"max" validate:"omitempty,min=0"`
+		Currency string `json:"currency" validate:"omitempty,iso4217"`
+		Gross    bool   `json:"gross"`
+	}
+
+	publicVacanciesQuery struct {
+		pageQuery
+		Location   string `form:"location"`
+		Employment string `form:"employment"`
+		Schedule   string `form:"schedule"`
+		Experience string `form:"experience"`
+		Education  string `form:"education"`
+		// Ожидаемая зарплата соискателя
+		Salary         *int   `form:"salary" validate:"omitempty,min=0"`
+		SalaryCurrency string `form:"salary_currency" validate:"omitempty,iso4217"`
+		// Наличие параметра, даже пустого, включает режим курсора
+		Cursor string `form:"cursor" validate:"max=256"`
+	}
+
+	vacancyFeedResponse struct {
+		Data       []vacancyResponse `json:"data"`
+		NextCursor *string           `json:"next_cursor"`
+	}
+)
+
+func RegisterVacancyHandlers(
+	engine *gin.Engine,
+	vacancyService port.VacancyService,
+	auth *authMiddleware,
+	logger *slog.Logger,
+	validator *validator.Validate,
+) {
+	handlers := vacancyHandlers{vacancyService, logger, validator}
+
+	group := engine.Group("/vacancies", auth.Authenticate(), auth.CompanyOnly())
+	group.POST("", handlers.Create)
+	group.GET("/my", handlers.ListMy)
+	group.GET("/:id", handlers.Get)
+	group.PUT("/:id", handlers.Update)
+	group.POST("/:id/submit", handlers.Submit)
+	group.POST("/:id/restore", handlers.Restore)
+	group.DELETE("/:id", handlers.Archive)
+
+	public := engine.Group("/public/vacancies")
+	public.GET("", handlers.SearchPublished)
+	public.GET("/:id", handlers.GetPublished)
+
+	// Лента каталога для систем интеграции, обычно по API-ключу вуза
+	integration := engine.Group("/integration", auth.Authenticate())
+	integration.GET("/vacancies", handlers.ExportPublished)
+}
+
+func (h *vacancyHandlers) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var request createVacancyRequest
+
+	err := c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	vacancy, err := h.vacancyService.Create(ctx, actor, port.CreateVacancyInput{
+		Title:            request.Title,
+		Description:      request.Description,
+		Contacts:         request.Contacts,
+		Requirements:     request.Requirements,
+		Responsibilities: request.Responsibilities,
+		Conditions:       request.Conditions,
+		Salary:           request.Salary.toDomain(),
+		Employment:       request.Employment,
+		Schedule:         request.Schedule,
+		Experience:       request.Experience,
+		Education:        request.Education,
+		Location:         request.Location,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error creating vacancy", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, newVacancyResponse(vacancy))
+}
+
+func (h *vacancyHandlers) ListMy(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	var query pageQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return
+	}
+
+	page, err := query.pageRequest(domain.VacancySortFields)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing sort", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid sort"})
+
+		return
+	}
+
+	vacancies, err := h.vacancyService.ListByCompany(ctx, actor, page)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error listing vacancies", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(vacancies, newVacancyResponse))
+}
+
+func (h *vacancyHandlers) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	vacancy, err := h.vacancyService.Get(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting vacancy", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newVacancyResponse(vacancy))
+}
+
+func (h *vacancyHandlers) Update(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	var request updateVacancyRequest
+
+	err = c.ShouldBindJSON(&request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing request body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing request body"})
+
+		return
+	}
+
+	err = h.validator.StructCtx(ctx, request)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating body", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating body"})
+
+		return
+	}
+
+	vacancy, err := h.vacancyService.Update(ctx, actor, id, port.UpdateVacancyInput{
+		Title:            request.Title,
+		Description:      request.Description,
+		Contacts:         request.Contacts,
+		Requirements:     request.Requirements,
+		Responsibilities: request.Responsibilities,
+		Conditions:       request.Conditions,
+		Salary:           request.Salary.toDomain(),
+		Employment:       request.Employment,
+		Schedule:         request.Schedule,
+		Experience:       request.Experience,
+		Education:        request.Education,
+		Location:         request.Location,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error updating vacancy", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newVacancyResponse(vacancy))
+}
+
+func (h *vacancyHandlers) Submit(c *gin.Context) {
+	h.transition(c, h.vacancyService.Submit)
+}
+
+func (h *vacancyHandlers) Restore(c *gin.Context) {
+	h.transition(c, h.vacancyService.Restore)
+}
+
+func (h *vacancyHandlers) Archive(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	_, err = h.vacancyService.Archive(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error archiving vacancy", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// transition переводит собственную вакансию компании в новое состояние и
+// отдаёт её.
+func (h *vacancyHandlers) transition(
+	c *gin.Context,
+	move func(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error),
+) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	vacancy, err := move(ctx, actor, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error changing vacancy state", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newVacancyResponse(vacancy))
+}
+
+func (h *vacancyHandlers) SearchPublished(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	query, filter, ok := h.bindPublicQuery(c)
+	if !ok {
+		return
+	}
+
+	if _, ok := c.GetQuery("cursor"); ok {
+		h.feedPublished(c, query, filter, h.vacancyService.FeedPublished)
+
+		return
+	}
+
+	page, err := query.pageRequest(domain.VacancySortFields)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing sort", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid sort"})
+
+		return
+	}
+
+	filter.Page = page
+
+	vacancies, err := h.vacancyService.SearchPublished(ctx, filter)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error searching vacancies", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newPaginatedResponse(vacancies, newVacancyResponse))
+}
+
+func (h *vacancyHandlers) ExportPublished(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	actor, ok := actorFromContext(ctx)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+
+		return
+	}
+
+	query, filter, ok := h.bindPublicQuery(c)
+	if !ok {
+		return
+	}
+
+	export := func(ctx context.Context, f domain.VacancyFilter, after *domain.Cursor, limit int) (port.CursorPaginated[*domain.Vacancy], error) {
+		return h.vacancyService.ExportPublished(ctx, actor, f, after, limit)
+	}
+
+	h.feedPublished(c, query, filter, export)
+}
+
+// bindPublicQuery разбирает фильтры каталога; при ошибке ответ уже записан.
+func (h *vacancyHandlers) bindPublicQuery(c *gin.Context) (publicVacanciesQuery, domain.VacancyFilter, bool) {
+	ctx := c.Request.Context()
+
+	var query publicVacanciesQuery
+
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error parsing query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error parsing query"})
+
+		return publicVacanciesQuery{}, domain.VacancyFilter{}, false
+	}
+
+	err = h.validator.StructCtx(ctx, query)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error validating query", "err", err)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "error validating query"})
+
+		return publicVacanciesQuery{}, domain.VacancyFilter{}, false
+	}
+
+	filter := domain.VacancyFilter{
+		Location:       optionalString(query.Location),
+		Employment:     optionalString(query.Employment),
+		Schedule:       optionalString(query.Schedule),
+		Experience:     optionalString(query.Experience),
+		Education:      optionalString(query.Education),
+		Salary:         query.Salary,
+		SalaryCurrency: optionalString(query.SalaryCurrency),
+	}
+
+	return query, filter, true
+}
+
+// feedFunc — источник ленты: публичный каталог или выгрузка для интеграции.
+type feedFunc func(ctx context.Context, f domain.VacancyFilter, after *domain.Cursor, limit int) (port.CursorPaginated[*domain.Vacancy], error)
+
+// feedPublished отдаёт каталог в режиме курсора: page игнорируется,
+// а порядок всегда от новых к старым.
+func (h *vacancyHandlers) feedPublished(c *gin.Context, query publicVacanciesQuery, filter domain.VacancyFilter, feedFn feedFunc) {
+	ctx := c.Request.Context()
+
+	if query.Sort != "" && query.Sort != "-"+string(domain.SortByCreatedAt) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "sort is not supported in cursor mode"})
+
+		return
+	}
+
+	var after *domain.Cursor
+
+	if query.Cursor != "" {
+		cursor, err := decodeCursor(query.Cursor)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "error decoding cursor", "err", err)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid cursor"})
+
+			return
+		}
+		after = &cursor
+	}
+
+	size := query.Size
+	if size == 0 {
+		size = defaultPageSize
+	}
+
+	feed, err := feedFn(ctx, filter, after, size)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error searching vacancies", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	response := vacancyFeedResponse{
+		Data: make([]vacancyResponse, 0, len(feed.Data)),
+	}
+	for _, v := range feed.Data {
+		response.Data = append(response.Data, newVacancyResponse(v))
+	}
+	if feed.NextCursor != nil {
+		next := encodeCursor(*feed.NextCursor)
+		response.NextCursor = &next
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *vacancyHandlers) GetPublished(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy id"})
+
+		return
+	}
+
+	vacancy, err := h.vacancyService.GetPublished(ctx, id)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "error getting published vacancy", "err", err)
+		h.writeError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, newVacancyResponse(vacancy))
+}
+
+func (h *vacancyHandlers) writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": "vacancy not found"})
+	case errors.Is(err, domain.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"message": "forbidden"})
+	case errors.Is(err, domain.ErrConflict):
+		c.JSON(http.StatusConflict, gin.H{"message": "action is not allowed in current vacancy state"})
+	case errors.Is(err, domain.ErrInvariantViolated):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": "invalid vacancy data"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "internal server error"})
+	}
+}
+
+// toDomain подставляет валюту по умолчанию, если она не передана.
+func (s *salaryDTO) toDomain() *domain.Salary {
+	if s == nil {
+		return nil
+	}
+	currency := s.Currency
+	if currency == "" {
+		currency = defaultSalaryCurrency
+	}
+	return &domain.Salary{
+		Min:      s.Min,
+		Max:      s.Max,
+		Currency: currency,
+		Gross:    s.Gross,
+	}
+}
+
+func newSalaryDTO(s *domain.Salary) *salaryDTO {
+	if s == nil {
+		return nil
+	}
+	return &salaryDTO{
+		Min:      s.Min,
+		Max:      s.Max,
+		Currency: s.Currency,
+		Gross:    s.Gross,
+	}
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func newVacancyResponse(v *domain.Vacancy) vacancyResponse {
+	vi := v.Immutable()
+	return vacancyResponse{
+		ID:                vi.ID,
+		CompanyID:         vi.CompanyID,
+		Title:             vi.Title,
+		Description:       vi.Description,
+		Contacts:          vi.Contacts,
+		Requirements:      vi.Requirements,
+		Responsibilities:  vi.Responsibilities,
+		Conditions:        vi.Conditions,
+		Salary:            newSalaryDTO(vi.Salary),
+		Employment:        vi.Employment,
+		Schedule:          vi.Schedule,
+		Experience:        vi.Experience,
+		Education:         vi.Education,
+		Location:          vi.Location,
+		State:             string(vi.State),
+		ModerationComment: vi.ModerationComment,
+		CreatedAt:         vi.CreatedAt,
+		UpdatedAt:         vi.UpdatedAt,
+	}
+}
@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -10,21 +11,29 @@ import (
 )
 
 type authClaims struct {
-	Role string `json:"role"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
+	CompanyID string `json:"cid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type jwtService struct {
-	clock     port.Clock
-	tokenTTL  time.Duration
-	secretKey []byte
+	clock    port.Clock
+	tokenTTL time.Duration
+	keys     *KeySet
+	parser   *jwt.Parser
 }
 
-func NewJWTService(clock port.Clock, tokenTTL time.Duration, secretKey string) *jwtService {
+func NewJWTService(clock port.Clock, tokenTTL time.Duration, keys *KeySet) *jwtService {
 	return &jwtService{
-		clock:     clock,
-		tokenTTL:  tokenTTL,
-		secretKey: []byte(secretKey),
+		clock:    clock,
+		tokenTTL: tokenTTL,
+		keys:     keys,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(keys.methods()),
+			jwt.WithExpirationRequired(),
+			jwt.WithTimeFunc(clock.Now),
+		),
 	}
 }
 
@@ -34,19 +43,26 @@ func (s *jwtService) Generate(payload port.TokenPayload) (string, error) {
 			Subject:   payload.Sub.String(),
 			ExpiresAt: jwt.NewNumericDate(s.clock.Now().Add(s.tokenTTL)),
 		},
-		Role: string(payload.Role),
+		Role:      string(payload.Role),
+		SessionID: payload.SessionID.String(),
 	}
+	if payload.CompanyID != uuid.Nil {
+		claims.CompanyID = payload.CompanyID.String()
+	}
+
+	signing := s.keys.signing
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(signing.method, claims)
+	if signing.kid != "" {
+		token.Header["kid"] = signing.kid
+	}
 
-	return token.SignedString(s.secretKey)
+	return token.SignedString(signing.key)
 }
 
-func (s *jwtService) Validate(tokenStr string) (port.TokenPayload, error) {
+func (s *jwtService) Validate(_ context.Context, tokenStr string) (port.TokenPayload, error) {
 	var claims authClaims
-	token, err := jwt.ParseWithClaims(tokenStr, &claims, func(_ *jwt.Token) (any, error) {
-		return s.secretKey, nil
-	})
+	token, err := s.parser.ParseWithClaims(tokenStr, &claims, s.keys.keyfunc)
 	if err != nil || !token.Valid {
 		return port.TokenPayload{}, errors.New("invalid or expired auth token")
 	}
@@ -56,8 +72,27 @@ func (s *jwtService) Validate(tokenStr string) (port.TokenPayload, error) {
 		return port.TokenPayload{}, err
 	}
 
+	sid, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return port.TokenPayload{}, errors.New("auth token without session")
+	}
+
+	var cid uuid.UUID
+	if claims.CompanyID != "" {
+		cid, err = uuid.Parse(claims.CompanyID)
+		if err != nil {
+			return port.TokenPayload{}, errors.New("auth token with invalid company")
+		}
+	}
+
 	return port.TokenPayload{
-		Sub:  uid,
-		Role: port.Role(claims.Role),
+		Sub:       uid,
+		Role:      port.Role(claims.Role),
+		CompanyID: cid,
+		SessionID: sid,
 	}, nil
 }
+
+func (s *jwtService) PublicKeys() []port.PublicKey {
+	return s.keys.PublicKeys()
+}
@@ -0,0 +1,236 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+)
+
+const minRSAKeyBits = 2048
+
+type (
+	verificationKey struct {
+		method jwt.SigningMethod
+		key    any
+	}
+
+	signingKey struct {
+		kid    string
+		method jwt.SigningMethod
+		key    any
+	}
+
+	// KeySet — ключ подписи и набор ключей проверки, различаемых по kid.
+	// Для ротации новый ключ становится ключом подписи, а открытая часть
+	// старого остаётся в наборе проверки, пока не истекут выданные им токены.
+	KeySet struct {
+		signing signingKey
+		verify  map[string]verificationKey
+	}
+)
+
+// NewHMACKeySet — режим с общим секретом (HS256). Токены подписываются
+// без kid, ключи наружу не публикуются.
+func NewHMACKeySet(secret string) *KeySet {
+	key := []byte(secret)
+	return &KeySet{
+		signing: signingKey{method: jwt.SigningMethodHS256, key: key},
+		verify: map[string]verificationKey{
+			"": {method: jwt.SigningMethodHS256, key: key},
+		},
+	}
+}
+
+// LoadKeySet читает закрытый ключ подписи и дополнительные открытые ключи
+// проверки из PEM-файлов. Поддерживаются RSA (RS256) и Ed25519 (EdDSA).
+// kid каждого ключа вычисляется из его открытой части.
+func LoadKeySet(privateKeyFile string, publicKeyFiles []string) (*KeySet, error) {
+	privateKey, err := readPrivateKey(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key %s: %w", privateKeyFile, err)
+	}
+
+	ks := &KeySet{verify: make(map[string]verificationKey)}
+
+	signingVerificationKey, err := newVerificationKey(privateKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key %s: %w", privateKeyFile, err)
+	}
+	kid, err := keyID(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	ks.signing = signingKey{kid: kid, method: signingVerificationKey.method, key: privateKey}
+	ks.verify[kid] = signingVerificationKey
+
+	for _, file := range publicKeyFiles {
+		publicKey, err := readPublicKey(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key %s: %w", file, err)
+		}
+
+		vk, err := newVerificationKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading public key %s: %w", file, err)
+		}
+		kid, err := keyID(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		ks.verify[kid] = vk
+	}
+
+	return ks, nil
+}
+
+// PublicKeys возвращает открытые ключи проверки для JWKS. В режиме HMAC
+// список пуст: секрет не публикуется.
+func (ks *KeySet) PublicKeys() []port.PublicKey {
+	keys := make([]port.PublicKey, 0, len(ks.verify))
+	for kid, vk := range ks.verify {
+		switch key := vk.key.(type) {
+		case *rsa.PublicKey:
+			keys = append(keys, port.PublicKey{
+				Kty: "RSA",
+				Kid: kid,
+				Alg: vk.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			keys = append(keys, port.PublicKey{
+				Kty: "OKP",
+				Kid: kid,
+				Alg: vk.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(key),
+			})
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b port.PublicKey) int {
+		return strings.Compare(a.Kid, b.Kid)
+	})
+
+	return keys
+}
+
+// keyfunc выбирает ключ по kid и отклоняет токен, если его alg не совпадает
+// с алгоритмом этого ключа (защита от подмены alg, например RS256 → HS256).
+func (ks *KeySet) keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	vk, ok := ks.verify[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != vk.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+
+	return vk.key, nil
+}
+
+func (ks *KeySet) methods() []string {
+	seen := make(map[string]bool)
+	methods := make([]string, 0, 1)
+	for _, vk := range ks.verify {
+		if alg := vk.method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+func newVerificationKey(publicKey crypto.PublicKey) (verificationKey, error) {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return verificationKey{}, fmt.Errorf("rsa key is shorter than %d bits", minRSAKeyBits)
+		}
+		return verificationKey{method: jwt.SigningMethodRS256, key: key}, nil
+	case ed25519.PublicKey:
+		return verificationKey{method: jwt.SigningMethodEdDSA, key: key}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+// keyID — отпечаток открытого ключа: не требует настройки и не меняется
+// при перезапуске.
+func keyID(publicKey crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return base64.RawURLEncoding.EncodeToString(sum[:16]), nil
+}
+
+func readPrivateKey(file string) (crypto.Signer, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unexpected pem block %q", block.Type)
+	}
+}
+
+func readPublicKey(file string) (crypto.PublicKey, error) {
+	block, err := readPEM(file)
+	if err != nil {
+		return nil, err
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unexpected pem block %q", block.Type)
+	}
+}
+
+func readPEM(file string) (*pem.Block, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	return block, nil
+}
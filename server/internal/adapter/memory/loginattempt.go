@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+)
+
+// Выше этого размера при записи удаляются записи, выпавшие из окна.
+const loginAttemptSweepSize = 10_000
+
+// loginAttemptStore хранит счётчики в памяти процесса: подходит для одного
+// экземпляра сервера и для локальной разработки.
+type loginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]port.LoginAttempts
+}
+
+func NewLoginAttemptStore() *loginAttemptStore {
+	return &loginAttemptStore{attempts: make(map[string]port.LoginAttempts)}
+}
+
+func (s *loginAttemptStore) Get(_ context.Context, key string) (port.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.attempts[key], nil
+}
+
+func (s *loginAttemptStore) RecordFailure(_ context.Context, key string, at, windowStart time.Time) (port.LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.attempts) >= loginAttemptSweepSize {
+		s.sweep(windowStart)
+	}
+
+	attempts := s.attempts[key]
+	if attempts.LastFailureAt.Before(windowStart) {
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = at
+
+	s.attempts[key] = attempts
+
+	return attempts, nil
+}
+
+func (s *loginAttemptStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if attempts, ok := s.attempts[key]; ok && attempts.Failures > 0 {
+		attempts.Failures--
+		s.attempts[key] = attempts
+	}
+
+	return nil
+}
+
+func (s *loginAttemptStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.attempts, key)
+
+	return nil
+}
+
+func (s *loginAttemptStore) sweep(windowStart time.Time) {
+	for key, attempts := range s.attempts {
+		if attempts.LastFailureAt.Before(windowStart) {
+			delete(s.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+)
+
+// fileNotifier дописывает сообщения в файл-«почтовый ящик», откуда их
+// удобно забирать в ручных и e2e-проверках.
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileNotifier(path string) *fileNotifier {
+	return &fileNotifier{path: path}
+}
+
+func (n *fileNotifier) Send(_ context.Context, msg port.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening outbox file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().UTC().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("error writing outbox file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+)
+
+// logNotifier пишет сообщения в лог вместо доставки — для локальной разработки.
+type logNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *logNotifier {
+	return &logNotifier{logger}
+}
+
+func (n *logNotifier) Send(ctx context.Context, msg port.Message) error {
+	n.logger.InfoContext(ctx, "notification", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
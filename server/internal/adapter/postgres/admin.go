@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type adminRepo struct {
+	q *pgqueries.Queries
+}
+
+func NewAdminRepo(q *pgqueries.Queries) *adminRepo {
+	return &adminRepo{q}
+}
+
+func (r *adminRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Admin, error) {
+	adb, err := r.q.GetAdminByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting admin by id: %w", err)
+	}
+
+	return reconstructAdmin(adb)
+}
+
+func (r *adminRepo) GetByLogin(ctx context.Context, login string) (*domain.Admin, error) {
+	adb, err := r.q.GetAdminByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting admin by login: %w", err)
+	}
+
+	return reconstructAdmin(adb)
+}
+
+func (r *adminRepo) Save(ctx context.Context, a *domain.Admin) error {
+	_, err := r.GetByID(ctx, a.Immutable().ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return r.create(ctx, a)
+		}
+		return fmt.Errorf("error getting admin by id: %w", err)
+	}
+	return r.update(ctx, a)
+}
+
+func (r *adminRepo) create(ctx context.Context, a *domain.Admin) error {
+	im := a.Immutable()
+	err := r.q.CreateAdmin(ctx, pgqueries.CreateAdminParams{
+		ID:           im.ID,
+		Login:        im.Login,
+		PasswordHash: im.PasswordHash,
+		CreatedAt:    im.CreatedAt,
+		UpdatedAt:    im.UpdatedAt,
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error creating admin: %w", err)
+	}
+	return nil
+}
+
+func (r *adminRepo) update(ctx context.Context, a *domain.Admin) error {
+	im := a.Immutable()
+	err := r.q.UpdateAdmin(ctx, pgqueries.UpdateAdminParams{
+		ID:           im.ID,
+		Login:        im.Login,
+		PasswordHash: im.PasswordHash,
+		CreatedAt:    im.CreatedAt,
+		UpdatedAt:    im.UpdatedAt,
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error updating admin: %w", err)
+	}
+	return nil
+}
+
+func reconstructAdmin(adb pgqueries.Admin) (*domain.Admin, error) {
+	a, err := domain.ReconstructAdmin(domain.AdminImmutable{
+		ID:           adb.ID,
+		Login:        adb.Login,
+		PasswordHash: adb.PasswordHash,
+		CreatedAt:    adb.CreatedAt,
+		UpdatedAt:    adb.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing admin: %w", err)
+	}
+
+	return a, nil
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type apiKeyRepo struct {
+	q *pgqueries.Queries
+}
+
+func NewAPIKeyRepo(q *pgqueries.Queries) *apiKeyRepo {
+	return &apiKeyRepo{q}
+}
+
+func (r *apiKeyRepo) Create(ctx context.Context, k *domain.APIKey) error {
+	im := k.Immutable()
+
+	scopes := make([]string, 0, len(im.Scopes))
+	for _, scope := range im.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	err := r.q.CreateAPIKey(ctx, pgqueries.CreateAPIKeyParams{
+		ID:           im.ID,
+		UniversityID: im.UniversityID,
+		Name:         im.Name,
+		Prefix:       im.Prefix,
+		SecretHash:   im.SecretHash,
+		Scopes:       scopes,
+		LastUsedAt:   optionalTimestamptz(im.LastUsedAt),
+		CreatedAt:    im.CreatedAt,
+		RevokedAt:    optionalTimestamptz(im.RevokedAt),
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error creating api key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	kdb, err := r.q.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting api key by id: %w", err)
+	}
+
+	return reconstructAPIKey(kdb)
+}
+
+func (r *apiKeyRepo) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	kdb, err := r.q.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting api key by prefix: %w", err)
+	}
+
+	return reconstructAPIKey(kdb)
+}
+
+func (r *apiKeyRepo) ListByUniversity(ctx context.Context, universityID uuid.UUID) ([]*domain.APIKey, error) {
+	rows, err := r.q.ListAPIKeysByUniversity(ctx, universityID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys: %w", err)
+	}
+
+	keys := make([]*domain.APIKey, 0, len(rows))
+	for _, row := range rows {
+		k, err := reconstructAPIKey(row)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepo) Revoke(ctx context.Context, k *domain.APIKey) error {
+	im := k.Immutable()
+	affected, err := r.q.RevokeAPIKey(ctx, pgqueries.RevokeAPIKeyParams{
+		RevokedAt: optionalTimestamptz(im.RevokedAt),
+		ID:        im.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking api key: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (r *apiKeyRepo) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.q.TouchAPIKey(ctx, pgqueries.TouchAPIKeyParams{
+		LastUsedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:         id,
+	})
+	if err != nil {
+		return fmt.Errorf("error touching api key: %w", err)
+	}
+	return nil
+}
+
+func reconstructAPIKey(kdb pgqueries.ApiKey) (*domain.APIKey, error) {
+	scopes := make([]domain.Permission, 0, len(kdb.Scopes))
+	for _, s := range kdb.Scopes {
+		scope, err := domain.ParsePermission(s)
+		if err != nil {
+			return nil, fmt.Errorf("error reconstructing api key: %w", err)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	k, err := domain.ReconstructAPIKey(domain.APIKeyImmutable{
+		ID:           kdb.ID,
+		UniversityID: kdb.UniversityID,
+		Name:         kdb.Name,
+		Prefix:       kdb.Prefix,
+		SecretHash:   kdb.SecretHash,
+		Scopes:       scopes,
+		LastUsedAt:   timestamptzPtr(kdb.LastUsedAt),
+		CreatedAt:    kdb.CreatedAt,
+		RevokedAt:    timestamptzPtr(kdb.RevokedAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing api key: %w", err)
+	}
+
+	return k, nil
+}
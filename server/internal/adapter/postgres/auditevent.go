@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type auditEventRepo struct {
+	q *pgqueries.Queries
+}
+
+func NewAuditEventRepo(q *pgqueries.Queries) *auditEventRepo {
+	return &auditEventRepo{q}
+}
+
+func (r *auditEventRepo) Append(ctx context.Context, e *domain.AuditEvent) error {
+	im := e.Immutable()
+
+	before, err := json.Marshal(im.Before)
+	if err != nil {
+		return fmt.Errorf("error encoding audit event state: %w", err)
+	}
+	after, err := json.Marshal(im.After)
+	if err != nil {
+		return fmt.Errorf("error encoding audit event state: %w", err)
+	}
+
+	err = r.q.AppendAuditEvent(ctx, pgqueries.AppendAuditEventParams{
+		ID:         im.ID,
+		OccurredAt: im.OccurredAt,
+		ActorID:    nullUUID(im.Actor.ID),
+		ActorRole:  string(im.Actor.Role),
+		ApiKeyID:   nullUUID(im.Actor.APIKeyID),
+		Action:     string(im.Action),
+		TargetType: string(im.TargetType),
+		TargetID:   nullUUID(im.TargetID),
+		Before:     before,
+		After:      after,
+		Ip:         im.IP,
+		UserAgent:  im.UserAgent,
+	})
+	if err != nil {
+		return fmt.Errorf("error appending audit event: %w", err)
+	}
+	return nil
+}
+
+func (r *auditEventRepo) List(ctx context.Context, f domain.AuditEventFilter) ([]*domain.AuditEvent, int, error) {
+	var targetType pgtype.Text
+	if f.TargetType != nil {
+		targetType = pgtype.Text{String: string(*f.TargetType), Valid: true}
+	}
+
+	rows, err := r.q.ListAuditEvents(ctx, pgqueries.ListAuditEventsParams{
+		ActorID:    optionalUUID(f.ActorID),
+		TargetType: targetType,
+		TargetID:   optionalUUID(f.TargetID),
+		From:       optionalTimestamptz(f.From),
+		To:         optionalTimestamptz(f.To),
+		Limit:      int32(f.Page.Limit()),
+		Offset:     int32(f.Page.Offset()),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	total, err := r.q.CountAuditEvents(ctx, pgqueries.CountAuditEventsParams{
+		ActorID:    optionalUUID(f.ActorID),
+		TargetType: targetType,
+		TargetID:   optionalUUID(f.TargetID),
+		From:       optionalTimestamptz(f.From),
+		To:         optionalTimestamptz(f.To),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting audit events: %w", err)
+	}
+
+	events := make([]*domain.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		e, err := reconstructAuditEvent(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, e)
+	}
+
+	return events, int(total), nil
+}
+
+func reconstructAuditEvent(edb pgqueries.AuditEvent) (*domain.AuditEvent, error) {
+	var before, after map[string]any
+	if err := json.Unmarshal(edb.Before, &before); err != nil {
+		return nil, fmt.Errorf("error decoding audit event state: %w", err)
+	}
+	if err := json.Unmarshal(edb.After, &after); err != nil {
+		return nil, fmt.Errorf("error decoding audit event state: %w", err)
+	}
+
+	actor := domain.Actor{ID: uuidOrNil(edb.ActorID), APIKeyID: uuidOrNil(edb.ApiKeyID)}
+	if edb.ActorRole != "" {
+		role, err := domain.ParseRole(edb.ActorRole)
+		if err != nil {
+			return nil, fmt.Errorf("error reconstructing audit event: %w", err)
+		}
+		actor.Role = role
+	}
+
+	targetType, err := domain.ParseAuditTargetType(edb.TargetType)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing audit event: %w", err)
+	}
+
+	e, err := domain.ReconstructAuditEvent(domain.AuditEventImmutable{
+		ID:         edb.ID,
+		OccurredAt: edb.OccurredAt,
+		Actor:      actor,
+		Action:     domain.AuditAction(edb.Action),
+		TargetType: targetType,
+		TargetID:   uuidOrNil(edb.TargetID),
+		Before:     before,
+		After:      after,
+		IP:         edb.Ip,
+		UserAgent:  edb.UserAgent,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing audit event: %w", err)
+	}
+
+	return e, nil
+}
@@ -1,111 +1,162 @@
 package postgres
 
 import (
-    "context"
-    "database/sql"
-    "errors"
-    "fmt"
-
-    "github.com/google/uuid"
-    "github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
-    "github.com/hr-platform-mosprom/internal/core/domain"
-    
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
 )
 
 type companyRepo struct {
-    q *pgqueries.Queries
+	q *pgqueries.Queries
 }
 
 func NewCompanyRepo(q *pgqueries.Queries) *companyRepo {
-    return &companyRepo{q}
+	return &companyRepo{q}
 }
 
 func (r *companyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Company, error) {
-    cdb, err := r.q.GetCompanyByID(ctx, id)
-    if err != nil {
-        if errors.Is(err, sql.ErrNoRows) {
-            return nil, domain.ErrNotFound
-        }
-        return nil, fmt.Errorf("error getting company by id: %w", err)
-    }
-
-    c, err := domain.ReconstructCompany(domain.CompanyImmutable{
-        ID:              cdb.ID,
-        Title:           cdb.Title,
-        Description:     cdb.Description,
-        Contacts:        cdb.Contacts,
-        INN:             cdb.Inn,
-        Address:         cdb.Address,
-        Approved:        cdb.Approved,
-        RepresentativeID: cdb.RepresentativeID,
-        Login:           cdb.Login,
-        PasswordHash:    cdb.PasswordHash,
-        CreatedAt:       cdb.CreatedAt,
-        UpdatedAt:       cdb.UpdatedAt,
-    })
-    if err != nil {
-        return nil, fmt.Errorf("error reconstructing company: %w", err)
-    }
-
-    return c, nil
+	cdb, err := r.q.GetCompanyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting company by id: %w", err)
+	}
+
+	return reconstructCompany(cdb)
+}
+
+func (r *companyRepo) GetByINN(ctx context.Context, inn string) (*domain.Company, error) {
+	cdb, err := r.q.GetCompanyByINN(ctx, inn)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting company by inn: %w", err)
+	}
+
+	return reconstructCompany(cdb)
+}
+
+func (r *companyRepo) List(ctx context.Context, f domain.CompanyFilter) ([]*domain.Company, int, error) {
+	rows, err := r.q.ListCompanies(ctx, pgqueries.ListCompaniesParams{
+		Approved: optionalBool(f.Approved),
+		Sort:     f.Page.Sort.String(),
+		Limit:    int32(f.Page.Limit()),
+		Offset:   int32(f.Page.Offset()),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing companies: %w", err)
+	}
+
+	total, err := r.q.CountCompanies(ctx, optionalBool(f.Approved))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting companies: %w", err)
+	}
+
+	companies := make([]*domain.Company, 0, len(rows))
+	for _, row := range rows {
+		c, err := reconstructCompany(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		companies = append(companies, c)
+	}
+
+	return companies, int(total), nil
 }
 
 func (r *companyRepo) Save(ctx context.Context, c *domain.Company) error {
-    _, err := r.GetByID(ctx, c.Immutable().ID)
-    if err != nil {
-        if errors.Is(err, domain.ErrNotFound) {
-            return r.create(ctx, c)
-        }
-        return fmt.Errorf("error getting company by id: %w", err)
-    }
-    return r.update(ctx, c)
+	_, err := r.GetByID(ctx, c.Immutable().ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return r.create(ctx, c)
+		}
+		return fmt.Errorf("error getting company by id: %w", err)
+	}
+	return r.update(ctx, c)
 }
 
 func (r *companyRepo) create(ctx context.Context, c *domain.Company) error {
-    im := c.Immutable()
-    err := r.q.CreateCompany(ctx, pgqueries.CreateCompanyParams{
-        ID:              im.ID,
-        Title:           im.Title,
-        Description:     im.Description,
-        Contacts:        im.Contacts,
-        Inn:             im.INN,
-        Address:         im.Address,
-        Approved:        im.Approved,
-        RepresentativeID: im.RepresentativeID,
-        Login:           im.Login,
-        PasswordHash:    im.PasswordHash,
-        CreatedAt:       im.CreatedAt,
-        UpdatedAt:       im.UpdatedAt,
-    })
-    if err != nil {
-        if isUniqueViolationError(err) {
-            return domain.ErrConflict
-        }
-        return fmt.Errorf("error creating company: %w", err)
-    }
-    return nil
+	return createCompany(ctx, r.q, c)
+}
+
+func createCompany(ctx context.Context, q *pgqueries.Queries, c *domain.Company) error {
+	im := c.Immutable()
+	err := q.CreateCompany(ctx, pgqueries.CreateCompanyParams{
+		ID:              im.ID,
+		Title:           im.Title,
+		Description:     im.Description,
+		Contacts:        im.Contacts,
+		Inn:             im.INN,
+		Address:         im.Address,
+		LogoUrl:         im.LogoURL,
+		Email:           im.Email,
+		EmailVerified:   im.EmailVerified,
+		Approved:        im.Approved,
+		RejectionReason: im.RejectionReason,
+		CreatedAt:       im.CreatedAt,
+		UpdatedAt:       im.UpdatedAt,
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error creating company: %w", err)
+	}
+	return nil
 }
 
 func (r *companyRepo) update(ctx context.Context, c *domain.Company) error {
-    im := c.Immutable()
-    err := r.q.UpdateCompany(ctx, pgqueries.UpdateCompanyParams{
-        ID:              im.ID,
-        Title:           im.Title,
-        Description:     im.Description,
-        Contacts:        im.Contacts,
-        Inn:             im.INN,
-        Address:         im.Address,
-        Approved:        im.Approved,
-        RepresentativeID: im.RepresentativeID,
-        Login:           im.Login,
-        PasswordHash:    im.PasswordHash,
-        CreatedAt:       im.CreatedAt,
-        UpdatedAt:       im.UpdatedAt,
-    })
-    if err != nil {
-        return fmt.Errorf("error updating company: %w", err)
-    }
-    return nil
+	im := c.Immutable()
+	err := r.q.UpdateCompany(ctx, pgqueries.UpdateCompanyParams{
+		ID:              im.ID,
+		Title:           im.Title,
+		Description:     im.Description,
+		Contacts:        im.Contacts,
+		Inn:             im.INN,
+		Address:         im.Address,
+		LogoUrl:         im.LogoURL,
+		Email:           im.Email,
+		EmailVerified:   im.EmailVerified,
+		Approved:        im.Approved,
+		RejectionReason: im.RejectionReason,
+		CreatedAt:       im.CreatedAt,
+		UpdatedAt:       im.UpdatedAt,
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error updating company: %w", err)
+	}
+	return nil
 }
 
-// shared helper
+func reconstructCompany(cdb pgqueries.Company) (*domain.Company, error) {
+	c, err := domain.ReconstructCompany(domain.CompanyImmutable{
+		ID:              cdb.ID,
+		Title:           cdb.Title,
+		Description:     cdb.Description,
+		Contacts:        cdb.Contacts,
+		INN:             cdb.Inn,
+		Address:         cdb.Address,
+		LogoURL:         cdb.LogoUrl,
+		Email:           cdb.Email,
+		EmailVerified:   cdb.EmailVerified,
+		Approved:        cdb.Approved,
+		RejectionReason: cdb.RejectionReason,
+		CreatedAt:       cdb.CreatedAt,
+		UpdatedAt:       cdb.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing company: %w", err)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,371 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type companyMemberRepo struct {
+	db *pgxpool.Pool
+	q  *pgqueries.Queries
+}
+
+// NewCompanyMemberRepo принимает пул: компания создаётся в одной транзакции
+// со своим первым владельцем.
+func NewCompanyMemberRepo(db *pgxpool.Pool) *companyMemberRepo {
+	return &companyMemberRepo{db, pgqueries.New(db)}
+}
+
+func (r *companyMemberRepo) CreateWithCompany(ctx context.Context, company *domain.Company, owner *domain.CompanyMember) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	if err := createCompany(ctx, q, company); err != nil {
+		return err
+	}
+	if err := createCompanyMember(ctx, q, owner); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *companyMemberRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.CompanyMember, error) {
+	mdb, err := r.q.GetCompanyMemberByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting company member by id: %w", err)
+	}
+
+	return reconstructCompanyMember(mdb)
+}
+
+func (r *companyMemberRepo) GetByLogin(ctx context.Context, login string) (*domain.CompanyMember, error) {
+	mdb, err := r.q.GetCompanyMemberByLogin(ctx, login)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting company member by login: %w", err)
+	}
+
+	return reconstructCompanyMember(mdb)
+}
+
+func (r *companyMemberRepo) ListByCompany(ctx context.Context, companyID uuid.UUID) ([]*domain.CompanyMember, error) {
+	rows, err := r.q.ListCompanyMembers(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing company members: %w", err)
+	}
+
+	members := make([]*domain.CompanyMember, 0, len(rows))
+	for _, row := range rows {
+		m, err := reconstructCompanyMember(row)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, nil
+}
+
+func (r *companyMemberRepo) Save(ctx context.Context, m *domain.CompanyMember) error {
+	_, err := r.GetByID(ctx, m.Immutable().ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return createCompanyMember(ctx, r.q, m)
+		}
+		return fmt.Errorf("error getting company member by id: %w", err)
+	}
+
+	return r.keepingOwner(ctx, m.Immutable().CompanyID, func(q *pgqueries.Queries) error {
+		return updateCompanyMember(ctx, q, m)
+	})
+}
+
+func (r *companyMemberRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	m, err := r.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("error getting company member by id: %w", err)
+	}
+
+	return r.keepingOwner(ctx, m.Immutable().CompanyID, func(q *pgqueries.Queries) error {
+		err := q.DeleteCompanyMember(ctx, id)
+		if err != nil {
+			return fmt.Errorf("error deleting company member: %w", err)
+		}
+		return nil
+	})
+}
+
+// keepingOwner применяет изменение под блокировкой строки компании и
+// откатывает его, если в компании не осталось владельцев: так владельцы,
+// одновременно понижающие друг друга, не оставят компанию без владельца.
+func (r *companyMemberRepo) keepingOwner(ctx context.Context, companyID uuid.UUID, change func(q *pgqueries.Queries) error) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	if err := q.LockCompanyMembers(ctx, companyID); err != nil {
+		return fmt.Errorf("error locking company members: %w", err)
+	}
+	if err := change(q); err != nil {
+		return err
+	}
+
+	owners, err := q.CountCompanyOwners(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("error counting company owners: %w", err)
+	}
+	if owners == 0 {
+		return fmt.Errorf("%w: company must keep at least one owner", domain.ErrConflict)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func updateCompanyMember(ctx context.Context, q *pgqueries.Queries, m *domain.CompanyMember) error {
+	im := m.Immutable()
+	err := q.UpdateCompanyMember(ctx, pgqueries.UpdateCompanyMemberParams{
+		Role:          string(im.Role),
+		Name:          im.Name,
+		Email:         im.Email,
+		EmailVerified: im.EmailVerified,
+		Login:         im.Login,
+		PasswordHash:  im.PasswordHash,
+		UpdatedAt:     im.UpdatedAt,
+		ID:            im.ID,
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error updating company member: %w", err)
+	}
+	return nil
+}
+
+func createCompanyMember(ctx context.Context, q *pgqueries.Queries, m *domain.CompanyMember) error {
+	im := m.Immutable()
+	err := q.CreateCompanyMember(ctx, pgqueries.CreateCompanyMemberParams{
+		ID:            im.ID,
+		CompanyID:     im.CompanyID,
+		Role:          string(im.Role),
+		Name:          im.Name,
+		Email:         im.Email,
+		EmailVerified: im.EmailVerified,
+		Login:         im.Login,
+		PasswordHash:  im.PasswordHash,
+		CreatedAt:     im.CreatedAt,
+		UpdatedAt:     im.UpdatedAt,
+	})
+	if err != nil {
+		if isUniqueViolationError(err) {
+			return domain.ErrConflict
+		}
+		return fmt.Errorf("error creating company member: %w", err)
+	}
+	return nil
+}
+
+func reconstructCompanyMember(mdb pgqueries.CompanyMember) (*domain.CompanyMember, error) {
+	role, err := domain.ParseCompanyMemberRole(mdb.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing company member: %w", err)
+	}
+
+	m, err := domain.ReconstructCompanyMember(domain.CompanyMemberImmutable{
+		ID:            mdb.ID,
+		CompanyID:     mdb.CompanyID,
+		Role:          role,
+		Name:          mdb.Name,
+		Email:         mdb.Email,
+		EmailVerified: mdb.EmailVerified,
+		Login:         mdb.Login,
+		PasswordHash:  mdb.PasswordHash,
+		CreatedAt:     mdb.CreatedAt,
+		UpdatedAt:     mdb.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing company member: %w", err)
+	}
+
+	return m, nil
+}
+
+type companyInvitationRepo struct {
+	db *pgxpool.Pool
+	q  *pgqueries.Queries
+}
+
+// NewCompanyInvitationRepo принимает пул: приглашение принимается в одной
+// транзакции с созданием сотрудника.
+func NewCompanyInvitationRepo(db *pgxpool.Pool) *companyInvitationRepo {
+	return &companyInvitationRepo{db, pgqueries.New(db)}
+}
+
+func (r *companyInvitationRepo) Create(ctx context.Context, i *domain.CompanyInvitation) error {
+	im := i.Immutable()
+	err := r.q.CreateCompanyInvitation(ctx, pgqueries.CreateCompanyInvitationParams{
+		ID:         im.ID,
+		CompanyID:  im.CompanyID,
+		Email:      im.Email,
+		Role:       string(im.Role),
+		TokenHash:  im.TokenHash,
+		InvitedBy:  im.InvitedBy,
+		ExpiresAt:  im.ExpiresAt,
+		CreatedAt:  im.CreatedAt,
+		AcceptedAt: optionalTimestamptz(im.AcceptedAt),
+		RevokedAt:  optionalTimestamptz(im.RevokedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating company invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *companyInvitationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.CompanyInvitation, error) {
+	idb, err := r.q.GetCompanyInvitationByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting company invitation by id: %w", err)
+	}
+
+	return reconstructCompanyInvitation(idb)
+}
+
+func (r *companyInvitationRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.CompanyInvitation, error) {
+	idb, err := r.q.GetCompanyInvitationByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting company invitation by token hash: %w", err)
+	}
+
+	return reconstructCompanyInvitation(idb)
+}
+
+func (r *companyInvitationRepo) ListPending(ctx context.Context, companyID uuid.UUID, at time.Time) ([]*domain.CompanyInvitation, error) {
+	rows, err := r.q.ListPendingCompanyInvitations(ctx, pgqueries.ListPendingCompanyInvitationsParams{
+		CompanyID: companyID,
+		Now:       at,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing company invitations: %w", err)
+	}
+
+	invitations := make([]*domain.CompanyInvitation, 0, len(rows))
+	for _, row := range rows {
+		i, err := reconstructCompanyInvitation(row)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, i)
+	}
+
+	return invitations, nil
+}
+
+func (r *companyInvitationRepo) Accept(ctx context.Context, i *domain.CompanyInvitation, m *domain.CompanyMember) error {
+	im := i.Immutable()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	affected, err := q.MarkCompanyInvitationAccepted(ctx, pgqueries.MarkCompanyInvitationAcceptedParams{
+		AcceptedAt: optionalTimestamptz(im.AcceptedAt),
+		ID:         im.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("error accepting company invitation: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	if err := createCompanyMember(ctx, q, m); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *companyInvitationRepo) Revoke(ctx context.Context, i *domain.CompanyInvitation) error {
+	im := i.Immutable()
+	affected, err := r.q.MarkCompanyInvitationRevoked(ctx, pgqueries.MarkCompanyInvitationRevokedParams{
+		RevokedAt: optionalTimestamptz(im.RevokedAt),
+		ID:        im.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking company invitation: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func reconstructCompanyInvitation(idb pgqueries.CompanyInvitation) (*domain.CompanyInvitation, error) {
+	role, err := domain.ParseCompanyMemberRole(idb.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing company invitation: %w", err)
+	}
+
+	i, err := domain.ReconstructCompanyInvitation(domain.CompanyInvitationImmutable{
+		ID:         idb.ID,
+		CompanyID:  idb.CompanyID,
+		Email:      idb.Email,
+		Role:       role,
+		TokenHash:  idb.TokenHash,
+		InvitedBy:  idb.InvitedBy,
+		ExpiresAt:  idb.ExpiresAt,
+		CreatedAt:  idb.CreatedAt,
+		AcceptedAt: timestamptzPtr(idb.AcceptedAt),
+		RevokedAt:  timestamptzPtr(idb.RevokedAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing company invitation: %w", err)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+)
+
+type loginAttemptStore struct {
+	q *pgqueries.Queries
+}
+
+func NewLoginAttemptStore(q *pgqueries.Queries) *loginAttemptStore {
+	return &loginAttemptStore{q}
+}
+
+func (s *loginAttemptStore) Get(ctx context.Context, key string) (port.LoginAttempts, error) {
+	adb, err := s.q.GetLoginAttempt(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return port.LoginAttempts{}, nil
+		}
+		return port.LoginAttempts{}, fmt.Errorf("error getting login attempts: %w", err)
+	}
+
+	return port.LoginAttempts{
+		Failures:      int(adb.Failures),
+		LastFailureAt: adb.LastFailureAt,
+	}, nil
+}
+
+func (s *loginAttemptStore) RecordFailure(ctx context.Context, key string, at, windowStart time.Time) (port.LoginAttempts, error) {
+	adb, err := s.q.RecordLoginFailure(ctx, pgqueries.RecordLoginFailureParams{
+		Key:         key,
+		At:          at,
+		WindowStart: windowStart,
+	})
+	if err != nil {
+		return port.LoginAttempts{}, fmt.Errorf("error recording login failure: %w", err)
+	}
+
+	return port.LoginAttempts{
+		Failures:      int(adb.Failures),
+		LastFailureAt: adb.LastFailureAt,
+	}, nil
+}
+
+func (s *loginAttemptStore) Release(ctx context.Context, key string) error {
+	err := s.q.ReleaseLoginAttempt(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error releasing login attempt: %w", err)
+	}
+	return nil
+}
+
+func (s *loginAttemptStore) Reset(ctx context.Context, key string) error {
+	err := s.q.DeleteLoginAttempt(ctx, key)
+	if err != nil {
+		return fmt.Errorf("error deleting login attempts: %w", err)
+	}
+	return nil
+}
+
+// DeleteStale удаляет счётчики, выпавшие из окна; вызывается периодически.
+func (s *loginAttemptStore) DeleteStale(ctx context.Context, before time.Time) error {
+	err := s.q.DeleteStaleLoginAttempts(ctx, before)
+	if err != nil {
+		return fmt.Errorf("error deleting stale login attempts: %w", err)
+	}
+	return nil
+}
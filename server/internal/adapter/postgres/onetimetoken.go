@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type oneTimeTokenRepo struct {
+	q *pgqueries.Queries
+}
+
+func NewOneTimeTokenRepo(q *pgqueries.Queries) *oneTimeTokenRepo {
+	return &oneTimeTokenRepo{q}
+}
+
+func (r *oneTimeTokenRepo) Create(ctx context.Context, t *domain.OneTimeToken) error {
+	im := t.Immutable()
+	err := r.q.CreateOneTimeToken(ctx, pgqueries.CreateOneTimeTokenParams{
+		ID:        im.ID,
+		Purpose:   string(im.Purpose),
+		SubjectID: im.SubjectID,
+		Role:      string(im.Role),
+		TokenHash: im.TokenHash,
+		ExpiresAt: im.ExpiresAt,
+		CreatedAt: im.CreatedAt,
+		UsedAt:    optionalTimestamptz(im.UsedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating one-time token: %w", err)
+	}
+	return nil
+}
+
+func (r *oneTimeTokenRepo) Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.OneTimeToken, error) {
+	tdb, err := r.q.ConsumeOneTimeToken(ctx, pgqueries.ConsumeOneTimeTokenParams{
+		Now:       at,
+		TokenHash: tokenHash,
+		Purpose:   string(purpose),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error consuming one-time token: %w", err)
+	}
+
+	return reconstructOneTimeToken(tdb)
+}
+
+func (r *oneTimeTokenRepo) GetActive(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.OneTimeToken, error) {
+	tdb, err := r.q.GetActiveOneTimeToken(ctx, pgqueries.GetActiveOneTimeTokenParams{
+		TokenHash: tokenHash,
+		Purpose:   string(purpose),
+		Now:       at,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting one-time token: %w", err)
+	}
+
+	return reconstructOneTimeToken(tdb)
+}
+
+func (r *oneTimeTokenRepo) Invalidate(ctx context.Context, purpose domain.TokenPurpose, subjectID uuid.UUID, at time.Time) error {
+	err := r.q.InvalidateOneTimeTokens(ctx, pgqueries.InvalidateOneTimeTokensParams{
+		UsedAt:    pgtype.Timestamptz{Time: at, Valid: true},
+		SubjectID: subjectID,
+		Purpose:   string(purpose),
+	})
+	if err != nil {
+		return fmt.Errorf("error invalidating one-time tokens: %w", err)
+	}
+	return nil
+}
+
+func reconstructOneTimeToken(tdb pgqueries.OneTimeToken) (*domain.OneTimeToken, error) {
+	role, err := domain.ParseRole(tdb.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing one-time token: %w", err)
+	}
+
+	t, err := domain.ReconstructOneTimeToken(domain.OneTimeTokenImmutable{
+		ID:        tdb.ID,
+		Purpose:   domain.TokenPurpose(tdb.Purpose),
+		SubjectID: tdb.SubjectID,
+		Role:      role,
+		TokenHash: tdb.TokenHash,
+		ExpiresAt: tdb.ExpiresAt,
+		CreatedAt: tdb.CreatedAt,
+		UsedAt:    timestamptzPtr(tdb.UsedAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing one-time token: %w", err)
+	}
+
+	return t, nil
+}
@@ -10,29 +10,44 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countCompanies = `-- name: CountCompanies :one
+SELECT count(*)
+FROM companies
+WHERE $1::boolean IS NULL OR approved = $1::boolean
+`
+
+func (q *Queries) CountCompanies(ctx context.Context, approved pgtype.Bool) (int64, error) {
+	row := q.db.QueryRow(ctx, countCompanies, approved)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCompany = `-- name: CreateCompany :exec
 INSERT INTO companies (
-    id, title, description, contacts, inn, address, approved, representative_id, login, password_hash, created_at, updated_at
+    id, title, description, contacts, inn, address, approved, created_at, updated_at, rejection_reason, logo_url, email, email_verified
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 )
 `
 
 type CreateCompanyParams struct {
-	ID               uuid.UUID
-	Title            string
-	Description      string
-	Contacts         string
-	Inn              string
-	Address          string
-	Approved         bool
-	RepresentativeID uuid.UUID
-	Login            string
-	PasswordHash     string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	ID              uuid.UUID
+	Title           string
+	Description     string
+	Contacts        string
+	Inn             string
+	Address         string
+	Approved        bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	LogoUrl         string
+	Email           string
+	EmailVerified   bool
 }
 
 func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) error {
@@ -44,11 +59,12 @@ func (q *Queries) CreateCompany(ctx context.Context, arg CreateCompanyParams) er
 		arg.Inn,
 		arg.Address,
 		arg.Approved,
-		arg.RepresentativeID,
-		arg.Login,
-		arg.PasswordHash,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.RejectionReason,
+		arg.LogoUrl,
+		arg.Email,
+		arg.EmailVerified,
 	)
 	return err
 }
@@ -62,11 +78,12 @@ SELECT
     inn,
     address,
     approved,
-    representative_id,
-    login,
-    password_hash,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    logo_url,
+    email,
+    email_verified
 FROM companies
 WHERE id = $1
 `
@@ -82,11 +99,12 @@ func (q *Queries) GetCompanyByID(ctx context.Context, id uuid.UUID) (Company, er
 		&i.Inn,
 		&i.Address,
 		&i.Approved,
-		&i.RepresentativeID,
-		&i.Login,
-		&i.PasswordHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RejectionReason,
+		&i.LogoUrl,
+		&i.Email,
+		&i.EmailVerified,
 	)
 	return i, err
 }
@@ -100,11 +118,12 @@ SELECT
     inn,
     address,
     approved,
-    representative_id,
-    login,
-    password_hash,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    logo_url,
+    email,
+    email_verified
 FROM companies
 WHERE inn = $1
 `
@@ -120,16 +139,17 @@ func (q *Queries) GetCompanyByINN(ctx context.Context, inn string) (Company, err
 		&i.Inn,
 		&i.Address,
 		&i.Approved,
-		&i.RepresentativeID,
-		&i.Login,
-		&i.PasswordHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RejectionReason,
+		&i.LogoUrl,
+		&i.Email,
+		&i.EmailVerified,
 	)
 	return i, err
 }
 
-const getCompanyByLogin = `-- name: GetCompanyByLogin :one
+const listCompanies = `-- name: ListCompanies :many
 SELECT
     id,
     title,
@@ -138,33 +158,62 @@ SELECT
     inn,
     address,
     approved,
-    representative_id,
-    login,
-    password_hash,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    logo_url,
+    email,
+    email_verified
 FROM companies
-WHERE login = $1
+WHERE $1::boolean IS NULL OR approved = $1::boolean
+ORDER BY
+    CASE WHEN $2::text = 'title' THEN title END ASC,
+    CASE WHEN $2::text = '-title' THEN title END DESC,
+    CASE WHEN $2::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $3 OFFSET $4
 `
 
-func (q *Queries) GetCompanyByLogin(ctx context.Context, login string) (Company, error) {
-	row := q.db.QueryRow(ctx, getCompanyByLogin, login)
-	var i Company
-	err := row.Scan(
-		&i.ID,
-		&i.Title,
-		&i.Description,
-		&i.Contacts,
-		&i.Inn,
-		&i.Address,
-		&i.Approved,
-		&i.RepresentativeID,
-		&i.Login,
-		&i.PasswordHash,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
+type ListCompaniesParams struct {
+	Approved pgtype.Bool
+	Sort     string
+	Limit    int32
+	Offset   int32
+}
+
+func (q *Queries) ListCompanies(ctx context.Context, arg ListCompaniesParams) ([]Company, error) {
+	rows, err := q.db.Query(ctx, listCompanies, arg.Approved, arg.Sort, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Company
+	for rows.Next() {
+		var i Company
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Contacts,
+			&i.Inn,
+			&i.Address,
+			&i.Approved,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RejectionReason,
+			&i.LogoUrl,
+			&i.Email,
+			&i.EmailVerified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const updateCompany = `-- name: UpdateCompany :exec
@@ -176,27 +225,29 @@ SET
     inn = $4,
     address = $5,
     approved = $6,
-    representative_id = $7,
-    login = $8,
-    password_hash = $9,
-    created_at = $10,
-    updated_at = $11
-WHERE id = $12
+    created_at = $7,
+    updated_at = $8,
+    rejection_reason = $9,
+    logo_url = $10,
+    email = $11,
+    email_verified = $12
+WHERE id = $13
 `
 
 type UpdateCompanyParams struct {
-	Title            string
-	Description      string
-	Contacts         string
-	Inn              string
-	Address          string
-	Approved         bool
-	RepresentativeID uuid.UUID
-	Login            string
-	PasswordHash     string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	ID               uuid.UUID
+	Title           string
+	Description     string
+	Contacts        string
+	Inn             string
+	Address         string
+	Approved        bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	LogoUrl         string
+	Email           string
+	EmailVerified   bool
+	ID              uuid.UUID
 }
 
 func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) error {
@@ -207,11 +258,12 @@ func (q *Queries) UpdateCompany(ctx context.Context, arg UpdateCompanyParams) er
 		arg.Inn,
 		arg.Address,
 		arg.Approved,
-		arg.RepresentativeID,
-		arg.Login,
-		arg.PasswordHash,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.RejectionReason,
+		arg.LogoUrl,
+		arg.Email,
+		arg.EmailVerified,
 		arg.ID,
 	)
 	return err
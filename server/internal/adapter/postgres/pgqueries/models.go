@@ -8,21 +8,118 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Admin struct {
+	ID           uuid.UUID
+	Login        string
+	PasswordHash string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type ApiKey struct {
+	ID           uuid.UUID
+	UniversityID uuid.UUID
+	Name         string
+	Prefix       string
+	SecretHash   string
+	Scopes       []string
+	LastUsedAt   pgtype.Timestamptz
+	CreatedAt    time.Time
+	RevokedAt    pgtype.Timestamptz
+}
+
+type AuditEvent struct {
+	ID         uuid.UUID
+	OccurredAt time.Time
+	ActorID    pgtype.UUID
+	ActorRole  string
+	ApiKeyID   pgtype.UUID
+	Action     string
+	TargetType string
+	TargetID   pgtype.UUID
+	Before     []byte
+	After      []byte
+	Ip         string
+	UserAgent  string
+}
+
 type Company struct {
-	ID               uuid.UUID
-	Title            string
-	Description      string
-	Contacts         string
-	Inn              string
-	Address          string
-	Approved         bool
-	RepresentativeID uuid.UUID
-	Login            string
-	PasswordHash     string
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	ID              uuid.UUID
+	Title           string
+	Description     string
+	Contacts        string
+	Inn             string
+	Address         string
+	Approved        bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	LogoUrl         string
+	Email           string
+	EmailVerified   bool
+}
+
+type CompanyInvitation struct {
+	ID         uuid.UUID
+	CompanyID  uuid.UUID
+	Email      string
+	Role       string
+	TokenHash  string
+	InvitedBy  uuid.UUID
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	AcceptedAt pgtype.Timestamptz
+	RevokedAt  pgtype.Timestamptz
+}
+
+type CompanyMember struct {
+	ID            uuid.UUID
+	CompanyID     uuid.UUID
+	Role          string
+	Name          string
+	Email         string
+	EmailVerified bool
+	Login         string
+	PasswordHash  string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type LoginAttempt struct {
+	Key           string
+	Failures      int32
+	LastFailureAt time.Time
+}
+
+type OneTimeToken struct {
+	ID        uuid.UUID
+	Purpose   string
+	SubjectID uuid.UUID
+	Role      string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    pgtype.Timestamptz
+}
+
+type Pipeline struct {
+	ID         uuid.UUID
+	CompanyID  uuid.UUID
+	VacancyID  pgtype.UUID
+	Stages     []byte
+	CreatedAt  time.Time
+	ReplacedAt pgtype.Timestamptz
+}
+
+type RecoveryCode struct {
+	ID        uuid.UUID
+	SubjectID uuid.UUID
+	CodeHash  string
+	UsedAt    pgtype.Timestamptz
+	CreatedAt time.Time
 }
 
 type Response struct {
@@ -36,34 +133,86 @@ type Response struct {
 	Status      string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	PipelineID  pgtype.UUID
+	Stage       string
 }
 
-type University struct {
-	ID           uuid.UUID
-	Title        string
-	Login        string
-	PasswordHash string
-	Inn          string
-	Confirmed    bool
+type ResponseStatusHistory struct {
+	ID         uuid.UUID
+	ResponseID uuid.UUID
+	FromStatus string
+	ToStatus   string
+	ActorID    pgtype.UUID
+	ActorRole  string
+	ChangedAt  time.Time
+	FromStage  string
+	ToStage    string
+}
+
+type Session struct {
+	ID               uuid.UUID
+	FamilyID         uuid.UUID
+	SubjectID        uuid.UUID
+	Role             string
+	RefreshTokenHash string
+	ExpiresAt        time.Time
+	CreatedAt        time.Time
+	RotatedAt        pgtype.Timestamptz
+	RevokedAt        pgtype.Timestamptz
+	CompanyID        pgtype.UUID
+}
+
+type TwoFactor struct {
+	SubjectID    uuid.UUID
+	Role         string
+	Secret       string
+	ConfirmedAt  pgtype.Timestamptz
+	LastUsedStep int64
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
 
+type TwoFactorRequirement struct {
+	Role      string
+	Required  bool
+	UpdatedBy uuid.UUID
+	UpdatedAt time.Time
+}
+
+type University struct {
+	ID              uuid.UUID
+	Title           string
+	Login           string
+	PasswordHash    string
+	Inn             string
+	Confirmed       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	Email           string
+	EmailVerified   bool
+}
+
 type Vacancy struct {
-	ID               uuid.UUID
-	CompanyID        uuid.UUID
-	Title            string
-	Description      string
-	Contacts         string
-	Requirements     string
-	Responsibilities string
-	Conditions       string
-	Employment       string
-	Schedule         string
-	Experience       string
-	Education        string
-	Location         string
-	IsActive         bool
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	ID                uuid.UUID
+	CompanyID         uuid.UUID
+	Title             string
+	Description       string
+	Contacts          string
+	Requirements      string
+	Responsibilities  string
+	Conditions        string
+	Employment        string
+	Schedule          string
+	Experience        string
+	Education         string
+	Location          string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	SalaryMin         pgtype.Int4
+	SalaryMax         pgtype.Int4
+	SalaryCurrency    pgtype.Text
+	SalaryGross       pgtype.Bool
+	State             string
+	ModerationComment string
 }
@@ -10,8 +10,28 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countResponses = `-- name: CountResponses :one
+SELECT count(*)
+FROM responses
+WHERE ($1::uuid IS NULL OR vacancy_id = $1::uuid)
+    AND ($2::text IS NULL OR status = $2::text)
+`
+
+type CountResponsesParams struct {
+	VacancyID pgtype.UUID
+	Status    pgtype.Text
+}
+
+func (q *Queries) CountResponses(ctx context.Context, arg CountResponsesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countResponses, arg.VacancyID, arg.Status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createResponse = `-- name: CreateResponse :exec
 INSERT INTO responses (
     id,
@@ -23,7 +43,9 @@ INSERT INTO responses (
     resume_url,
     status,
     created_at,
-    updated_at
+    updated_at,
+    pipeline_id,
+    stage
 ) VALUES (
     $1,
     $2,
@@ -34,7 +56,9 @@ INSERT INTO responses (
     $7,
     $8,
     $9,
-    $10
+    $10,
+    $11,
+    $12
 )
 `
 
@@ -49,6 +73,8 @@ type CreateResponseParams struct {
 	Status      string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	PipelineID  pgtype.UUID
+	Stage       string
 }
 
 func (q *Queries) CreateResponse(ctx context.Context, arg CreateResponseParams) error {
@@ -63,10 +89,25 @@ func (q *Queries) CreateResponse(ctx context.Context, arg CreateResponseParams)
 		arg.Status,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.PipelineID,
+		arg.Stage,
 	)
 	return err
 }
 
+const deleteResponse = `-- name: DeleteResponse :execrows
+DELETE FROM responses
+WHERE id = $1
+`
+
+func (q *Queries) DeleteResponse(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteResponse, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getResponseByID = `-- name: GetResponseByID :one
 SELECT
     id,
@@ -78,7 +119,9 @@ SELECT
     resume_url,
     status,
     created_at,
-    updated_at
+    updated_at,
+    pipeline_id,
+    stage
 FROM responses
 WHERE id = $1
 `
@@ -97,11 +140,13 @@ func (q *Queries) GetResponseByID(ctx context.Context, id uuid.UUID) (Response,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.PipelineID,
+		&i.Stage,
 	)
 	return i, err
 }
 
-const listResponsesByVacancy = `-- name: ListResponsesByVacancy :many
+const searchResponses = `-- name: SearchResponses :many
 SELECT
     id,
     vacancy_id,
@@ -112,14 +157,35 @@ SELECT
     resume_url,
     status,
     created_at,
-    updated_at
+    updated_at,
+    pipeline_id,
+    stage
 FROM responses
-WHERE vacancy_id = $1
-ORDER BY created_at DESC
+WHERE ($1::uuid IS NULL OR vacancy_id = $1::uuid)
+    AND ($2::text IS NULL OR status = $2::text)
+ORDER BY
+    CASE WHEN $3::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $4 OFFSET $5
 `
 
-func (q *Queries) ListResponsesByVacancy(ctx context.Context, vacancyID uuid.UUID) ([]Response, error) {
-	rows, err := q.db.Query(ctx, listResponsesByVacancy, vacancyID)
+type SearchResponsesParams struct {
+	VacancyID pgtype.UUID
+	Status    pgtype.Text
+	Sort      string
+	Limit     int32
+	Offset    int32
+}
+
+func (q *Queries) SearchResponses(ctx context.Context, arg SearchResponsesParams) ([]Response, error) {
+	rows, err := q.db.Query(ctx, searchResponses,
+		arg.VacancyID,
+		arg.Status,
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -138,6 +204,8 @@ func (q *Queries) ListResponsesByVacancy(ctx context.Context, vacancyID uuid.UUI
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.PipelineID,
+			&i.Stage,
 		); err != nil {
 			return nil, err
 		}
@@ -149,21 +217,33 @@ func (q *Queries) ListResponsesByVacancy(ctx context.Context, vacancyID uuid.UUI
 	return items, nil
 }
 
-const updateResponseStatus = `-- name: UpdateResponseStatus :exec
+const updateResponseStatus = `-- name: UpdateResponseStatus :execrows
 UPDATE responses
 SET
     status = $1,
-    updated_at = $2
-WHERE id = $3
+    stage = $2,
+    updated_at = $3
+WHERE id = $4 AND stage = $5
 `
 
 type UpdateResponseStatusParams struct {
 	Status    string
+	Stage     string
 	UpdatedAt time.Time
 	ID        uuid.UUID
+	FromStage string
 }
 
-func (q *Queries) UpdateResponseStatus(ctx context.Context, arg UpdateResponseStatusParams) error {
-	_, err := q.db.Exec(ctx, updateResponseStatus, arg.Status, arg.UpdatedAt, arg.ID)
-	return err
+func (q *Queries) UpdateResponseStatus(ctx context.Context, arg UpdateResponseStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateResponseStatus,
+		arg.Status,
+		arg.Stage,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.FromStage,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
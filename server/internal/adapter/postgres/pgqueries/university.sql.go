@@ -10,22 +10,39 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countUniversities = `-- name: CountUniversities :one
+SELECT count(*)
+FROM universities
+WHERE $1::boolean IS NULL OR confirmed = $1::boolean
+`
+
+func (q *Queries) CountUniversities(ctx context.Context, confirmed pgtype.Bool) (int64, error) {
+	row := q.db.QueryRow(ctx, countUniversities, confirmed)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUniversity = `-- name: CreateUniversity :exec
-INSERT INTO universities (id, title, login, password_hash, inn, confirmed, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO universities (id, title, login, password_hash, inn, confirmed, created_at, updated_at, rejection_reason, email, email_verified)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
 
 type CreateUniversityParams struct {
-	ID           uuid.UUID
-	Title        string
-	Login        string
-	PasswordHash string
-	Inn          string
-	Confirmed    bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              uuid.UUID
+	Title           string
+	Login           string
+	PasswordHash    string
+	Inn             string
+	Confirmed       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	Email           string
+	EmailVerified   bool
 }
 
 func (q *Queries) CreateUniversity(ctx context.Context, arg CreateUniversityParams) error {
@@ -38,6 +55,9 @@ func (q *Queries) CreateUniversity(ctx context.Context, arg CreateUniversityPara
 		arg.Confirmed,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.RejectionReason,
+		arg.Email,
+		arg.EmailVerified,
 	)
 	return err
 }
@@ -52,20 +72,26 @@ SELECT
     title,
     confirmed,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    email,
+    email_verified
 FROM universities
 WHERE id = $1
 `
 
 type GetUniversityByIDRow struct {
-	ID           uuid.UUID
-	Login        string
-	PasswordHash string
-	Inn          string
-	Title        string
-	Confirmed    bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              uuid.UUID
+	Login           string
+	PasswordHash    string
+	Inn             string
+	Title           string
+	Confirmed       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	Email           string
+	EmailVerified   bool
 }
 
 // Universities
@@ -81,6 +107,9 @@ func (q *Queries) GetUniversityByID(ctx context.Context, id uuid.UUID) (GetUnive
 		&i.Confirmed,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RejectionReason,
+		&i.Email,
+		&i.EmailVerified,
 	)
 	return i, err
 }
@@ -94,20 +123,26 @@ SELECT
     title,
     confirmed,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    email,
+    email_verified
 FROM universities
 WHERE login = $1
 `
 
 type GetUniversityByLoginRow struct {
-	ID           uuid.UUID
-	Login        string
-	PasswordHash string
-	Inn          string
-	Title        string
-	Confirmed    bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              uuid.UUID
+	Login           string
+	PasswordHash    string
+	Inn             string
+	Title           string
+	Confirmed       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	Email           string
+	EmailVerified   bool
 }
 
 func (q *Queries) GetUniversityByLogin(ctx context.Context, login string) (GetUniversityByLoginRow, error) {
@@ -122,10 +157,90 @@ func (q *Queries) GetUniversityByLogin(ctx context.Context, login string) (GetUn
 		&i.Confirmed,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.RejectionReason,
+		&i.Email,
+		&i.EmailVerified,
 	)
 	return i, err
 }
 
+const listUniversities = `-- name: ListUniversities :many
+SELECT
+    id,
+    login,
+    password_hash,
+    inn,
+    title,
+    confirmed,
+    created_at,
+    updated_at,
+    rejection_reason,
+    email,
+    email_verified
+FROM universities
+WHERE $1::boolean IS NULL OR confirmed = $1::boolean
+ORDER BY
+    CASE WHEN $2::text = 'title' THEN title END ASC,
+    CASE WHEN $2::text = '-title' THEN title END DESC,
+    CASE WHEN $2::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListUniversitiesParams struct {
+	Confirmed pgtype.Bool
+	Sort      string
+	Limit     int32
+	Offset    int32
+}
+
+type ListUniversitiesRow struct {
+	ID              uuid.UUID
+	Login           string
+	PasswordHash    string
+	Inn             string
+	Title           string
+	Confirmed       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	Email           string
+	EmailVerified   bool
+}
+
+func (q *Queries) ListUniversities(ctx context.Context, arg ListUniversitiesParams) ([]ListUniversitiesRow, error) {
+	rows, err := q.db.Query(ctx, listUniversities, arg.Confirmed, arg.Sort, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUniversitiesRow
+	for rows.Next() {
+		var i ListUniversitiesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Login,
+			&i.PasswordHash,
+			&i.Inn,
+			&i.Title,
+			&i.Confirmed,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RejectionReason,
+			&i.Email,
+			&i.EmailVerified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUniversity = `-- name: UpdateUniversity :exec
 UPDATE universities
 SET
@@ -135,19 +250,25 @@ SET
     inn = $4,
     confirmed = $5,
     created_at = $6,
-    updated_at = $7
-WHERE id = $8
+    updated_at = $7,
+    rejection_reason = $8,
+    email = $9,
+    email_verified = $10
+WHERE id = $11
 `
 
 type UpdateUniversityParams struct {
-	Login        string
-	PasswordHash string
-	Title        string
-	Inn          string
-	Confirmed    bool
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	ID           uuid.UUID
+	Login           string
+	PasswordHash    string
+	Title           string
+	Inn             string
+	Confirmed       bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	RejectionReason string
+	Email           string
+	EmailVerified   bool
+	ID              uuid.UUID
 }
 
 func (q *Queries) UpdateUniversity(ctx context.Context, arg UpdateUniversityParams) error {
@@ -159,6 +280,9 @@ func (q *Queries) UpdateUniversity(ctx context.Context, arg UpdateUniversityPara
 		arg.Confirmed,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.RejectionReason,
+		arg.Email,
+		arg.EmailVerified,
 		arg.ID,
 	)
 	return err
@@ -10,8 +10,69 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countVacancies = `-- name: CountVacancies :one
+SELECT count(*)
+FROM vacancies
+WHERE ($1::uuid IS NULL OR company_id = $1::uuid)
+    AND ($2::text IS NULL OR location = $2::text)
+    AND ($3::text IS NULL OR employment = $3::text)
+    AND ($4::text IS NULL OR schedule = $4::text)
+    AND ($5::text IS NULL OR experience = $5::text)
+    AND ($6::text IS NULL OR education = $6::text)
+    AND ($7::text IS NULL OR state = $7::text)
+    AND (
+        $8::integer IS NULL
+        OR (
+            (salary_min IS NOT NULL OR salary_max IS NOT NULL)
+            AND (salary_min IS NULL OR salary_min <= $8::integer)
+            AND (salary_max IS NULL OR salary_max >= $8::integer)
+        )
+    )
+    AND ($9::text IS NULL OR salary_currency = $9::text)
+    AND (
+        $10::boolean IS NULL
+        OR EXISTS (
+            SELECT 1 FROM companies
+            WHERE companies.id = vacancies.company_id
+                AND companies.approved = $10::boolean
+        )
+    )
+`
+
+type CountVacanciesParams struct {
+	CompanyID       pgtype.UUID
+	Location        pgtype.Text
+	Employment      pgtype.Text
+	Schedule        pgtype.Text
+	Experience      pgtype.Text
+	Education       pgtype.Text
+	State           pgtype.Text
+	Salary          pgtype.Int4
+	SalaryCurrency  pgtype.Text
+	CompanyApproved pgtype.Bool
+}
+
+func (q *Queries) CountVacancies(ctx context.Context, arg CountVacanciesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countVacancies,
+		arg.CompanyID,
+		arg.Location,
+		arg.Employment,
+		arg.Schedule,
+		arg.Experience,
+		arg.Education,
+		arg.State,
+		arg.Salary,
+		arg.SalaryCurrency,
+		arg.CompanyApproved,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createVacancy = `-- name: CreateVacancy :exec
 INSERT INTO vacancies (
     id,
@@ -27,9 +88,14 @@ INSERT INTO vacancies (
     experience,
     education,
     location,
-    is_active,
     created_at,
-    updated_at
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
 ) VALUES (
     $1,
     $2,
@@ -46,27 +112,37 @@ INSERT INTO vacancies (
     $13,
     $14,
     $15,
-    $16
+    $16,
+    $17,
+    $18,
+    $19,
+    $20,
+    $21
 )
 `
 
 type CreateVacancyParams struct {
-	ID               uuid.UUID
-	CompanyID        uuid.UUID
-	Title            string
-	Description      string
-	Contacts         string
-	Requirements     string
-	Responsibilities string
-	Conditions       string
-	Employment       string
-	Schedule         string
-	Experience       string
-	Education        string
-	Location         string
-	IsActive         bool
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	ID                uuid.UUID
+	CompanyID         uuid.UUID
+	Title             string
+	Description       string
+	Contacts          string
+	Requirements      string
+	Responsibilities  string
+	Conditions        string
+	Employment        string
+	Schedule          string
+	Experience        string
+	Education         string
+	Location          string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	SalaryMin         pgtype.Int4
+	SalaryMax         pgtype.Int4
+	SalaryCurrency    pgtype.Text
+	SalaryGross       pgtype.Bool
+	State             string
+	ModerationComment string
 }
 
 func (q *Queries) CreateVacancy(ctx context.Context, arg CreateVacancyParams) error {
@@ -84,13 +160,31 @@ func (q *Queries) CreateVacancy(ctx context.Context, arg CreateVacancyParams) er
 		arg.Experience,
 		arg.Education,
 		arg.Location,
-		arg.IsActive,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.SalaryMin,
+		arg.SalaryMax,
+		arg.SalaryCurrency,
+		arg.SalaryGross,
+		arg.State,
+		arg.ModerationComment,
 	)
 	return err
 }
 
+const deleteVacancy = `-- name: DeleteVacancy :execrows
+DELETE FROM vacancies
+WHERE id = $1
+`
+
+func (q *Queries) DeleteVacancy(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteVacancy, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getVacancyByID = `-- name: GetVacancyByID :one
 SELECT
     id,
@@ -106,9 +200,14 @@ SELECT
     experience,
     education,
     location,
-    is_active,
     created_at,
-    updated_at
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
 FROM vacancies
 WHERE id = $1
 `
@@ -130,14 +229,19 @@ func (q *Queries) GetVacancyByID(ctx context.Context, id uuid.UUID) (Vacancy, er
 		&i.Experience,
 		&i.Education,
 		&i.Location,
-		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SalaryMin,
+		&i.SalaryMax,
+		&i.SalaryCurrency,
+		&i.SalaryGross,
+		&i.State,
+		&i.ModerationComment,
 	)
 	return i, err
 }
 
-const listActiveVacancies = `-- name: ListActiveVacancies :many
+const searchVacancies = `-- name: SearchVacancies :many
 SELECT
     id,
     company_id,
@@ -152,16 +256,82 @@ SELECT
     experience,
     education,
     location,
-    is_active,
     created_at,
-    updated_at
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
 FROM vacancies
-WHERE is_active = TRUE
-ORDER BY created_at DESC
+WHERE ($1::uuid IS NULL OR company_id = $1::uuid)
+    AND ($2::text IS NULL OR location = $2::text)
+    AND ($3::text IS NULL OR employment = $3::text)
+    AND ($4::text IS NULL OR schedule = $4::text)
+    AND ($5::text IS NULL OR experience = $5::text)
+    AND ($6::text IS NULL OR education = $6::text)
+    AND ($7::text IS NULL OR state = $7::text)
+    AND (
+        $8::integer IS NULL
+        OR (
+            (salary_min IS NOT NULL OR salary_max IS NOT NULL)
+            AND (salary_min IS NULL OR salary_min <= $8::integer)
+            AND (salary_max IS NULL OR salary_max >= $8::integer)
+        )
+    )
+    AND ($9::text IS NULL OR salary_currency = $9::text)
+    AND (
+        $10::boolean IS NULL
+        OR EXISTS (
+            SELECT 1 FROM companies
+            WHERE companies.id = vacancies.company_id
+                AND companies.approved = $10::boolean
+        )
+    )
+ORDER BY
+    CASE WHEN $11::text = 'title' THEN title END ASC,
+    CASE WHEN $11::text = '-title' THEN title END DESC,
+    CASE WHEN $11::text = 'salary' THEN COALESCE(salary_min, salary_max) END ASC NULLS LAST,
+    CASE WHEN $11::text = '-salary' THEN COALESCE(salary_max, salary_min) END DESC NULLS LAST,
+    CASE WHEN $11::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT $12 OFFSET $13
 `
 
-func (q *Queries) ListActiveVacancies(ctx context.Context) ([]Vacancy, error) {
-	rows, err := q.db.Query(ctx, listActiveVacancies)
+type SearchVacanciesParams struct {
+	CompanyID       pgtype.UUID
+	Location        pgtype.Text
+	Employment      pgtype.Text
+	Schedule        pgtype.Text
+	Experience      pgtype.Text
+	Education       pgtype.Text
+	State           pgtype.Text
+	Salary          pgtype.Int4
+	SalaryCurrency  pgtype.Text
+	CompanyApproved pgtype.Bool
+	Sort            string
+	Limit           int32
+	Offset          int32
+}
+
+func (q *Queries) SearchVacancies(ctx context.Context, arg SearchVacanciesParams) ([]Vacancy, error) {
+	rows, err := q.db.Query(ctx, searchVacancies,
+		arg.CompanyID,
+		arg.Location,
+		arg.Employment,
+		arg.Schedule,
+		arg.Experience,
+		arg.Education,
+		arg.State,
+		arg.Salary,
+		arg.SalaryCurrency,
+		arg.CompanyApproved,
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -183,9 +353,14 @@ func (q *Queries) ListActiveVacancies(ctx context.Context) ([]Vacancy, error) {
 			&i.Experience,
 			&i.Education,
 			&i.Location,
-			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SalaryMin,
+			&i.SalaryMax,
+			&i.SalaryCurrency,
+			&i.SalaryGross,
+			&i.State,
+			&i.ModerationComment,
 		); err != nil {
 			return nil, err
 		}
@@ -197,7 +372,7 @@ func (q *Queries) ListActiveVacancies(ctx context.Context) ([]Vacancy, error) {
 	return items, nil
 }
 
-const listVacanciesByCompany = `-- name: ListVacanciesByCompany :many
+const searchVacanciesByCursor = `-- name: SearchVacanciesByCursor :many
 SELECT
     id,
     company_id,
@@ -212,16 +387,79 @@ SELECT
     experience,
     education,
     location,
-    is_active,
     created_at,
-    updated_at
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
 FROM vacancies
-WHERE company_id = $1
-ORDER BY created_at DESC
+WHERE ($1::uuid IS NULL OR company_id = $1::uuid)
+    AND ($2::text IS NULL OR location = $2::text)
+    AND ($3::text IS NULL OR employment = $3::text)
+    AND ($4::text IS NULL OR schedule = $4::text)
+    AND ($5::text IS NULL OR experience = $5::text)
+    AND ($6::text IS NULL OR education = $6::text)
+    AND ($7::text IS NULL OR state = $7::text)
+    AND (
+        $8::integer IS NULL
+        OR (
+            (salary_min IS NOT NULL OR salary_max IS NOT NULL)
+            AND (salary_min IS NULL OR salary_min <= $8::integer)
+            AND (salary_max IS NULL OR salary_max >= $8::integer)
+        )
+    )
+    AND ($9::text IS NULL OR salary_currency = $9::text)
+    AND (
+        $10::boolean IS NULL
+        OR EXISTS (
+            SELECT 1 FROM companies
+            WHERE companies.id = vacancies.company_id
+                AND companies.approved = $10::boolean
+        )
+    )
+    AND (
+        $11::timestamptz IS NULL
+        OR (created_at, id) < ($11::timestamptz, $12::uuid)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT $13
 `
 
-func (q *Queries) ListVacanciesByCompany(ctx context.Context, companyID uuid.UUID) ([]Vacancy, error) {
-	rows, err := q.db.Query(ctx, listVacanciesByCompany, companyID)
+type SearchVacanciesByCursorParams struct {
+	CompanyID       pgtype.UUID
+	Location        pgtype.Text
+	Employment      pgtype.Text
+	Schedule        pgtype.Text
+	Experience      pgtype.Text
+	Education       pgtype.Text
+	State           pgtype.Text
+	Salary          pgtype.Int4
+	SalaryCurrency  pgtype.Text
+	CompanyApproved pgtype.Bool
+	AfterCreatedAt  pgtype.Timestamptz
+	AfterID         pgtype.UUID
+	Limit           int32
+}
+
+func (q *Queries) SearchVacanciesByCursor(ctx context.Context, arg SearchVacanciesByCursorParams) ([]Vacancy, error) {
+	rows, err := q.db.Query(ctx, searchVacanciesByCursor,
+		arg.CompanyID,
+		arg.Location,
+		arg.Employment,
+		arg.Schedule,
+		arg.Experience,
+		arg.Education,
+		arg.State,
+		arg.Salary,
+		arg.SalaryCurrency,
+		arg.CompanyApproved,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -243,9 +481,14 @@ func (q *Queries) ListVacanciesByCompany(ctx context.Context, companyID uuid.UUI
 			&i.Experience,
 			&i.Education,
 			&i.Location,
-			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SalaryMin,
+			&i.SalaryMax,
+			&i.SalaryCurrency,
+			&i.SalaryGross,
+			&i.State,
+			&i.ModerationComment,
 		); err != nil {
 			return nil, err
 		}
@@ -272,29 +515,39 @@ SET
     experience = $10,
     education = $11,
     location = $12,
-    is_active = $13,
-    created_at = $14,
-    updated_at = $15
-WHERE id = $16
+    created_at = $13,
+    updated_at = $14,
+    salary_min = $15,
+    salary_max = $16,
+    salary_currency = $17,
+    salary_gross = $18,
+    state = $19,
+    moderation_comment = $20
+WHERE id = $21
 `
 
 type UpdateVacancyParams struct {
-	CompanyID        uuid.UUID
-	Title            string
-	Description      string
-	Contacts         string
-	Requirements     string
-	Responsibilities string
-	Conditions       string
-	Employment       string
-	Schedule         string
-	Experience       string
-	Education        string
-	Location         string
-	IsActive         bool
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	ID               uuid.UUID
+	CompanyID         uuid.UUID
+	Title             string
+	Description       string
+	Contacts          string
+	Requirements      string
+	Responsibilities  string
+	Conditions        string
+	Employment        string
+	Schedule          string
+	Experience        string
+	Education         string
+	Location          string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	SalaryMin         pgtype.Int4
+	SalaryMax         pgtype.Int4
+	SalaryCurrency    pgtype.Text
+	SalaryGross       pgtype.Bool
+	State             string
+	ModerationComment string
+	ID                uuid.UUID
 }
 
 func (q *Queries) UpdateVacancy(ctx context.Context, arg UpdateVacancyParams) error {
@@ -311,9 +564,14 @@ func (q *Queries) UpdateVacancy(ctx context.Context, arg UpdateVacancyParams) er
 		arg.Experience,
 		arg.Education,
 		arg.Location,
-		arg.IsActive,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.SalaryMin,
+		arg.SalaryMax,
+		arg.SalaryCurrency,
+		arg.SalaryGross,
+		arg.State,
+		arg.ModerationComment,
 		arg.ID,
 	)
 	return err
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type pipelineRepo struct {
+	db *pgxpool.Pool
+	q  *pgqueries.Queries
+}
+
+// pipelineStageRow — этап конвейера в колонке stages.
+type pipelineStageRow struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// NewPipelineRepo принимает пул: новая версия конвейера заменяет прежнюю в
+// одной транзакции.
+func NewPipelineRepo(db *pgxpool.Pool) *pipelineRepo {
+	return &pipelineRepo{db, pgqueries.New(db)}
+}
+
+func (r *pipelineRepo) Save(ctx context.Context, p *domain.Pipeline) error {
+	pi := p.Immutable()
+
+	rows := make([]pipelineStageRow, 0, len(pi.Stages))
+	for _, st := range pi.Stages {
+		rows = append(rows, pipelineStageRow{Key: st.Key, Name: st.Name, Type: string(st.Type)})
+	}
+	stages, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("error marshaling pipeline stages: %w", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	_, err = q.ReplaceCurrentPipeline(ctx, pgqueries.ReplaceCurrentPipelineParams{
+		ReplacedAt: pgtype.Timestamptz{Time: pi.CreatedAt, Valid: true},
+		CompanyID:  pi.CompanyID,
+		VacancyID:  nullUUID(pi.VacancyID),
+	})
+	if err != nil {
+		return fmt.Errorf("error replacing current pipeline: %w", err)
+	}
+
+	err = q.CreatePipeline(ctx, pgqueries.CreatePipelineParams{
+		ID:        pi.ID,
+		CompanyID: pi.CompanyID,
+		VacancyID: nullUUID(pi.VacancyID),
+		Stages:    stages,
+		CreatedAt: pi.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating pipeline: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *pipelineRepo) Current(ctx context.Context, companyID, vacancyID uuid.UUID) (*domain.Pipeline, error) {
+	pdb, err := r.q.GetCurrentPipeline(ctx, pgqueries.GetCurrentPipelineParams{
+		CompanyID: companyID,
+		VacancyID: nullUUID(vacancyID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting current pipeline: %w", err)
+	}
+
+	return reconstructPipeline(pdb)
+}
+
+func (r *pipelineRepo) ByID(ctx context.Context, id uuid.UUID) (*domain.Pipeline, error) {
+	pdb, err := r.q.GetPipelineByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting pipeline by id: %w", err)
+	}
+
+	return reconstructPipeline(pdb)
+}
+
+func (r *pipelineRepo) Clear(ctx context.Context, companyID, vacancyID uuid.UUID, at time.Time) error {
+	affected, err := r.q.ReplaceCurrentPipeline(ctx, pgqueries.ReplaceCurrentPipelineParams{
+		ReplacedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		CompanyID:  companyID,
+		VacancyID:  nullUUID(vacancyID),
+	})
+	if err != nil {
+		return fmt.Errorf("error clearing pipeline: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func reconstructPipeline(pdb pgqueries.Pipeline) (*domain.Pipeline, error) {
+	var rows []pipelineStageRow
+	if err := json.Unmarshal(pdb.Stages, &rows); err != nil {
+		return nil, fmt.Errorf("error unmarshaling pipeline stages: %w", err)
+	}
+
+	stages := make([]domain.PipelineStage, 0, len(rows))
+	for _, row := range rows {
+		stages = append(stages, domain.PipelineStage{
+			Key:  row.Key,
+			Name: row.Name,
+			Type: domain.ResponseStatus(row.Type),
+		})
+	}
+
+	p, err := domain.ReconstructPipeline(domain.PipelineImmutable{
+		ID:        pdb.ID,
+		CompanyID: pdb.CompanyID,
+		VacancyID: uuidOrNil(pdb.VacancyID),
+		Stages:    stages,
+		CreatedAt: pdb.CreatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing pipeline: %w", err)
+	}
+
+	return p, nil
+}
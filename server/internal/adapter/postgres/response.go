@@ -1,93 +1,269 @@
 package postgres
 
 import (
-    "context"
-    "database/sql"
-    "errors"
-    "fmt"
-
-    "github.com/google/uuid"
-    "github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
-    "github.com/hr-platform-mosprom/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type responseRepo struct {
-    q *pgqueries.Queries
+	db *pgxpool.Pool
+	q  *pgqueries.Queries
 }
 
-func NewResponseRepo(q *pgqueries.Queries) *responseRepo {
-    return &responseRepo{q}
+// NewResponseRepo принимает пул: смена этапа сохраняется в одной транзакции
+// с записью истории.
+func NewResponseRepo(db *pgxpool.Pool) *responseRepo {
+	return &responseRepo{db, pgqueries.New(db)}
 }
 
-func (r *responseRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Response, error) {
-    rdb, err := r.q.GetResponseByID(ctx, id)
-    if err != nil {
-        if errors.Is(err, sql.ErrNoRows) {
-            return nil, domain.ErrNotFound
-        }
-        return nil, fmt.Errorf("error getting response by id: %w", err)
-    }
-
-    resp, err := domain.ReconstructResponse(domain.ResponseImmutable{
-        ID:         rdb.ID,
-        VacancyID:  rdb.VacancyID,
-        FullName:   rdb.FullName,
-        Email:      rdb.Email,
-        Phone:      rdb.Phone,
-        CoverLetter: rdb.CoverLetter,
-        ResumeURL:  rdb.ResumeUrl,
-        Status:     rdb.Status,
-        CreatedAt:  rdb.CreatedAt,
-        UpdatedAt:  rdb.UpdatedAt,
-    })
-    if err != nil {
-        return nil, fmt.Errorf("error reconstructing response: %w", err)
-    }
-
-    return resp, nil
+func (r *responseRepo) ByID(ctx context.Context, id uuid.UUID) (*domain.Response, error) {
+	rdb, err := r.q.GetResponseByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting response by id: %w", err)
+	}
+
+	return reconstructResponse(rdb)
+}
+
+func (r *responseRepo) ByVacancy(ctx context.Context, vacancyID uuid.UUID, page domain.PageRequest) ([]*domain.Response, int, error) {
+	return r.Search(ctx, domain.ResponseFilter{
+		VacancyID: &vacancyID,
+		Page:      page,
+	})
+}
+
+func (r *responseRepo) Search(ctx context.Context, f domain.ResponseFilter) ([]*domain.Response, int, error) {
+	rows, err := r.q.SearchResponses(ctx, pgqueries.SearchResponsesParams{
+		VacancyID: optionalUUID(f.VacancyID),
+		Status:    optionalResponseStatus(f.Status),
+		Sort:      f.Page.Sort.String(),
+		Limit:     int32(f.Page.Limit()),
+		Offset:    int32(f.Page.Offset()),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching responses: %w", err)
+	}
+
+	total, err := r.q.CountResponses(ctx, pgqueries.CountResponsesParams{
+		VacancyID: optionalUUID(f.VacancyID),
+		Status:    optionalResponseStatus(f.Status),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting responses: %w", err)
+	}
+
+	responses := make([]*domain.Response, 0, len(rows))
+	for _, row := range rows {
+		resp, err := reconstructResponse(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, int(total), nil
+}
+
+func (r *responseRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	affected, err := r.q.DeleteResponse(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting response: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
 }
 
 func (r *responseRepo) Save(ctx context.Context, resp *domain.Response) error {
-    _, err := r.GetByID(ctx, resp.Immutable().ID)
-    if err != nil {
-        if errors.Is(err, domain.ErrNotFound) {
-            return r.create(ctx, resp)
-        }
-        return fmt.Errorf("error getting response by id: %w", err)
-    }
-    return r.update(ctx, resp)
+	return saveResponse(ctx, r.q, resp)
+}
+
+func (r *responseRepo) SaveWithStatusChange(ctx context.Context, resp *domain.Response, change *domain.ResponseStatusChange) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	// Отклик обновляется, только если он всё ещё на этапе, с которого
+	// проверялся переход: из двух одновременных переходов проходит один
+	ci := change.Immutable()
+	if ci.FromStage == "" {
+		err = createResponse(ctx, q, resp)
+	} else {
+		err = updateResponse(ctx, q, resp, ci.FromStage)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = q.CreateResponseStatusChange(ctx, pgqueries.CreateResponseStatusChangeParams{
+		ID:         ci.ID,
+		ResponseID: ci.ResponseID,
+		FromStatus: string(ci.From),
+		ToStatus:   string(ci.To),
+		FromStage:  ci.FromStage,
+		ToStage:    ci.ToStage,
+		ActorID:    nullUUID(ci.Actor.ID),
+		ActorRole:  string(ci.Actor.Role),
+		ChangedAt:  ci.ChangedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating response status change: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
 }
 
-func (r *responseRepo) create(ctx context.Context, resp *domain.Response) error {
-    im := resp.Immutable()
-    err := r.q.CreateResponse(ctx, pgqueries.CreateResponseParams{
-        ID:          im.ID,
-        VacancyID:   im.VacancyID,
-        FullName:    im.FullName,
-        Email:       im.Email,
-        Phone:       im.Phone,
-        CoverLetter: im.CoverLetter,
-        ResumeUrl:   im.ResumeURL,
-        Status:      im.Status,
-        CreatedAt:   im.CreatedAt,
-        UpdatedAt:   im.UpdatedAt,
-    })
-    if err != nil {
-        return fmt.Errorf("error creating response: %w", err)
-    }
-    return nil
+func (r *responseRepo) StatusHistory(ctx context.Context, responseID uuid.UUID) ([]*domain.ResponseStatusChange, error) {
+	rows, err := r.q.ListResponseStatusChanges(ctx, responseID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing response status changes: %w", err)
+	}
+
+	changes := make([]*domain.ResponseStatusChange, 0, len(rows))
+	for _, row := range rows {
+		c, err := reconstructResponseStatusChange(row)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+func saveResponse(ctx context.Context, q *pgqueries.Queries, resp *domain.Response) error {
+	current, err := q.GetResponseByID(ctx, resp.Immutable().ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return createResponse(ctx, q, resp)
+		}
+		return fmt.Errorf("error getting response by id: %w", err)
+	}
+	return updateResponse(ctx, q, resp, current.Stage)
+}
+
+func createResponse(ctx context.Context, q *pgqueries.Queries, resp *domain.Response) error {
+	im := resp.Immutable()
+	err := q.CreateResponse(ctx, pgqueries.CreateResponseParams{
+		ID:          im.ID,
+		VacancyID:   im.VacancyID,
+		FullName:    im.FullName,
+		Email:       im.Email,
+		Phone:       im.Phone,
+		CoverLetter: im.CoverLetter,
+		ResumeUrl:   im.ResumeURL,
+		Status:      string(im.Status),
+		CreatedAt:   im.CreatedAt,
+		UpdatedAt:   im.UpdatedAt,
+		PipelineID:  nullUUID(im.PipelineID),
+		Stage:       im.Stage,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating response: %w", err)
+	}
+	return nil
+}
+
+// updateResponse возвращает ErrConflict, если отклик уже не на этапе fromStage.
+func updateResponse(ctx context.Context, q *pgqueries.Queries, resp *domain.Response, fromStage string) error {
+	im := resp.Immutable()
+	affected, err := q.UpdateResponseStatus(ctx, pgqueries.UpdateResponseStatusParams{
+		ID:        im.ID,
+		Status:    string(im.Status),
+		Stage:     im.Stage,
+		UpdatedAt: im.UpdatedAt,
+		FromStage: fromStage,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating response: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: response stage changed concurrently", domain.ErrConflict)
+	}
+	return nil
+}
+
+func reconstructResponse(rdb pgqueries.Response) (*domain.Response, error) {
+	status, err := domain.ParseResponseStatus(rdb.Status)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing response: %w", err)
+	}
+
+	resp, err := domain.ReconstructResponse(domain.ResponseImmutable{
+		ID:          rdb.ID,
+		VacancyID:   rdb.VacancyID,
+		FullName:    rdb.FullName,
+		Email:       rdb.Email,
+		Phone:       rdb.Phone,
+		CoverLetter: rdb.CoverLetter,
+		ResumeURL:   rdb.ResumeUrl,
+		Status:      status,
+		PipelineID:  uuidOrNil(rdb.PipelineID),
+		Stage:       rdb.Stage,
+		CreatedAt:   rdb.CreatedAt,
+		UpdatedAt:   rdb.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing response: %w", err)
+	}
+
+	return resp, nil
+}
+
+func reconstructResponseStatusChange(cdb pgqueries.ResponseStatusHistory) (*domain.ResponseStatusChange, error) {
+	to, err := domain.ParseResponseStatus(cdb.ToStatus)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing response status change: %w", err)
+	}
+
+	actor := domain.Actor{ID: uuidOrNil(cdb.ActorID)}
+	if cdb.ActorRole != "" {
+		role, err := domain.ParseRole(cdb.ActorRole)
+		if err != nil {
+			return nil, fmt.Errorf("error reconstructing response status change: %w", err)
+		}
+		actor.Role = role
+	}
+
+	c, err := domain.ReconstructResponseStatusChange(domain.ResponseStatusChangeImmutable{
+		ID:         cdb.ID,
+		ResponseID: cdb.ResponseID,
+		From:       domain.ResponseStatus(cdb.FromStatus),
+		To:         to,
+		FromStage:  cdb.FromStage,
+		ToStage:    cdb.ToStage,
+		Actor:      actor,
+		ChangedAt:  cdb.ChangedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing response status change: %w", err)
+	}
+
+	return c, nil
 }
 
-func (r *responseRepo) update(ctx context.Context, resp *domain.Response) error {
-    im := resp.Immutable()
-    // Часто обновляется только статус; если у тебя общий Update — оставим полный апдейт
-    err := r.q.UpdateResponseStatus(ctx, pgqueries.UpdateResponseStatusParams{
-        ID:        im.ID,
-        Status:    im.Status,
-        UpdatedAt: im.UpdatedAt,
-    })
-    if err != nil {
-        return fmt.Errorf("error updating response: %w", err)
-    }
-    return nil
+func optionalResponseStatus(s *domain.ResponseStatus) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: string(*s), Valid: true}
 }
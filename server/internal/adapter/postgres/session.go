@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type sessionRepo struct {
+	db *pgxpool.Pool
+	q  *pgqueries.Queries
+}
+
+// NewSessionRepo принимает пул, а не Queries: ротация выполняется в транзакции.
+func NewSessionRepo(db *pgxpool.Pool) *sessionRepo {
+	return &sessionRepo{db, pgqueries.New(db)}
+}
+
+func (r *sessionRepo) Create(ctx context.Context, s *domain.Session) error {
+	return r.create(ctx, r.q, s)
+}
+
+func (r *sessionRepo) GetByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	sdb, err := r.q.GetSessionByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting session by refresh token hash: %w", err)
+	}
+
+	return reconstructSession(sdb)
+}
+
+func (r *sessionRepo) Rotate(ctx context.Context, prev, next *domain.Session, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	affected, err := q.MarkSessionRotated(ctx, pgqueries.MarkSessionRotatedParams{
+		RotatedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		ID:        prev.Immutable().ID,
+	})
+	if err != nil {
+		return fmt.Errorf("error marking session rotated: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrConflict
+	}
+
+	if err := r.create(ctx, q, next); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepo) IsFamilyActive(ctx context.Context, familyID uuid.UUID, at time.Time) (bool, error) {
+	active, err := r.q.IsSessionFamilyActive(ctx, pgqueries.IsSessionFamilyActiveParams{
+		FamilyID: familyID,
+		Now:      at,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error checking session family: %w", err)
+	}
+	return active, nil
+}
+
+func (r *sessionRepo) RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	err := r.q.RevokeSessionFamily(ctx, pgqueries.RevokeSessionFamilyParams{
+		RevokedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		FamilyID:  familyID,
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking session family: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepo) RevokeBySubject(ctx context.Context, subjectID uuid.UUID, at time.Time) error {
+	err := r.q.RevokeSessionsBySubject(ctx, pgqueries.RevokeSessionsBySubjectParams{
+		RevokedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		SubjectID: subjectID,
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking sessions by subject: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepo) RevokeByCompany(ctx context.Context, companyID uuid.UUID, at time.Time) error {
+	err := r.q.RevokeSessionsByCompany(ctx, pgqueries.RevokeSessionsByCompanyParams{
+		RevokedAt: pgtype.Timestamptz{Time: at, Valid: true},
+		CompanyID: nullUUID(companyID),
+	})
+	if err != nil {
+		return fmt.Errorf("error revoking sessions by company: %w", err)
+	}
+	return nil
+}
+
+func (r *sessionRepo) create(ctx context.Context, q *pgqueries.Queries, s *domain.Session) error {
+	im := s.Immutable()
+	err := q.CreateSession(ctx, pgqueries.CreateSessionParams{
+		ID:               im.ID,
+		FamilyID:         im.FamilyID,
+		SubjectID:        im.SubjectID,
+		Role:             string(im.Role),
+		RefreshTokenHash: im.RefreshTokenHash,
+		ExpiresAt:        im.ExpiresAt,
+		CreatedAt:        im.CreatedAt,
+		RotatedAt:        optionalTimestamptz(im.RotatedAt),
+		RevokedAt:        optionalTimestamptz(im.RevokedAt),
+		CompanyID:        nullUUID(im.CompanyID),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating session: %w", err)
+	}
+	return nil
+}
+
+func reconstructSession(sdb pgqueries.Session) (*domain.Session, error) {
+	role, err := domain.ParseRole(sdb.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing session: %w", err)
+	}
+
+	s, err := domain.ReconstructSession(domain.SessionImmutable{
+		ID:               sdb.ID,
+		FamilyID:         sdb.FamilyID,
+		SubjectID:        sdb.SubjectID,
+		Role:             role,
+		CompanyID:        uuidOrNil(sdb.CompanyID),
+		RefreshTokenHash: sdb.RefreshTokenHash,
+		ExpiresAt:        sdb.ExpiresAt,
+		CreatedAt:        sdb.CreatedAt,
+		RotatedAt:        timestamptzPtr(sdb.RotatedAt),
+		RevokedAt:        timestamptzPtr(sdb.RevokedAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing session: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type twoFactorRepo struct {
+	db *pgxpool.Pool
+	q  *pgqueries.Queries
+}
+
+// NewTwoFactorRepo принимает пул: резервные коды заменяются в транзакции.
+func NewTwoFactorRepo(db *pgxpool.Pool) *twoFactorRepo {
+	return &twoFactorRepo{db, pgqueries.New(db)}
+}
+
+func (r *twoFactorRepo) Get(ctx context.Context, subjectID uuid.UUID) (*domain.TwoFactor, error) {
+	tdb, err := r.q.GetTwoFactor(ctx, subjectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting two-factor: %w", err)
+	}
+
+	return reconstructTwoFactor(tdb)
+}
+
+func (r *twoFactorRepo) Save(ctx context.Context, t *domain.TwoFactor) error {
+	im := t.Immutable()
+	err := r.q.UpsertTwoFactor(ctx, pgqueries.UpsertTwoFactorParams{
+		SubjectID:    im.SubjectID,
+		Role:         string(im.Role),
+		Secret:       im.Secret,
+		ConfirmedAt:  optionalTimestamptz(im.ConfirmedAt),
+		LastUsedStep: im.LastUsedStep,
+		CreatedAt:    im.CreatedAt,
+		UpdatedAt:    im.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error saving two-factor: %w", err)
+	}
+	return nil
+}
+
+func (r *twoFactorRepo) UseStep(ctx context.Context, subjectID uuid.UUID, step int64, at time.Time) error {
+	affected, err := r.q.UseTwoFactorStep(ctx, pgqueries.UseTwoFactorStepParams{
+		LastUsedStep: step,
+		UpdatedAt:    at,
+		SubjectID:    subjectID,
+	})
+	if err != nil {
+		return fmt.Errorf("error using two-factor step: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *twoFactorRepo) Delete(ctx context.Context, subjectID uuid.UUID) error {
+	err := r.q.DeleteTwoFactor(ctx, subjectID)
+	if err != nil {
+		return fmt.Errorf("error deleting two-factor: %w", err)
+	}
+	return nil
+}
+
+func (r *twoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, subjectID uuid.UUID, codeHashes []string, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	q := r.q.WithTx(tx)
+
+	err = q.DeleteRecoveryCodes(ctx, subjectID)
+	if err != nil {
+		return fmt.Errorf("error deleting recovery codes: %w", err)
+	}
+
+	for _, codeHash := range codeHashes {
+		err = q.CreateRecoveryCode(ctx, pgqueries.CreateRecoveryCodeParams{
+			ID:        uuid.New(),
+			SubjectID: subjectID,
+			CodeHash:  codeHash,
+			CreatedAt: at,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *twoFactorRepo) UseRecoveryCode(ctx context.Context, subjectID uuid.UUID, codeHash string, at time.Time) error {
+	affected, err := r.q.UseRecoveryCode(ctx, pgqueries.UseRecoveryCodeParams{
+		UsedAt:    at,
+		SubjectID: subjectID,
+		CodeHash:  codeHash,
+	})
+	if err != nil {
+		return fmt.Errorf("error using recovery code: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *twoFactorRepo) CountRecoveryCodes(ctx context.Context, subjectID uuid.UUID) (int, error) {
+	count, err := r.q.CountUnusedRecoveryCodes(ctx, subjectID)
+	if err != nil {
+		return 0, fmt.Errorf("error counting recovery codes: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *twoFactorRepo) IsRequired(ctx context.Context, role port.Role) (bool, error) {
+	required, err := r.q.GetTwoFactorRequirement(ctx, string(role))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting two-factor requirement: %w", err)
+	}
+	return required, nil
+}
+
+func (r *twoFactorRepo) SetRequired(ctx context.Context, role port.Role, required bool, by uuid.UUID, at time.Time) error {
+	err := r.q.SetTwoFactorRequirement(ctx, pgqueries.SetTwoFactorRequirementParams{
+		Role:      string(role),
+		Required:  required,
+		UpdatedBy: by,
+		UpdatedAt: at,
+	})
+	if err != nil {
+		return fmt.Errorf("error setting two-factor requirement: %w", err)
+	}
+	return nil
+}
+
+func reconstructTwoFactor(tdb pgqueries.TwoFactor) (*domain.TwoFactor, error) {
+	role, err := domain.ParseRole(tdb.Role)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing two-factor: %w", err)
+	}
+
+	t, err := domain.ReconstructTwoFactor(domain.TwoFactorImmutable{
+		SubjectID:    tdb.SubjectID,
+		Role:         role,
+		Secret:       tdb.Secret,
+		ConfirmedAt:  timestamptzPtr(tdb.ConfirmedAt),
+		LastUsedStep: tdb.LastUsedStep,
+		CreatedAt:    tdb.CreatedAt,
+		UpdatedAt:    tdb.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing two-factor: %w", err)
+	}
+
+	return t, nil
+}
@@ -30,14 +30,17 @@ func (r *universityRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Uni
 	}
 
 	university, err := domain.ReconstructUniversity(domain.UniversityImmutable{
-		ID:           universityFromDB.ID,
-		Title:        universityFromDB.Title,
-		Login:        universityFromDB.Login,
-		PasswordHash: universityFromDB.PasswordHash,
-		INN:          universityFromDB.Inn,
-		Confirmed:    universityFromDB.Confirmed,
-		CreatedAt:    universityFromDB.CreatedAt,
-		UpdatedAt:    universityFromDB.UpdatedAt,
+		ID:              universityFromDB.ID,
+		Title:           universityFromDB.Title,
+		Login:           universityFromDB.Login,
+		PasswordHash:    universityFromDB.PasswordHash,
+		INN:             universityFromDB.Inn,
+		Email:           universityFromDB.Email,
+		EmailVerified:   universityFromDB.EmailVerified,
+		Confirmed:       universityFromDB.Confirmed,
+		RejectionReason: universityFromDB.RejectionReason,
+		CreatedAt:       universityFromDB.CreatedAt,
+		UpdatedAt:       universityFromDB.UpdatedAt,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error reconstructing university: %w", err)
@@ -56,14 +59,17 @@ func (r *universityRepo) GetByLogin(ctx context.Context, login string) (*domain.
 	}
 
 	university, err := domain.ReconstructUniversity(domain.UniversityImmutable{
-		ID:           universityFromDB.ID,
-		Title:        universityFromDB.Title,
-		Login:        universityFromDB.Login,
-		PasswordHash: universityFromDB.PasswordHash,
-		INN:          universityFromDB.Inn,
-		Confirmed:    universityFromDB.Confirmed,
-		CreatedAt:    universityFromDB.CreatedAt,
-		UpdatedAt:    universityFromDB.UpdatedAt,
+		ID:              universityFromDB.ID,
+		Title:           universityFromDB.Title,
+		Login:           universityFromDB.Login,
+		PasswordHash:    universityFromDB.PasswordHash,
+		INN:             universityFromDB.Inn,
+		Email:           universityFromDB.Email,
+		EmailVerified:   universityFromDB.EmailVerified,
+		Confirmed:       universityFromDB.Confirmed,
+		RejectionReason: universityFromDB.RejectionReason,
+		CreatedAt:       universityFromDB.CreatedAt,
+		UpdatedAt:       universityFromDB.UpdatedAt,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error reconstructing university: %w", err)
@@ -72,6 +78,46 @@ func (r *universityRepo) GetByLogin(ctx context.Context, login string) (*domain.
 	return university, nil
 }
 
+func (r *universityRepo) List(ctx context.Context, f domain.UniversityFilter) ([]*domain.University, int, error) {
+	rows, err := r.q.ListUniversities(ctx, pgqueries.ListUniversitiesParams{
+		Confirmed: optionalBool(f.Confirmed),
+		Sort:      f.Page.Sort.String(),
+		Limit:     int32(f.Page.Limit()),
+		Offset:    int32(f.Page.Offset()),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing universities: %w", err)
+	}
+
+	total, err := r.q.CountUniversities(ctx, optionalBool(f.Confirmed))
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting universities: %w", err)
+	}
+
+	universities := make([]*domain.University, 0, len(rows))
+	for _, row := range rows {
+		university, err := domain.ReconstructUniversity(domain.UniversityImmutable{
+			ID:              row.ID,
+			Title:           row.Title,
+			Login:           row.Login,
+			PasswordHash:    row.PasswordHash,
+			INN:             row.Inn,
+			Email:           row.Email,
+			EmailVerified:   row.EmailVerified,
+			Confirmed:       row.Confirmed,
+			RejectionReason: row.RejectionReason,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("error reconstructing university: %w", err)
+		}
+		universities = append(universities, university)
+	}
+
+	return universities, int(total), nil
+}
+
 func (r *universityRepo) Save(ctx context.Context, university *domain.University) error {
 	_, err := r.GetByID(ctx, university.Immutable().ID)
 	if err != nil {
@@ -97,14 +143,17 @@ func (r *universityRepo) create(ctx context.Context, university *domain.Universi
 	universityImmutable := university.Immutable()
 
 	err := r.q.CreateUniversity(ctx, pgqueries.CreateUniversityParams{
-		ID:           universityImmutable.ID,
-		Title:        universityImmutable.Title,
-		Login:        universityImmutable.Login,
-		Inn:          universityImmutable.INN,
-		Confirmed:    universityImmutable.Confirmed,
-		PasswordHash: universityImmutable.PasswordHash,
-		CreatedAt:    universityImmutable.CreatedAt,
-		UpdatedAt:    universityImmutable.UpdatedAt,
+		ID:              universityImmutable.ID,
+		Title:           universityImmutable.Title,
+		Login:           universityImmutable.Login,
+		Inn:             universityImmutable.INN,
+		Email:           universityImmutable.Email,
+		EmailVerified:   universityImmutable.EmailVerified,
+		Confirmed:       universityImmutable.Confirmed,
+		PasswordHash:    universityImmutable.PasswordHash,
+		RejectionReason: universityImmutable.RejectionReason,
+		CreatedAt:       universityImmutable.CreatedAt,
+		UpdatedAt:       universityImmutable.UpdatedAt,
 	})
 	if err != nil {
 		if isUniqueViolationError(err) {
@@ -120,14 +169,17 @@ func (r *universityRepo) update(ctx context.Context, university *domain.Universi
 	universityImmutable := university.Immutable()
 
 	err := r.q.UpdateUniversity(ctx, pgqueries.UpdateUniversityParams{
-		ID:           universityImmutable.ID,
-		Title:        universityImmutable.Title,
-		Login:        universityImmutable.Login,
-		Inn:          universityImmutable.INN,
-		Confirmed:    universityImmutable.Confirmed,
-		PasswordHash: universityImmutable.PasswordHash,
-		CreatedAt:    universityImmutable.CreatedAt,
-		UpdatedAt:    universityImmutable.UpdatedAt,
+		ID:              universityImmutable.ID,
+		Title:           universityImmutable.Title,
+		Login:           universityImmutable.Login,
+		Inn:             universityImmutable.INN,
+		Email:           universityImmutable.Email,
+		EmailVerified:   universityImmutable.EmailVerified,
+		Confirmed:       universityImmutable.Confirmed,
+		PasswordHash:    universityImmutable.PasswordHash,
+		RejectionReason: universityImmutable.RejectionReason,
+		CreatedAt:       universityImmutable.CreatedAt,
+		UpdatedAt:       universityImmutable.UpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("error updating user: %w", err)
@@ -1,117 +1,287 @@
 package postgres
 
 import (
-    "context"
-    "database/sql"
-    "errors"
-    "fmt"
-
-    "github.com/google/uuid"
-    "github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
-    "github.com/hr-platform-mosprom/internal/core/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type vacancyRepo struct {
-    q *pgqueries.Queries
+	q *pgqueries.Queries
 }
 
 func NewVacancyRepo(q *pgqueries.Queries) *vacancyRepo {
-    return &vacancyRepo{q}
+	return &vacancyRepo{q}
+}
+
+func (r *vacancyRepo) ByID(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error) {
+	vdb, err := r.q.GetVacancyByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+
+	return reconstructVacancy(vdb)
 }
 
-func (r *vacancyRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error) {
-    vdb, err := r.q.GetVacancyByID(ctx, id)
-    if err != nil {
-        if errors.Is(err, sql.ErrNoRows) {
-            return nil, domain.ErrNotFound
-        }
-        return nil, fmt.Errorf("error getting vacancy by id: %w", err)
-    }
-
-    v, err := domain.ReconstructVacancy(domain.VacancyImmutable{
-        ID:              vdb.ID,
-        CompanyID:       vdb.CompanyID,
-        Title:           vdb.Title,
-        Description:     vdb.Description,
-        Contacts:        vdb.Contacts,
-        Requirements:    vdb.Requirements,
-        Responsibilities: vdb.Responsibilities,
-        Conditions:      vdb.Conditions,
-        Employment:      vdb.Employment,
-        Schedule:        vdb.Schedule,
-        Experience:      vdb.Experience,
-        Education:       vdb.Education,
-        Location:        vdb.Location,
-        IsActive:        vdb.IsActive,
-        CreatedAt:       vdb.CreatedAt,
-        UpdatedAt:       vdb.UpdatedAt,
-    })
-    if err != nil {
-        return nil, fmt.Errorf("error reconstructing vacancy: %w", err)
-    }
-
-    return v, nil
+func (r *vacancyRepo) ByCompany(ctx context.Context, companyID uuid.UUID, page domain.PageRequest) ([]*domain.Vacancy, int, error) {
+	return r.Search(ctx, domain.VacancyFilter{
+		CompanyID: &companyID,
+		Page:      page,
+	})
+}
+
+func (r *vacancyRepo) All(ctx context.Context, page domain.PageRequest) ([]*domain.Vacancy, int, error) {
+	return r.Search(ctx, domain.VacancyFilter{
+		Page: page,
+	})
+}
+
+func (r *vacancyRepo) Search(ctx context.Context, f domain.VacancyFilter) ([]*domain.Vacancy, int, error) {
+	rows, err := r.q.SearchVacancies(ctx, pgqueries.SearchVacanciesParams{
+		CompanyID:       optionalUUID(f.CompanyID),
+		Location:        optionalText(f.Location),
+		Employment:      optionalText(f.Employment),
+		Schedule:        optionalText(f.Schedule),
+		Experience:      optionalText(f.Experience),
+		Education:       optionalText(f.Education),
+		State:           optionalVacancyState(f.State),
+		Salary:          optionalInt4(f.Salary),
+		SalaryCurrency:  optionalText(f.SalaryCurrency),
+		CompanyApproved: optionalBool(f.CompanyApproved),
+		Sort:            f.Page.Sort.String(),
+		Limit:           int32(f.Page.Limit()),
+		Offset:          int32(f.Page.Offset()),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error searching vacancies: %w", err)
+	}
+
+	total, err := r.q.CountVacancies(ctx, pgqueries.CountVacanciesParams{
+		CompanyID:       optionalUUID(f.CompanyID),
+		Location:        optionalText(f.Location),
+		Employment:      optionalText(f.Employment),
+		Schedule:        optionalText(f.Schedule),
+		Experience:      optionalText(f.Experience),
+		Education:       optionalText(f.Education),
+		State:           optionalVacancyState(f.State),
+		Salary:          optionalInt4(f.Salary),
+		SalaryCurrency:  optionalText(f.SalaryCurrency),
+		CompanyApproved: optionalBool(f.CompanyApproved),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting vacancies: %w", err)
+	}
+
+	vacancies := make([]*domain.Vacancy, 0, len(rows))
+	for _, row := range rows {
+		v, err := reconstructVacancy(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		vacancies = append(vacancies, v)
+	}
+
+	return vacancies, int(total), nil
+}
+
+func (r *vacancyRepo) SearchByCursor(ctx context.Context, f domain.VacancyFilter, after *domain.Cursor, limit int) ([]*domain.Vacancy, *domain.Cursor, error) {
+	params := pgqueries.SearchVacanciesByCursorParams{
+		CompanyID:       optionalUUID(f.CompanyID),
+		Location:        optionalText(f.Location),
+		Employment:      optionalText(f.Employment),
+		Schedule:        optionalText(f.Schedule),
+		Experience:      optionalText(f.Experience),
+		Education:       optionalText(f.Education),
+		State:           optionalVacancyState(f.State),
+		Salary:          optionalInt4(f.Salary),
+		SalaryCurrency:  optionalText(f.SalaryCurrency),
+		CompanyApproved: optionalBool(f.CompanyApproved),
+		// Одна лишняя строка показывает, есть ли следующая страница
+		Limit: int32(limit + 1),
+	}
+	if after != nil {
+		params.AfterCreatedAt = pgtype.Timestamptz{Time: after.CreatedAt, Valid: true}
+		params.AfterID = pgtype.UUID{Bytes: after.ID, Valid: true}
+	}
+
+	rows, err := r.q.SearchVacanciesByCursor(ctx, params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error searching vacancies by cursor: %w", err)
+	}
+
+	var next *domain.Cursor
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		next = &domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	vacancies := make([]*domain.Vacancy, 0, len(rows))
+	for _, row := range rows {
+		v, err := reconstructVacancy(row)
+		if err != nil {
+			return nil, nil, err
+		}
+		vacancies = append(vacancies, v)
+	}
+
+	return vacancies, next, nil
+}
+
+func (r *vacancyRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	affected, err := r.q.DeleteVacancy(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error deleting vacancy: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
 }
 
 func (r *vacancyRepo) Save(ctx context.Context, v *domain.Vacancy) error {
-    _, err := r.GetByID(ctx, v.Immutable().ID)
-    if err != nil {
-        if errors.Is(err, domain.ErrNotFound) {
-            return r.create(ctx, v)
-        }
-        return fmt.Errorf("error getting vacancy by id: %w", err)
-    }
-    return r.update(ctx, v)
+	_, err := r.ByID(ctx, v.Immutable().ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return r.create(ctx, v)
+		}
+		return fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+	return r.update(ctx, v)
 }
 
 func (r *vacancyRepo) create(ctx context.Context, v *domain.Vacancy) error {
-    im := v.Immutable()
-    err := r.q.CreateVacancy(ctx, pgqueries.CreateVacancyParams{
-        ID:              im.ID,
-        CompanyID:       im.CompanyID,
-        Title:           im.Title,
-        Description:     im.Description,
-        Contacts:        im.Contacts,
-        Requirements:    im.Requirements,
-        Responsibilities: im.Responsibilities,
-        Conditions:      im.Conditions,
-        Employment:      im.Employment,
-        Schedule:        im.Schedule,
-        Experience:      im.Experience,
-        Education:       im.Education,
-        Location:        im.Location,
-        IsActive:        im.IsActive,
-        CreatedAt:       im.CreatedAt,
-        UpdatedAt:       im.UpdatedAt,
-    })
-    if err != nil {
-        return fmt.Errorf("error creating vacancy: %w", err)
-    }
-    return nil
+	im := v.Immutable()
+	salaryMin, salaryMax, salaryCurrency, salaryGross := salaryParams(im.Salary)
+	err := r.q.CreateVacancy(ctx, pgqueries.CreateVacancyParams{
+		ID:                im.ID,
+		CompanyID:         im.CompanyID,
+		Title:             im.Title,
+		Description:       im.Description,
+		Contacts:          im.Contacts,
+		Requirements:      im.Requirements,
+		Responsibilities:  im.Responsibilities,
+		Conditions:        im.Conditions,
+		Employment:        im.Employment,
+		Schedule:          im.Schedule,
+		Experience:        im.Experience,
+		Education:         im.Education,
+		Location:          im.Location,
+		CreatedAt:         im.CreatedAt,
+		UpdatedAt:         im.UpdatedAt,
+		SalaryMin:         salaryMin,
+		SalaryMax:         salaryMax,
+		SalaryCurrency:    salaryCurrency,
+		SalaryGross:       salaryGross,
+		State:             string(im.State),
+		ModerationComment: im.ModerationComment,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating vacancy: %w", err)
+	}
+	return nil
 }
 
 func (r *vacancyRepo) update(ctx context.Context, v *domain.Vacancy) error {
-    im := v.Immutable()
-    err := r.q.UpdateVacancy(ctx, pgqueries.UpdateVacancyParams{
-        ID:              im.ID,
-        CompanyID:       im.CompanyID,
-        Title:           im.Title,
-        Description:     im.Description,
-        Contacts:        im.Contacts,
-        Requirements:    im.Requirements,
-        Responsibilities: im.Responsibilities,
-        Conditions:      im.Conditions,
-        Employment:      im.Employment,
-        Schedule:        im.Schedule,
-        Experience:      im.Experience,
-        Education:       im.Education,
-        Location:        im.Location,
-        IsActive:        im.IsActive,
-        CreatedAt:       im.CreatedAt,
-        UpdatedAt:       im.UpdatedAt,
-    })
-    if err != nil {
-        return fmt.Errorf("error updating vacancy: %w", err)
-    }
-    return nil
+	im := v.Immutable()
+	salaryMin, salaryMax, salaryCurrency, salaryGross := salaryParams(im.Salary)
+	err := r.q.UpdateVacancy(ctx, pgqueries.UpdateVacancyParams{
+		ID:                im.ID,
+		CompanyID:         im.CompanyID,
+		Title:             im.Title,
+		Description:       im.Description,
+		Contacts:          im.Contacts,
+		Requirements:      im.Requirements,
+		Responsibilities:  im.Responsibilities,
+		Conditions:        im.Conditions,
+		Employment:        im.Employment,
+		Schedule:          im.Schedule,
+		Experience:        im.Experience,
+		Education:         im.Education,
+		Location:          im.Location,
+		CreatedAt:         im.CreatedAt,
+		UpdatedAt:         im.UpdatedAt,
+		SalaryMin:         salaryMin,
+		SalaryMax:         salaryMax,
+		SalaryCurrency:    salaryCurrency,
+		SalaryGross:       salaryGross,
+		State:             string(im.State),
+		ModerationComment: im.ModerationComment,
+	})
+	if err != nil {
+		return fmt.Errorf("error updating vacancy: %w", err)
+	}
+	return nil
+}
+
+func reconstructVacancy(vdb pgqueries.Vacancy) (*domain.Vacancy, error) {
+	state, err := domain.ParseVacancyState(vdb.State)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing vacancy: %w", err)
+	}
+
+	v, err := domain.ReconstructVacancy(domain.VacancyImmutable{
+		ID:                vdb.ID,
+		CompanyID:         vdb.CompanyID,
+		Title:             vdb.Title,
+		Description:       vdb.Description,
+		Contacts:          vdb.Contacts,
+		Requirements:      vdb.Requirements,
+		Responsibilities:  vdb.Responsibilities,
+		Conditions:        vdb.Conditions,
+		Salary:            reconstructSalary(vdb),
+		Employment:        vdb.Employment,
+		Schedule:          vdb.Schedule,
+		Experience:        vdb.Experience,
+		Education:         vdb.Education,
+		Location:          vdb.Location,
+		State:             state,
+		ModerationComment: vdb.ModerationComment,
+		CreatedAt:         vdb.CreatedAt,
+		UpdatedAt:         vdb.UpdatedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing vacancy: %w", err)
+	}
+
+	return v, nil
+}
+
+func optionalVacancyState(s *domain.VacancyState) pgtype.Text {
+	if s == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: string(*s), Valid: true}
+}
+
+// salaryParams раскладывает вилку по nullable-колонкам vacancies.
+func salaryParams(s *domain.Salary) (pgtype.Int4, pgtype.Int4, pgtype.Text, pgtype.Bool) {
+	if s == nil {
+		return pgtype.Int4{}, pgtype.Int4{}, pgtype.Text{}, pgtype.Bool{}
+	}
+	return optionalInt4(s.Min),
+		optionalInt4(s.Max),
+		pgtype.Text{String: s.Currency, Valid: true},
+		pgtype.Bool{Bool: s.Gross, Valid: true}
+}
+
+func reconstructSalary(vdb pgqueries.Vacancy) *domain.Salary {
+	if !vdb.SalaryMin.Valid && !vdb.SalaryMax.Valid {
+		return nil
+	}
+	return &domain.Salary{
+		Min:      int4Ptr(vdb.SalaryMin),
+		Max:      int4Ptr(vdb.SalaryMax),
+		Currency: vdb.SalaryCurrency.String,
+		Gross:    vdb.SalaryGross.Bool,
+	}
 }
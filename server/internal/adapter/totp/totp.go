@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Параметры по умолчанию из RFC 6238, которые понимают все распространённые
+// приложения-аутентификаторы: HMAC-SHA1, 6 цифр, шаг 30 секунд.
+const (
+	period       = 30
+	digits       = 6
+	secretLength = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type totp struct {
+	// skew — сколько соседних шагов принимать с каждой стороны, чтобы
+	// пережить расхождение часов телефона и сервера
+	skew int64
+}
+
+func NewTOTP(skew int64) *totp {
+	return &totp{skew}
+}
+
+func (t *totp) GenerateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("error generating totp secret: %w", err)
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI собирает ссылку в формате Key Uri Format:
+// otpauth://totp/<issuer>:<account>?secret=...&issuer=...
+func (t *totp) URI(secret, issuer, account string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func (t *totp) Verify(secret, code string, at time.Time) (int64, bool) {
+	if len(code) != digits {
+		return 0, false
+	}
+
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := at.Unix() / period
+	for step := current - t.skew; step <= current+t.skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(generate(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// generate вычисляет HOTP (RFC 4226) для номера шага.
+func generate(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", digits, value%1_000_000)
+}
@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/hr-platform-mosprom/internal/adapter/jwt"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres"
+	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
+	clock "github.com/hr-platform-mosprom/internal/adapter/time"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/application/service"
+)
+
+// CreateAdmin заводит администратора напрямую в БД, минуя HTTP.
+func CreateAdmin(env environment, login, password string) error {
+	db, err := postgres.New(env.PostgresDSN)
+	if err != nil {
+		return fmt.Errorf("error creating postgres db connection: %w", err)
+	}
+	defer db.Close()
+
+	queries := pgqueries.New(db)
+
+	tokenKeys, err := loadTokenKeys(env)
+	if err != nil {
+		return err
+	}
+
+	utcClock := clock.NewUTCClock()
+	auditLog := service.NewAuditLog(postgres.NewAuditEventRepo(queries), utcClock)
+	adminService := service.NewAdminService(service.AdminServiceDeps{
+		AdminRepo:       postgres.NewAdminRepo(queries),
+		PasswordService: newPasswordService(),
+		PasswordPolicy:  newPasswordPolicy(env),
+		SessionService: service.NewSessionService(service.SessionServiceDeps{
+			SessionRepo:     postgres.NewSessionRepo(db),
+			TokenService:    jwt.NewJWTService(utcClock, env.AccessTokenTTL, tokenKeys),
+			AuditLog:        auditLog,
+			Clock:           utcClock,
+			RefreshTokenTTL: env.RefreshTokenTTL,
+		}),
+		AuditLog: auditLog,
+		Clock:    utcClock,
+	})
+
+	admin, err := adminService.Create(context.Background(), port.CreateAdminData{
+		Login:    login,
+		Password: password,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating admin: %w", err)
+	}
+
+	slog.Info("admin created", "id", admin.ID, "login", admin.Login)
+
+	return nil
+}
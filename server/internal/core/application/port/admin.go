@@ -0,0 +1,46 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type AdminRepository interface {
+	Save(ctx context.Context, admin *domain.Admin) error
+	GetByLogin(ctx context.Context, login string) (*domain.Admin, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Admin, error)
+}
+
+type CreateAdminData struct {
+	Login    string
+	Password string
+}
+
+type SignInAdminData struct {
+	Login    string
+	Password string
+	// Адрес клиента, для ограничения перебора
+	IP string
+}
+
+type AdminResult struct {
+	ID    uuid.UUID
+	Login string
+}
+
+type AdminWithTokenResult struct {
+	AdminResult
+	Token        string
+	RefreshToken string
+	// Если у администратора включена 2FA, вход возвращает только этот токен,
+	// а сессию выдаёт TwoFactorService.Verify
+	TwoFactorToken string
+}
+
+type AdminService interface {
+	// Create заводит администратора в обход HTTP, используется CLI-командой
+	Create(ctx context.Context, data CreateAdminData) (*AdminResult, error)
+	SignIn(ctx context.Context, data SignInAdminData) (*AdminWithTokenResult, error)
+}
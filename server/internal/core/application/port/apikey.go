@@ -0,0 +1,58 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	ListByUniversity(ctx context.Context, universityID uuid.UUID) ([]*domain.APIKey, error)
+	// Revoke возвращает ErrConflict, если ключ уже отозван.
+	Revoke(ctx context.Context, key *domain.APIKey) error
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type APIKeyResult struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	Scopes     []domain.Permission
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// APIKeyWithSecretResult содержит ключ целиком; он показывается только один
+// раз, при выпуске.
+type APIKeyWithSecretResult struct {
+	APIKeyResult
+	Key string
+}
+
+type CreateAPIKeyData struct {
+	Name   string
+	Scopes []domain.Permission
+}
+
+// APIKeyAuthenticator проверяет ключ из запроса и возвращает актора,
+// ограниченного областями ключа.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (domain.Actor, error)
+}
+
+// APIKeyService управляет ключами интеграции вуза. Выпускать и отзывать
+// ключи можно только из интерактивной сессии.
+type APIKeyService interface {
+	APIKeyAuthenticator
+	Create(ctx context.Context, actor domain.Actor, data CreateAPIKeyData) (*APIKeyWithSecretResult, error)
+	List(ctx context.Context, actor domain.Actor) ([]APIKeyResult, error)
+	// Rotate выпускает ключ с теми же именем и областями и отзывает прежний.
+	Rotate(ctx context.Context, actor domain.Actor, keyID uuid.UUID) (*APIKeyWithSecretResult, error)
+	Revoke(ctx context.Context, actor domain.Actor, keyID uuid.UUID) error
+}
@@ -0,0 +1,70 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type AuditEventRepository interface {
+	Append(ctx context.Context, event *domain.AuditEvent) error
+	List(ctx context.Context, f domain.AuditEventFilter) ([]*domain.AuditEvent, int, error)
+}
+
+// AuditEntry — действие для журнала аудита. Before и After — значения полей
+// объекта до и после действия; секреты и хеши в них не передаются.
+type AuditEntry struct {
+	Actor      domain.Actor
+	Action     domain.AuditAction
+	TargetType domain.AuditTargetType
+	TargetID   uuid.UUID
+	Before     map[string]any
+	After      map[string]any
+}
+
+// AuditLog записывает действия сервисов. Адрес и User-Agent берутся из
+// RequestMeta в контексте.
+type AuditLog interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+type AuditEventResult struct {
+	ID         uuid.UUID
+	OccurredAt time.Time
+	ActorID    uuid.UUID
+	ActorRole  string
+	APIKeyID   uuid.UUID
+	Action     domain.AuditAction
+	TargetType domain.AuditTargetType
+	TargetID   uuid.UUID
+	Before     map[string]any
+	After      map[string]any
+	IP         string
+	UserAgent  string
+}
+
+type AuditService interface {
+	List(ctx context.Context, actor domain.Actor, f domain.AuditEventFilter) (Paginated[AuditEventResult], error)
+}
+
+// RequestMeta — сведения о запросе, которые транспорт передаёт в журнал
+// аудита через контекст.
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+}
+
+type requestMetaContextKey struct{}
+
+func ContextWithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaContextKey{}, meta)
+}
+
+// RequestMetaFromContext возвращает пустые сведения для вызовов вне HTTP,
+// например из CLI.
+func RequestMetaFromContext(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaContextKey{}).(RequestMeta)
+	return meta
+}
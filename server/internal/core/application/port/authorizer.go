@@ -0,0 +1,16 @@
+package port
+
+import (
+	"context"
+
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// Authorizer решает, есть ли у актора право на действие. Принадлежность
+// ресурса компании актора проверяют сами сервисы.
+type Authorizer interface {
+	// Authorize возвращает ErrForbidden, если права нет.
+	Authorize(ctx context.Context, actor domain.Actor, permission domain.Permission) error
+	// Can нужен там, где без права действие не запрещается, а меняет результат.
+	Can(ctx context.Context, actor domain.Actor, permission domain.Permission) (bool, error)
+}
@@ -10,40 +10,61 @@ import (
 // Репозиторий компаний
 type CompanyRepository interface {
 	Save(ctx context.Context, company *domain.Company) error
-	GetByLogin(ctx context.Context, login string) (*domain.Company, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Company, error)
 	GetByINN(ctx context.Context, inn string) (*domain.Company, error)
+	List(ctx context.Context, f domain.CompanyFilter) ([]*domain.Company, int, error)
 }
 
 // Входные данные для регистрации компании
 type SignUpCompanyData struct {
-	Login            string
-	Password         string
-	Title            string
-	INN              string
-	Description      string
-	Contacts         string
-	Address          string
-	RepresentativeID uuid.UUID
+	Login       string
+	Password    string
+	Title       string
+	INN         string
+	Description string
+	Contacts    string
+	Address     string
+	LogoURL     string
+	Email       string
+	// Имя владельца, который заводит компанию
+	OwnerName string
 }
 
 // Входные данные для авторизации
 type SignInCompanyData struct {
 	Login    string
 	Password string
+	// Адрес клиента, для ограничения перебора
+	IP string
 }
 
 // Результат для компании (как UniversityResult)
 type CompanyResult struct {
-	Login string
-	Title string
-	INN   string
+	ID          uuid.UUID
+	Title       string
+	INN         string
+	Description string
+	Contacts    string
+	Address     string
+	LogoURL     string
+	Email       string
+	// Пока адрес не подтверждён, компанию нельзя одобрить
+	EmailVerified bool
+	Approved      bool
+	// Причина отказа модератора, пуста для одобренных и ожидающих компаний
+	RejectionReason string
 }
 
 // Результат с токеном (как UniversityWithTokenResult)
 type CompanyWithTokenResult struct {
 	CompanyResult
-	Token string
+	// Сотрудник, которому выдана сессия
+	Member       CompanyMemberResult
+	Token        string
+	RefreshToken string
+	// Если у компании включена 2FA, вход возвращает только этот токен,
+	// а сессию выдаёт TwoFactorService.Verify
+	TwoFactorToken string
 }
 
 // Данные для обновления профиля компании
@@ -52,10 +73,25 @@ type UpdateCompanyProfileData struct {
 	Description string
 	Contacts    string
 	Address     string
+	LogoURL     string
 }
 
-// Данные для смены учётных данных компании
+// Данные для смены учётных данных сотрудника компании
 type ChangeCompanyCredentialsData struct {
 	Login    string
 	Password string
 }
+
+type CompanyService interface {
+	SignUp(ctx context.Context, data SignUpCompanyData) (*CompanyWithTokenResult, error)
+	SignIn(ctx context.Context, data SignInCompanyData) (*CompanyWithTokenResult, error)
+	GetProfile(ctx context.Context, actor domain.Actor) (*CompanyResult, error)
+	UpdateProfile(ctx context.Context, actor domain.Actor, data UpdateCompanyProfileData) (*CompanyResult, error)
+	ChangeCredentials(ctx context.Context, actor domain.Actor, data ChangeCompanyCredentialsData) error
+
+	// Модерация, только для администратора
+	List(ctx context.Context, actor domain.Actor, f domain.CompanyFilter) (Paginated[CompanyResult], error)
+	Approve(ctx context.Context, actor domain.Actor, companyID uuid.UUID) error
+	Reject(ctx context.Context, actor domain.Actor, companyID uuid.UUID, reason string) error
+	RevokeApproval(ctx context.Context, actor domain.Actor, companyID uuid.UUID, reason string) error
+}
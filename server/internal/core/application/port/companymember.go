@@ -0,0 +1,89 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// Репозиторий сотрудников компании
+type CompanyMemberRepository interface {
+	// CreateWithCompany атомарно заводит компанию вместе с её первым владельцем.
+	CreateWithCompany(ctx context.Context, company *domain.Company, owner *domain.CompanyMember) error
+	// Save и Delete атомарно проверяют, что у компании остался владелец,
+	// и возвращают ErrConflict, если изменение лишило бы её последнего.
+	Save(ctx context.Context, member *domain.CompanyMember) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CompanyMember, error)
+	GetByLogin(ctx context.Context, login string) (*domain.CompanyMember, error)
+	ListByCompany(ctx context.Context, companyID uuid.UUID) ([]*domain.CompanyMember, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// Репозиторий приглашений в команду компании
+type CompanyInvitationRepository interface {
+	Create(ctx context.Context, invitation *domain.CompanyInvitation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CompanyInvitation, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.CompanyInvitation, error)
+	ListPending(ctx context.Context, companyID uuid.UUID, at time.Time) ([]*domain.CompanyInvitation, error)
+	// Accept атомарно отмечает приглашение принятым и заводит сотрудника.
+	// ErrNotFound, если приглашение уже принято или отозвано.
+	Accept(ctx context.Context, invitation *domain.CompanyInvitation, member *domain.CompanyMember) error
+	// Revoke возвращает ErrConflict, если приглашение уже принято или отозвано.
+	Revoke(ctx context.Context, invitation *domain.CompanyInvitation) error
+}
+
+type CompanyMemberResult struct {
+	ID            uuid.UUID
+	CompanyID     uuid.UUID
+	Role          domain.CompanyMemberRole
+	Name          string
+	Email         string
+	EmailVerified bool
+	Login         string
+	CreatedAt     time.Time
+}
+
+type CompanyMemberWithTokenResult struct {
+	CompanyMemberResult
+	Token        string
+	RefreshToken string
+}
+
+type CompanyInvitationResult struct {
+	ID        uuid.UUID
+	Email     string
+	Role      domain.CompanyMemberRole
+	InvitedBy uuid.UUID
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type InviteCompanyMemberData struct {
+	Email string
+	Role  domain.CompanyMemberRole
+}
+
+// Данные для вступления в команду по ссылке из письма
+type AcceptCompanyInvitationData struct {
+	Token    string
+	Name     string
+	Login    string
+	Password string
+}
+
+// CompanyMemberService управляет составом команды. Менять его может актор
+// с правом company.manage_members; в компании всегда остаётся хотя бы один
+// владелец.
+type CompanyMemberService interface {
+	ListMembers(ctx context.Context, actor domain.Actor) ([]CompanyMemberResult, error)
+	Invite(ctx context.Context, actor domain.Actor, data InviteCompanyMemberData) (*CompanyInvitationResult, error)
+	ListInvitations(ctx context.Context, actor domain.Actor) ([]CompanyInvitationResult, error)
+	RevokeInvitation(ctx context.Context, actor domain.Actor, invitationID uuid.UUID) error
+	// AcceptInvitation заводит сотрудника и сразу открывает ему сессию.
+	AcceptInvitation(ctx context.Context, data AcceptCompanyInvitationData) (*CompanyMemberWithTokenResult, error)
+	ChangeRole(ctx context.Context, actor domain.Actor, memberID uuid.UUID, role domain.CompanyMemberRole) (*CompanyMemberResult, error)
+	// RemoveMember удаляет сотрудника и завершает его сессии.
+	RemoveMember(ctx context.Context, actor domain.Actor, memberID uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type EmailVerificationService interface {
+	// Request отправляет ссылку подтверждения. Непустой email сначала
+	// заменяет адрес аккаунта, пустой — повторяет отправку на текущий.
+	Request(ctx context.Context, actor domain.Actor, email string) error
+	Confirm(ctx context.Context, token string) error
+}
@@ -0,0 +1,38 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttempts — счётчик неудачных входов по одному ключу (логину или IP).
+type LoginAttempts struct {
+	Failures      int
+	LastFailureAt time.Time
+}
+
+type LoginAttemptStore interface {
+	// Get возвращает нулевое значение, если неудач по ключу не было.
+	Get(ctx context.Context, key string) (LoginAttempts, error)
+	// RecordFailure атомарно увеличивает счётчик; если последняя неудача
+	// была раньше windowStart, счёт начинается заново.
+	RecordFailure(ctx context.Context, key string, at, windowStart time.Time) (LoginAttempts, error)
+	// Release атомарно уменьшает счётчик на единицу, не опуская его ниже нуля.
+	Release(ctx context.Context, key string) error
+	Reset(ctx context.Context, key string) error
+}
+
+type LoginAttempt struct {
+	Role  Role
+	Login string
+	IP    string
+}
+
+type LoginThrottler interface {
+	// Reserve засчитывает попытку как неудачную до проверки пароля или кода,
+	// чтобы параллельные запросы не проверялись в обход счётчика. Возвращает
+	// *domain.ThrottleError, если вход по логину или с IP временно заблокирован.
+	Reserve(ctx context.Context, attempt LoginAttempt) error
+	// Succeed снимает резерв успешной попытки.
+	Succeed(ctx context.Context, attempt LoginAttempt) error
+}
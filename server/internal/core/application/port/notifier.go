@@ -0,0 +1,14 @@
+package port
+
+import "context"
+
+type Message struct {
+	// Адрес получателя в терминах канала доставки
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
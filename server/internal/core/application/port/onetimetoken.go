@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type OneTimeTokenRepository interface {
+	Create(ctx context.Context, token *domain.OneTimeToken) error
+	// Consume атомарно помечает токен использованным и возвращает его.
+	// ErrNotFound, если токена нет, он уже использован или истёк.
+	Consume(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.OneTimeToken, error)
+	// GetActive возвращает действующий токен, не помечая его использованным.
+	// ErrNotFound в тех же случаях, что и Consume.
+	GetActive(ctx context.Context, purpose domain.TokenPurpose, tokenHash string, at time.Time) (*domain.OneTimeToken, error)
+	// Invalidate гасит все неиспользованные токены субъекта с данным назначением.
+	Invalidate(ctx context.Context, purpose domain.TokenPurpose, subjectID uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,8 @@
+package port
+
+type PasswordPolicy interface {
+	// Validate проверяет пароль; account — логин, название и прочие данные
+	// аккаунта, на которые пароль не должен быть похож. Нарушения
+	// возвращаются одной *domain.PasswordPolicyError.
+	Validate(password string, account ...string) error
+}
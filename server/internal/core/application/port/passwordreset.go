@@ -0,0 +1,20 @@
+package port
+
+import "context"
+
+type RequestPasswordResetData struct {
+	// Сброс доступен компаниям и вузам
+	Role  Role
+	Login string
+}
+
+type ConfirmPasswordResetData struct {
+	Token       string
+	NewPassword string
+}
+
+type PasswordResetService interface {
+	// Request не сообщает, существует ли аккаунт: ответ одинаков в обоих случаях.
+	Request(ctx context.Context, data RequestPasswordResetData) error
+	Confirm(ctx context.Context, data ConfirmPasswordResetData) error
+}
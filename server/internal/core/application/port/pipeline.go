@@ -0,0 +1,33 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// PipelineRepository хранит версии конвейеров. vacancyID, равный uuid.Nil,
+// обозначает конвейер компании по умолчанию.
+type PipelineRepository interface {
+	// Save сохраняет конвейер новой версией; прежняя версия для той же
+	// компании и вакансии перестаёт действовать, но остаётся доступна по ID.
+	Save(ctx context.Context, p *domain.Pipeline) error
+	// Current возвращает ErrNotFound, если действующего конвейера нет.
+	Current(ctx context.Context, companyID, vacancyID uuid.UUID) (*domain.Pipeline, error)
+	ByID(ctx context.Context, id uuid.UUID) (*domain.Pipeline, error)
+	// Clear снимает действующий конвейер; ErrNotFound, если его нет.
+	Clear(ctx context.Context, companyID, vacancyID uuid.UUID, at time.Time) error
+}
+
+// PipelineService настраивает конвейеры компании актора. vacancyID, равный
+// uuid.Nil, обозначает конвейер компании по умолчанию.
+type PipelineService interface {
+	// Get возвращает действующий конвейер: вакансии, компании или встроенный.
+	Get(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID) (*domain.Pipeline, error)
+	Set(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID, stages []domain.PipelineStage) (*domain.Pipeline, error)
+	// Reset возвращает вакансию к конвейеру компании, а компанию — ко
+	// встроенному.
+	Reset(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID) error
+}
@@ -7,21 +7,38 @@ import (
 	"github.com/hr-platform-mosprom/internal/core/domain"
 )
 
+// Списочные методы вместе со страницей возвращают общее число записей,
+// подходящих под фильтр без учёта пагинации.
 type ResponseRepository interface {
 	Save(ctx context.Context, r *domain.Response) error
+	// SaveWithStatusChange сохраняет отклик вместе с записью истории этапов
+	// в одной транзакции; ErrConflict, если отклик уже ушёл с этапа change.From
+	SaveWithStatusChange(ctx context.Context, r *domain.Response, change *domain.ResponseStatusChange) error
+	// StatusHistory возвращает историю этапов от старых записей к новым
+	StatusHistory(ctx context.Context, responseID uuid.UUID) ([]*domain.ResponseStatusChange, error)
 	ByID(ctx context.Context, id uuid.UUID) (*domain.Response, error)
-	ByVacancy(ctx context.Context, vacancyID uuid.UUID, limit, offset int) ([]*domain.Response, error)
-	Search(ctx context.Context, f domain.ResponseFilter) ([]*domain.Response, error)
+	ByVacancy(ctx context.Context, vacancyID uuid.UUID, page domain.PageRequest) ([]*domain.Response, int, error)
+	Search(ctx context.Context, f domain.ResponseFilter) ([]*domain.Response, int, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type ResponseService interface {
+	// Отклик кандидата, публичный сценарий
 	Create(ctx context.Context, in CreateResponseInput) (*domain.Response, error)
-	Get(ctx context.Context, id uuid.UUID) (*domain.Response, error)
-	ListByVacancy(ctx context.Context, vacancyID uuid.UUID, limit, offset int) ([]*domain.Response, error)
-	Search(ctx context.Context, f domain.ResponseFilter) ([]*domain.Response, error)
-	SetStatus(ctx context.Context, id uuid.UUID, status string) error
-	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Кабинет компании: доступ только к откликам на собственные вакансии
+	Get(ctx context.Context, actor domain.Actor, id uuid.UUID) (*ResponseWithHistory, error)
+	ListByVacancy(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID, page domain.PageRequest) (Paginated[*domain.Response], error)
+	// MoveToStage переводит отклик на этап stage конвейера, по которому он подан
+	MoveToStage(ctx context.Context, actor domain.Actor, id uuid.UUID, stage string) (*domain.Response, error)
+	// SetStatus — прежний способ смены этапа: отклик переводится на ближайший
+	// допустимый этап конвейера с типом status
+	SetStatus(ctx context.Context, actor domain.Actor, id uuid.UUID, status domain.ResponseStatus) (*domain.Response, error)
+}
+
+type ResponseWithHistory struct {
+	Response *domain.Response
+	History  []*domain.ResponseStatusChange
 }
 
 type CreateResponseInput struct {
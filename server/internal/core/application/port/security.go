@@ -1,10 +1,24 @@
 package port
 
-import "github.com/google/uuid"
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
 
 type PasswordService interface {
 	Hash(string) (string, error)
 	Check(raw, hash string) bool
+	// NeedsRehash сообщает, что хеш записан устаревшим алгоритмом или
+	// с устаревшими параметрами и его стоит пересчитать при входе.
+	NeedsRehash(hash string) bool
+}
+
+// PasswordAlgorithm — один из алгоритмов хеширования, различаемых по
+// префиксу хеша.
+type PasswordAlgorithm interface {
+	PasswordService
+	Owns(hash string) bool
 }
 
 type Role string
@@ -18,9 +32,31 @@ const (
 type TokenPayload struct {
 	Sub  uuid.UUID
 	Role Role
+	// Компания сотрудника для роли компании
+	CompanyID uuid.UUID
+	// Семейство сессий, к которому привязан access-токен
+	SessionID uuid.UUID
 }
 
 type TokenService interface {
 	Generate(TokenPayload) (string, error)
-	Validate(string) (TokenPayload, error)
+	Validate(ctx context.Context, token string) (TokenPayload, error)
+}
+
+// PublicKey — открытый ключ проверки подписи токенов в терминах JWK (RFC 7517).
+// Поля N/E заполняются для RSA, Crv/X — для Ed25519.
+type PublicKey struct {
+	Kty string
+	Kid string
+	Alg string
+	N   string
+	E   string
+	Crv string
+	X   string
+}
+
+// KeyPublisher отдаёт ключи, по которым сторонние сервисы могут
+// проверять наши токены без общего секрета.
+type KeyPublisher interface {
+	PublicKeys() []PublicKey
 }
@@ -0,0 +1,37 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type SessionRepository interface {
+	Create(ctx context.Context, s *domain.Session) error
+	GetByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error)
+	// Rotate атомарно помечает prev использованным и сохраняет next.
+	// Если prev уже был использован или отозван, возвращает domain.ErrConflict.
+	Rotate(ctx context.Context, prev, next *domain.Session, at time.Time) error
+	IsFamilyActive(ctx context.Context, familyID uuid.UUID, at time.Time) (bool, error)
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error
+	RevokeBySubject(ctx context.Context, subjectID uuid.UUID, at time.Time) error
+	RevokeByCompany(ctx context.Context, companyID uuid.UUID, at time.Time) error
+}
+
+type SessionTokens struct {
+	AccessToken  string
+	RefreshToken string
+}
+
+type SessionService interface {
+	// Issue открывает новое семейство сессий после успешного входа
+	Issue(ctx context.Context, actor domain.Actor) (SessionTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (SessionTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+	// RevokeSubject завершает все сессии пользователя, например после отзыва одобрения
+	RevokeSubject(ctx context.Context, subjectID uuid.UUID) error
+	// RevokeCompany завершает сессии всех сотрудников компании
+	RevokeCompany(ctx context.Context, companyID uuid.UUID) error
+}
@@ -1,6 +1,48 @@
 package port
 
+import "github.com/hr-platform-mosprom/internal/core/domain"
+
 type Paginated[T any] struct {
 	Data       []T
+	Page       int
+	Size       int
+	Total      int
 	TotalPages int
 }
+
+func NewPaginated[T any](data []T, page domain.PageRequest, total int) Paginated[T] {
+	totalPages := 0
+	if page.Size > 0 {
+		totalPages = (total + page.Size - 1) / page.Size
+	}
+
+	return Paginated[T]{
+		Data:       data,
+		Page:       page.Page,
+		Size:       page.Size,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// CursorPaginated — страница ленты; NextCursor пуст, если страница последняя.
+type CursorPaginated[T any] struct {
+	Data       []T
+	NextCursor *domain.Cursor
+}
+
+// MapPaginated преобразует элементы страницы, сохраняя её метаданные.
+func MapPaginated[T, R any](p Paginated[T], f func(T) R) Paginated[R] {
+	data := make([]R, 0, len(p.Data))
+	for _, item := range p.Data {
+		data = append(data, f(item))
+	}
+
+	return Paginated[R]{
+		Data:       data,
+		Page:       p.Page,
+		Size:       p.Size,
+		Total:      p.Total,
+		TotalPages: p.TotalPages,
+	}
+}
@@ -0,0 +1,78 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type TwoFactorRepository interface {
+	// Get возвращает ErrNotFound, если 2FA для субъекта не настраивалась.
+	Get(ctx context.Context, subjectID uuid.UUID) (*domain.TwoFactor, error)
+	Save(ctx context.Context, twoFactor *domain.TwoFactor) error
+	// UseStep атомарно сдвигает последний принятый шаг кода; ErrNotFound,
+	// если шаг step или более поздний уже принят.
+	UseStep(ctx context.Context, subjectID uuid.UUID, step int64, at time.Time) error
+	// Delete удаляет секрет вместе с резервными кодами.
+	Delete(ctx context.Context, subjectID uuid.UUID) error
+	// ReplaceRecoveryCodes атомарно заменяет все резервные коды субъекта.
+	ReplaceRecoveryCodes(ctx context.Context, subjectID uuid.UUID, codeHashes []string, at time.Time) error
+	// UseRecoveryCode гасит код; ErrNotFound, если его нет или он уже использован.
+	UseRecoveryCode(ctx context.Context, subjectID uuid.UUID, codeHash string, at time.Time) error
+	CountRecoveryCodes(ctx context.Context, subjectID uuid.UUID) (int, error)
+}
+
+type TwoFactorRequirementRepository interface {
+	// IsRequired возвращает false, если для роли настройка не задавалась.
+	IsRequired(ctx context.Context, role Role) (bool, error)
+	SetRequired(ctx context.Context, role Role, required bool, by uuid.UUID, at time.Time) error
+}
+
+// TOTP генерирует и проверяет одноразовые коды по RFC 6238.
+type TOTP interface {
+	GenerateSecret() (string, error)
+	// URI строит otpauth://-ссылку, которую приложение-аутентификатор читает из QR-кода.
+	URI(secret, issuer, account string) string
+	// Verify возвращает номер временного шага, которому соответствует код.
+	Verify(secret, code string, at time.Time) (step int64, ok bool)
+}
+
+type TwoFactorEnrollment struct {
+	Secret string
+	URI    string
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool
+	RecoveryCodesLeft int
+}
+
+type VerifyTwoFactorData struct {
+	Token string
+	// Код из приложения или один из резервных кодов
+	Code string
+	IP   string
+}
+
+type TwoFactorService interface {
+	Status(ctx context.Context, actor domain.Actor) (*TwoFactorStatus, error)
+	// Enroll выдаёт новый секрет; 2FA включается только после Confirm.
+	Enroll(ctx context.Context, actor domain.Actor) (*TwoFactorEnrollment, error)
+	// Confirm включает 2FA и возвращает резервные коды — они показываются один раз.
+	Confirm(ctx context.Context, actor domain.Actor, code string) ([]string, error)
+	RegenerateRecoveryCodes(ctx context.Context, actor domain.Actor, code string) ([]string, error)
+	Disable(ctx context.Context, actor domain.Actor, code string) error
+	// Challenge вызывается после проверки пароля: если у аккаунта включена 2FA,
+	// возвращает промежуточный токен для Verify, иначе пустую строку.
+	Challenge(ctx context.Context, actor domain.Actor) (string, error)
+	// Verify обменивает промежуточный токен и код на сессию.
+	Verify(ctx context.Context, data VerifyTwoFactorData) (SessionTokens, error)
+	// Satisfied сообщает, выполнено ли требование 2FA для роли актора.
+	Satisfied(ctx context.Context, actor domain.Actor) (bool, error)
+	AdminRequired(ctx context.Context, actor domain.Actor) (bool, error)
+	// SetAdminRequired включает обязательную 2FA для администраторов;
+	// включить её может только администратор, уже настроивший 2FA.
+	SetAdminRequired(ctx context.Context, actor domain.Actor, required bool) error
+}
@@ -11,6 +11,7 @@ type UniversityRepository interface {
 	Save(ctx context.Context, university *domain.University) error
 	GetByLogin(ctx context.Context, login string) (*domain.University, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.University, error)
+	List(ctx context.Context, f domain.UniversityFilter) ([]*domain.University, int, error)
 }
 
 type SignUpUniversityData struct {
@@ -18,22 +19,33 @@ type SignUpUniversityData struct {
 	Password string
 	Title    string
 	INN      string
+	Email    string
 }
 
 type SignInUniversityData struct {
 	Login    string
 	Password string
+	// Адрес клиента, для ограничения перебора
+	IP string
 }
 
 type UniversityResult struct {
+	ID    uuid.UUID
 	Login string
 	Title string
 	INN   string
+	Email string
+	// Пока адрес не подтверждён, вуз нельзя подтвердить
+	EmailVerified bool
+	Confirmed     bool
+	// Причина отказа модератора, пуста для подтверждённых и ожидающих вузов
+	RejectionReason string
 }
 
 type UniversityWithTokenResult struct {
 	UniversityResult
-	Token string
+	Token        string
+	RefreshToken string
 }
 
 type ChangeUniversityPasswordData struct {
@@ -44,5 +56,12 @@ type ChangeUniversityPasswordData struct {
 type UniversityService interface {
 	SignUp(ctx context.Context, data SignUpUniversityData) (*UniversityWithTokenResult, error)
 	SignIn(ctx context.Context, data SignInUniversityData) (*UniversityWithTokenResult, error)
+	GetProfile(ctx context.Context, actor domain.Actor) (*UniversityResult, error)
 	ChangePassword(ctx context.Context, actor domain.Actor, data ChangeUniversityPasswordData) error
+
+	// Модерация, только для администратора
+	List(ctx context.Context, actor domain.Actor, f domain.UniversityFilter) (Paginated[UniversityResult], error)
+	Confirm(ctx context.Context, actor domain.Actor, universityID uuid.UUID) error
+	Reject(ctx context.Context, actor domain.Actor, universityID uuid.UUID, reason string) error
+	RevokeConfirmation(ctx context.Context, actor domain.Actor, universityID uuid.UUID, reason string) error
 }
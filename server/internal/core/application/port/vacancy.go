@@ -7,36 +7,51 @@ import (
 	"github.com/hr-platform-mosprom/internal/core/domain"
 )
 
+// Списочные методы вместе со страницей возвращают общее число записей,
+// подходящих под фильтр без учёта пагинации.
 type VacancyRepository interface {
 	Save(ctx context.Context, v *domain.Vacancy) error
 	ByID(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error)
-	ByCompany(ctx context.Context, companyID uuid.UUID, limit, offset int) ([]*domain.Vacancy, error)
-	All(ctx context.Context, limit, offset int) ([]*domain.Vacancy, error)
-	Search(ctx context.Context, f domain.VacancyFilter) ([]*domain.Vacancy, error)
+	ByCompany(ctx context.Context, companyID uuid.UUID, page domain.PageRequest) ([]*domain.Vacancy, int, error)
+	All(ctx context.Context, page domain.PageRequest) ([]*domain.Vacancy, int, error)
+	Search(ctx context.Context, f domain.VacancyFilter) ([]*domain.Vacancy, int, error)
+	// SearchByCursor игнорирует f.Page: лента всегда упорядочена от новых к старым
+	SearchByCursor(ctx context.Context, f domain.VacancyFilter, after *domain.Cursor, limit int) ([]*domain.Vacancy, *domain.Cursor, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 }
 
 type VacancyService interface {
-	Create(ctx context.Context, in CreateVacancyInput) (*domain.Vacancy, error)
-	Update(ctx context.Context, id uuid.UUID, in UpdateVacancyInput) (*domain.Vacancy, error)
-	Get(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error)
-	ListByCompany(ctx context.Context, companyID uuid.UUID, limit, offset int) ([]*domain.Vacancy, error)
-	ListAll(ctx context.Context, limit, offset int) ([]*domain.Vacancy, error)
-	Search(ctx context.Context, f domain.VacancyFilter) ([]*domain.Vacancy, error)
-	Activate(ctx context.Context, id uuid.UUID) error
-	Deactivate(ctx context.Context, id uuid.UUID) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Кабинет компании: доступ только к собственным вакансиям. Новая
+	// вакансия — черновик, в каталог она попадает после модерации
+	Create(ctx context.Context, actor domain.Actor, in CreateVacancyInput) (*domain.Vacancy, error)
+	Update(ctx context.Context, actor domain.Actor, id uuid.UUID, in UpdateVacancyInput) (*domain.Vacancy, error)
+	Get(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error)
+	ListByCompany(ctx context.Context, actor domain.Actor, page domain.PageRequest) (Paginated[*domain.Vacancy], error)
+	Submit(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error)
+	Archive(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error)
+	Restore(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error)
+
+	// Модерация: очередь и решения по вакансиям любых компаний
+	ListForModeration(ctx context.Context, actor domain.Actor, f domain.VacancyFilter) (Paginated[*domain.Vacancy], error)
+	Publish(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error)
+	Reject(ctx context.Context, actor domain.Actor, id uuid.UUID, comment string) (*domain.Vacancy, error)
+
+	// Публичный каталог: только опубликованные вакансии одобренных компаний
+	GetPublished(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error)
+	SearchPublished(ctx context.Context, f domain.VacancyFilter) (Paginated[*domain.Vacancy], error)
+	FeedPublished(ctx context.Context, f domain.VacancyFilter, after *domain.Cursor, limit int) (CursorPaginated[*domain.Vacancy], error)
+	// ExportPublished — та же лента для систем интеграции вузов
+	ExportPublished(ctx context.Context, actor domain.Actor, f domain.VacancyFilter, after *domain.Cursor, limit int) (CursorPaginated[*domain.Vacancy], error)
 }
 
 type CreateVacancyInput struct {
-	CompanyID        uuid.UUID
 	Title            string
 	Description      string
 	Contacts         string
 	Requirements     string
 	Responsibilities string
 	Conditions       string
-	Salary           *int
+	Salary           *domain.Salary
 	Employment       string
 	Schedule         string
 	Experience       string
@@ -51,11 +66,10 @@ type UpdateVacancyInput struct {
 	Requirements     string
 	Responsibilities string
 	Conditions       string
-	Salary           *int
+	Salary           *domain.Salary
 	Employment       string
 	Schedule         string
 	Experience       string
 	Education        string
 	Location         string
-	IsActive         *bool
 }
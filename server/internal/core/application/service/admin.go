@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type adminService struct {
+	adminRepo       port.AdminRepository
+	passwordService port.PasswordService
+	passwordPolicy  port.PasswordPolicy
+	sessionService  port.SessionService
+	twoFactor       port.TwoFactorService
+	loginThrottler  port.LoginThrottler
+	auditLog        port.AuditLog
+	clock           port.Clock
+}
+
+type AdminServiceDeps struct {
+	AdminRepo       port.AdminRepository
+	PasswordService port.PasswordService
+	PasswordPolicy  port.PasswordPolicy
+	SessionService  port.SessionService
+	// Нужен только для входа; CLI-команда создания администратора его не задаёт
+	TwoFactor      port.TwoFactorService
+	LoginThrottler port.LoginThrottler
+	AuditLog       port.AuditLog
+	Clock          port.Clock
+}
+
+func NewAdminService(d AdminServiceDeps) *adminService {
+	return &adminService{
+		adminRepo:       d.AdminRepo,
+		passwordService: d.PasswordService,
+		passwordPolicy:  d.PasswordPolicy,
+		sessionService:  d.SessionService,
+		twoFactor:       d.TwoFactor,
+		loginThrottler:  d.LoginThrottler,
+		auditLog:        d.AuditLog,
+		clock:           d.Clock,
+	}
+}
+
+func (s *adminService) Create(ctx context.Context, data port.CreateAdminData) (*port.AdminResult, error) {
+	if err := s.passwordPolicy.Validate(data.Password, data.Login); err != nil {
+		return nil, fmt.Errorf("error validating password: %w", err)
+	}
+
+	passwordHash, err := s.passwordService.Hash(data.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	admin, err := domain.CreateAdmin(domain.CreateAdminAttrs{
+		Login:        data.Login,
+		PasswordHash: passwordHash,
+	}, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error creating admin: %w", err)
+	}
+
+	if err := s.adminRepo.Save(ctx, admin); err != nil {
+		return nil, fmt.Errorf("error saving admin: %w", err)
+	}
+
+	ai := admin.Immutable()
+
+	// Администратор создаётся из CLI, поэтому актор анонимен
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Action:     domain.AuditActionAdminCreate,
+		TargetType: domain.AuditTargetAdmin,
+		TargetID:   ai.ID,
+		After:      map[string]any{"login": ai.Login},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return &port.AdminResult{
+		ID:    ai.ID,
+		Login: ai.Login,
+	}, nil
+}
+
+func (s *adminService) SignIn(ctx context.Context, data port.SignInAdminData) (*port.AdminWithTokenResult, error) {
+	attempt := port.LoginAttempt{Role: port.RoleAdmin, Login: data.Login, IP: data.IP}
+
+	err := s.loginThrottler.Reserve(ctx, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("error reserving login attempt: %w", err)
+	}
+
+	admin, err := s.adminRepo.GetByLogin(ctx, data.Login)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.passwordService.Check(data.Password, dummyPasswordHash)
+			return nil, s.failSignIn(ctx, uuid.Nil, data.Login)
+		}
+		return nil, fmt.Errorf("error getting admin by login: %w", err)
+	}
+
+	ai := admin.Immutable()
+	if !s.passwordService.Check(data.Password, ai.PasswordHash) {
+		return nil, s.failSignIn(ctx, ai.ID, data.Login)
+	}
+
+	err = s.loginThrottler.Succeed(ctx, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("error resetting login attempts: %w", err)
+	}
+
+	if s.passwordService.NeedsRehash(ai.PasswordHash) {
+		err = s.rehashPassword(ctx, admin, data.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	actor := domain.Actor{ID: ai.ID, Role: domain.RoleAdmin}
+
+	challenge, err := s.twoFactor.Challenge(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("error starting two-factor challenge: %w", err)
+	}
+	if challenge != "" {
+		return &port.AdminWithTokenResult{TwoFactorToken: challenge}, nil
+	}
+
+	tokens, err := s.sessionService.Issue(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("error issuing session: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, signInAuditEntry(actor, false))
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return &port.AdminWithTokenResult{
+		AdminResult: port.AdminResult{
+			ID:    ai.ID,
+			Login: ai.Login,
+		},
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	}, nil
+}
+
+// rehashPassword пересчитывает хеш текущим алгоритмом, пока известен пароль.
+func (s *adminService) rehashPassword(ctx context.Context, admin *domain.Admin, password string) error {
+	passwordHash, err := s.passwordService.Hash(password)
+	if err != nil {
+		return fmt.Errorf("error rehashing password: %w", err)
+	}
+
+	admin, err = admin.ChangePasswordHash(passwordHash, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error changing password hash: %w", err)
+	}
+
+	err = s.adminRepo.Save(ctx, admin)
+	if err != nil {
+		return fmt.Errorf("error saving admin: %w", err)
+	}
+
+	return nil
+}
+
+// failSignIn записывает неудачу; adminID пуст, если логин не найден.
+func (s *adminService) failSignIn(ctx context.Context, adminID uuid.UUID, login string) error {
+	err := s.auditLog.Record(ctx, signInFailedAuditEntry(domain.AuditTargetAdmin, adminID, login))
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return domain.ErrUnauthorized
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+const (
+	// Ключ имеет вид hrp_<префикс>_<секрет>: по открытому префиксу ключ
+	// находится в БД и узнаётся в логах и сканерах утечек
+	apiKeyMarker = "hrp_"
+	// Сколько действующих ключей может быть у одного вуза
+	maxActiveAPIKeys = 10
+	// Время последнего использования обновляется не чаще раза в минуту,
+	// чтобы не писать в БД на каждый запрос интеграции
+	apiKeyTouchInterval = time.Minute
+)
+
+type apiKeyService struct {
+	apiKeyRepo     port.APIKeyRepository
+	universityRepo port.UniversityRepository
+	authorizer     port.Authorizer
+	auditLog       port.AuditLog
+	clock          port.Clock
+}
+
+type APIKeyServiceDeps struct {
+	APIKeyRepo     port.APIKeyRepository
+	UniversityRepo port.UniversityRepository
+	Authorizer     port.Authorizer
+	AuditLog       port.AuditLog
+	Clock          port.Clock
+}
+
+func NewAPIKeyService(d APIKeyServiceDeps) *apiKeyService {
+	return &apiKeyService{
+		apiKeyRepo:     d.APIKeyRepo,
+		universityRepo: d.UniversityRepo,
+		authorizer:     d.Authorizer,
+		auditLog:       d.AuditLog,
+		clock:          d.Clock,
+	}
+}
+
+func (s *apiKeyService) Create(ctx context.Context, actor domain.Actor, data port.CreateAPIKeyData) (*port.APIKeyWithSecretResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityManageAPIKeys); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.ListByUniversity(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys: %w", err)
+	}
+
+	active := 0
+	for _, key := range keys {
+		if key.Active() {
+			active++
+		}
+	}
+	if active >= maxActiveAPIKeys {
+		return nil, fmt.Errorf("%w: too many active api keys", domain.ErrConflict)
+	}
+
+	result, err := s.issue(ctx, actor.ID, data.Name, data.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionAPIKeyCreate,
+		TargetType: domain.AuditTargetAPIKey,
+		TargetID:   result.ID,
+		After:      apiKeyAuditFields(result.APIKeyResult),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context, actor domain.Actor) ([]port.APIKeyResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityManageAPIKeys); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.apiKeyRepo.ListByUniversity(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing api keys: %w", err)
+	}
+
+	results := make([]port.APIKeyResult, 0, len(keys))
+	for _, key := range keys {
+		results = append(results, newAPIKeyResult(key.Immutable()))
+	}
+
+	return results, nil
+}
+
+func (s *apiKeyService) Rotate(ctx context.Context, actor domain.Actor, keyID uuid.UUID) (*port.APIKeyWithSecretResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityManageAPIKeys); err != nil {
+		return nil, err
+	}
+
+	key, err := s.ownedKey(ctx, actor, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := key.Revoke(s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error revoking api key: %w", err)
+	}
+
+	ki := key.Immutable()
+	result, err := s.issue(ctx, actor.ID, ki.Name, ki.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Прежний ключ отзывается только после выпуска нового, чтобы интеграция
+	// не осталась без ключа при сбое
+	err = s.apiKeyRepo.Revoke(ctx, revoked)
+	if err != nil {
+		return nil, fmt.Errorf("error revoking api key: %w", err)
+	}
+
+	after := apiKeyAuditFields(newAPIKeyResult(revoked.Immutable()))
+	after["replaced_by"] = result.ID.String()
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionAPIKeyRotate,
+		TargetType: domain.AuditTargetAPIKey,
+		TargetID:   keyID,
+		Before:     apiKeyAuditFields(newAPIKeyResult(ki)),
+		After:      after,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *apiKeyService) Revoke(ctx context.Context, actor domain.Actor, keyID uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityManageAPIKeys); err != nil {
+		return err
+	}
+
+	key, err := s.ownedKey(ctx, actor, keyID)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := key.Revoke(s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking api key: %w", err)
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, revoked); err != nil {
+		return err
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionAPIKeyRevoke,
+		TargetType: domain.AuditTargetAPIKey,
+		TargetID:   keyID,
+		Before:     apiKeyAuditFields(newAPIKeyResult(key.Immutable())),
+		After:      apiKeyAuditFields(newAPIKeyResult(revoked.Immutable())),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (domain.Actor, error) {
+	prefix, ok := apiKeyPrefix(rawKey)
+	if !ok {
+		return domain.Actor{}, fmt.Errorf("malformed api key: %w", domain.ErrUnauthorized)
+	}
+
+	key, err := s.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.Actor{}, fmt.Errorf("unknown api key: %w", domain.ErrUnauthorized)
+		}
+		return domain.Actor{}, fmt.Errorf("error getting api key: %w", err)
+	}
+
+	ki := key.Immutable()
+	if subtle.ConstantTimeCompare([]byte(hashSecretToken(rawKey)), []byte(ki.SecretHash)) != 1 {
+		return domain.Actor{}, fmt.Errorf("api key secret mismatch: %w", domain.ErrUnauthorized)
+	}
+	if !key.Active() {
+		return domain.Actor{}, fmt.Errorf("api key is revoked: %w", domain.ErrUnauthorized)
+	}
+
+	// Ключ не переживает снятия подтверждения с вуза
+	university, err := s.universityRepo.GetByID(ctx, ki.UniversityID)
+	if err != nil {
+		return domain.Actor{}, fmt.Errorf("error getting university by id: %w", err)
+	}
+	if !university.Immutable().Confirmed {
+		return domain.Actor{}, fmt.Errorf("university is not confirmed: %w", domain.ErrUnauthorized)
+	}
+
+	now := s.clock.Now()
+	if ki.LastUsedAt == nil || now.Sub(*ki.LastUsedAt) >= apiKeyTouchInterval {
+		err = s.apiKeyRepo.Touch(ctx, ki.ID, now)
+		if err != nil {
+			return domain.Actor{}, fmt.Errorf("error touching api key: %w", err)
+		}
+	}
+
+	return key.Actor(), nil
+}
+
+func (s *apiKeyService) issue(ctx context.Context, universityID uuid.UUID, name string, scopes []domain.Permission) (*port.APIKeyWithSecretResult, error) {
+	prefix, rawKey, err := newAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("error generating api key: %w", err)
+	}
+
+	key, err := domain.CreateAPIKey(domain.CreateAPIKeyAttrs{
+		UniversityID: universityID,
+		Name:         name,
+		Prefix:       prefix,
+		SecretHash:   hashSecretToken(rawKey),
+		Scopes:       scopes,
+	}, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error creating api key: %w", err)
+	}
+
+	err = s.apiKeyRepo.Create(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("error saving api key: %w", err)
+	}
+
+	return &port.APIKeyWithSecretResult{
+		APIKeyResult: newAPIKeyResult(key.Immutable()),
+		Key:          rawKey,
+	}, nil
+}
+
+// ownedKey возвращает действующий ключ вуза actor; чужой ключ неотличим
+// от несуществующего.
+func (s *apiKeyService) ownedKey(ctx context.Context, actor domain.Actor, keyID uuid.UUID) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting api key by id: %w", err)
+	}
+	if key.Immutable().UniversityID != actor.ID {
+		return nil, fmt.Errorf("api key of another university: %w", domain.ErrNotFound)
+	}
+
+	return key, nil
+}
+
+// newAPIKey возвращает открытый префикс и ключ целиком. Префикс в hex не
+// содержит «_», поэтому однозначно отделяется от секрета.
+func newAPIKey() (string, string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := hex.EncodeToString(b)
+
+	secret, err := newSecretToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	return prefix, apiKeyMarker + prefix + "_" + secret, nil
+}
+
+func apiKeyPrefix(rawKey string) (string, bool) {
+	rest, ok := strings.CutPrefix(rawKey, apiKeyMarker)
+	if !ok {
+		return "", false
+	}
+
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+
+	return prefix, true
+}
+
+// apiKeyAuditFields — поля ключа для журнала; секрет и его хеш не пишутся.
+func apiKeyAuditFields(result port.APIKeyResult) map[string]any {
+	scopes := make([]string, 0, len(result.Scopes))
+	for _, scope := range result.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return map[string]any{
+		"name":    result.Name,
+		"prefix":  result.Prefix,
+		"scopes":  scopes,
+		"revoked": result.RevokedAt != nil,
+	}
+}
+
+func newAPIKeyResult(ki domain.APIKeyImmutable) port.APIKeyResult {
+	return port.APIKeyResult{
+		ID:         ki.ID,
+		Name:       ki.Name,
+		Prefix:     apiKeyMarker + ki.Prefix,
+		Scopes:     ki.Scopes,
+		LastUsedAt: ki.LastUsedAt,
+		CreatedAt:  ki.CreatedAt,
+		RevokedAt:  ki.RevokedAt,
+	}
+}
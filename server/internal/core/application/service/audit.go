@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type auditLog struct {
+	auditRepo port.AuditEventRepository
+	clock     port.Clock
+}
+
+func NewAuditLog(auditRepo port.AuditEventRepository, clock port.Clock) *auditLog {
+	return &auditLog{auditRepo: auditRepo, clock: clock}
+}
+
+// Record пишет событие после того, как действие сохранено. Ошибка записи
+// возвращается вызывающему: действие без следа в журнале не должно
+// выглядеть успешным.
+func (s *auditLog) Record(ctx context.Context, entry port.AuditEntry) error {
+	meta := port.RequestMetaFromContext(ctx)
+
+	event, err := domain.CreateAuditEvent(domain.CreateAuditEventAttrs{
+		Actor:      entry.Actor,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Before:     entry.Before,
+		After:      entry.After,
+		IP:         meta.IP,
+		UserAgent:  meta.UserAgent,
+	}, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error creating audit event: %w", err)
+	}
+
+	err = s.auditRepo.Append(ctx, event)
+	if err != nil {
+		return fmt.Errorf("error appending audit event: %w", err)
+	}
+
+	return nil
+}
+
+type auditService struct {
+	auditRepo  port.AuditEventRepository
+	authorizer port.Authorizer
+}
+
+func NewAuditService(auditRepo port.AuditEventRepository, authorizer port.Authorizer) *auditService {
+	return &auditService{auditRepo: auditRepo, authorizer: authorizer}
+}
+
+func (s *auditService) List(ctx context.Context, actor domain.Actor, f domain.AuditEventFilter) (port.Paginated[port.AuditEventResult], error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionAuditView); err != nil {
+		return port.Paginated[port.AuditEventResult]{}, err
+	}
+
+	events, total, err := s.auditRepo.List(ctx, f)
+	if err != nil {
+		return port.Paginated[port.AuditEventResult]{}, fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	results := make([]port.AuditEventResult, 0, len(events))
+	for _, event := range events {
+		results = append(results, newAuditEventResult(event.Immutable()))
+	}
+
+	return port.NewPaginated(results, f.Page, total), nil
+}
+
+// accountAuditTarget — вид объекта для действий над собственным аккаунтом
+// актора: входа, выхода, смены пароля.
+func accountAuditTarget(actor domain.Actor) domain.AuditTargetType {
+	switch actor.Role {
+	case domain.RoleCompany:
+		return domain.AuditTargetCompanyMember
+	case domain.RoleUniversity:
+		return domain.AuditTargetUniversity
+	default:
+		return domain.AuditTargetAdmin
+	}
+}
+
+// signInAuditEntry — успешный вход; twoFactor — вход завершён вторым фактором.
+func signInAuditEntry(actor domain.Actor, twoFactor bool) port.AuditEntry {
+	return port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionSignIn,
+		TargetType: accountAuditTarget(actor),
+		TargetID:   actor.ID,
+		After:      map[string]any{"two_factor": twoFactor},
+	}
+}
+
+// signInFailedAuditEntry — неудачный вход анонимного актора; targetID пуст,
+// если логин не найден. Логин сохраняется, чтобы перебор был виден и по
+// несуществующим аккаунтам.
+func signInFailedAuditEntry(targetType domain.AuditTargetType, targetID uuid.UUID, login string) port.AuditEntry {
+	return port.AuditEntry{
+		Action:     domain.AuditActionSignInFailed,
+		TargetType: targetType,
+		TargetID:   targetID,
+		After:      map[string]any{"login": login},
+	}
+}
+
+// credentialsAuditFields описывает учётные данные без секретов: сам пароль
+// и его хеш в журнал не попадают, только факт смены.
+func credentialsAuditFields(login string, passwordChanged bool) map[string]any {
+	return map[string]any{
+		"login":            login,
+		"password_changed": passwordChanged,
+	}
+}
+
+func newAuditEventResult(ei domain.AuditEventImmutable) port.AuditEventResult {
+	return port.AuditEventResult{
+		ID:         ei.ID,
+		OccurredAt: ei.OccurredAt,
+		ActorID:    ei.Actor.ID,
+		ActorRole:  string(ei.Actor.Role),
+		APIKeyID:   ei.Actor.APIKeyID,
+		Action:     ei.Action,
+		TargetType: ei.TargetType,
+		TargetID:   ei.TargetID,
+		Before:     ei.Before,
+		After:      ei.After,
+		IP:         ei.IP,
+		UserAgent:  ei.UserAgent,
+	}
+}
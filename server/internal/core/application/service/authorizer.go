@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// RolePermissions — матрица прав: какие права выданы каждой роли.
+type RolePermissions map[domain.AccessRole][]domain.Permission
+
+// DefaultRolePermissions возвращает матрицу прав по умолчанию. Рекрутер
+// ведёт отклики, но не публикует вакансии и не управляет компанией.
+func DefaultRolePermissions() RolePermissions {
+	return RolePermissions{
+		domain.AccessRoleAdmin: {
+			domain.PermissionCompanyList,
+			domain.PermissionCompanyApprove,
+			domain.PermissionUniversityList,
+			domain.PermissionUniversityApprove,
+			domain.PermissionVacancyModerate,
+			domain.PermissionSettingsManage,
+			domain.PermissionAuditView,
+		},
+		domain.AccessRoleUniversity: {
+			domain.PermissionUniversityViewProfile,
+			domain.PermissionUniversityEditProfile,
+			domain.PermissionUniversityManageAPIKeys,
+			domain.PermissionUniversityChangePassword,
+			domain.PermissionCatalogExport,
+		},
+		domain.AccessRoleCompanyOwner: {
+			domain.PermissionCompanyViewProfile,
+			domain.PermissionCompanyEditProfile,
+			domain.PermissionCompanyViewMembers,
+			domain.PermissionCompanyManageMembers,
+			domain.PermissionCompanyChangeCredentials,
+			domain.PermissionVacancyView,
+			domain.PermissionVacancyCreate,
+			domain.PermissionVacancyEdit,
+			domain.PermissionVacancyPublish,
+			domain.PermissionPipelineManage,
+			domain.PermissionResponseView,
+			domain.PermissionResponseViewPII,
+			domain.PermissionResponseChangeStatus,
+		},
+		domain.AccessRoleCompanyHiringManager: {
+			domain.PermissionCompanyViewProfile,
+			domain.PermissionCompanyViewMembers,
+			domain.PermissionCompanyChangeCredentials,
+			domain.PermissionVacancyView,
+			domain.PermissionVacancyCreate,
+			domain.PermissionVacancyEdit,
+			domain.PermissionVacancyPublish,
+			domain.PermissionPipelineManage,
+			domain.PermissionResponseView,
+			domain.PermissionResponseViewPII,
+			domain.PermissionResponseChangeStatus,
+		},
+		domain.AccessRoleCompanyRecruiter: {
+			domain.PermissionCompanyViewProfile,
+			domain.PermissionCompanyViewMembers,
+			domain.PermissionCompanyChangeCredentials,
+			domain.PermissionVacancyView,
+			domain.PermissionResponseView,
+			domain.PermissionResponseViewPII,
+			domain.PermissionResponseChangeStatus,
+		},
+	}
+}
+
+type authorizer struct {
+	memberRepo port.CompanyMemberRepository
+	grants     map[domain.AccessRole]map[domain.Permission]struct{}
+}
+
+// NewAuthorizer проверяет, что в матрице только известные роли и права.
+// Роль, которой нет в матрице, не получает никаких прав.
+func NewAuthorizer(memberRepo port.CompanyMemberRepository, permissions RolePermissions) (*authorizer, error) {
+	grants := make(map[domain.AccessRole]map[domain.Permission]struct{}, len(permissions))
+	for role, rolePermissions := range permissions {
+		if _, err := domain.ParseAccessRole(string(role)); err != nil {
+			return nil, err
+		}
+
+		granted := make(map[domain.Permission]struct{}, len(rolePermissions))
+		for _, permission := range rolePermissions {
+			if _, err := domain.ParsePermission(string(permission)); err != nil {
+				return nil, err
+			}
+			granted[permission] = struct{}{}
+		}
+		grants[role] = granted
+	}
+
+	return &authorizer{memberRepo: memberRepo, grants: grants}, nil
+}
+
+func (a *authorizer) Authorize(ctx context.Context, actor domain.Actor, permission domain.Permission) error {
+	ok, err := a.Can(ctx, actor, permission)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("permission %s required: %w", permission, domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+func (a *authorizer) Can(ctx context.Context, actor domain.Actor, permission domain.Permission) (bool, error) {
+	role, err := a.accessRole(ctx, actor)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := a.grants[role][permission]; !ok {
+		return false, nil
+	}
+
+	// API-ключ сужает права роли до своих областей
+	if actor.ViaAPIKey() && !slices.Contains(actor.Scopes, permission) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// accessRole определяет строку матрицы для актора. Роль сотрудника читается
+// из хранилища, а не из токена, чтобы её смена действовала сразу.
+func (a *authorizer) accessRole(ctx context.Context, actor domain.Actor) (domain.AccessRole, error) {
+	switch actor.Role {
+	case domain.RoleAdmin:
+		return domain.AccessRoleAdmin, nil
+	case domain.RoleUniversity:
+		return domain.AccessRoleUniversity, nil
+	case domain.RoleCompany:
+		member, err := a.memberRepo.GetByID(ctx, actor.ID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return "", fmt.Errorf("company member was removed: %w", domain.ErrForbidden)
+			}
+			return "", fmt.Errorf("error getting company member by id: %w", err)
+		}
+
+		mi := member.Immutable()
+		if mi.CompanyID != actor.CompanyID {
+			return "", fmt.Errorf("company member belongs to another company: %w", domain.ErrForbidden)
+		}
+
+		return domain.CompanyAccessRole(mi.Role), nil
+	default:
+		return "", fmt.Errorf("unknown role %q: %w", actor.Role, domain.ErrForbidden)
+	}
+}
@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type fakeMemberRepo struct {
+	members map[uuid.UUID]*domain.CompanyMember
+}
+
+func (r *fakeMemberRepo) CreateWithCompany(context.Context, *domain.Company, *domain.CompanyMember) error {
+	return errors.New("not implemented")
+}
+
+func (r *fakeMemberRepo) Save(_ context.Context, m *domain.CompanyMember) error {
+	r.members[m.Immutable().ID] = m
+	return nil
+}
+
+func (r *fakeMemberRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.CompanyMember, error) {
+	m, ok := r.members[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return m, nil
+}
+
+func (r *fakeMemberRepo) GetByLogin(context.Context, string) (*domain.CompanyMember, error) {
+	return nil, domain.ErrNotFound
+}
+
+func (r *fakeMemberRepo) ListByCompany(context.Context, uuid.UUID) ([]*domain.CompanyMember, error) {
+	return nil, nil
+}
+
+func (r *fakeMemberRepo) Delete(_ context.Context, id uuid.UUID) error {
+	delete(r.members, id)
+	return nil
+}
+
+// newTestMember заводит сотрудника в репозитории и возвращает его актора.
+func newTestMember(t *testing.T, repo *fakeMemberRepo, companyID uuid.UUID, role domain.CompanyMemberRole) domain.Actor {
+	t.Helper()
+
+	m, err := domain.CreateCompanyMember(domain.CreateCompanyMemberAttrs{
+		CompanyID:    companyID,
+		Role:         role,
+		Login:        "member-" + string(role),
+		PasswordHash: "$argon2id$test-hash",
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("error creating company member: %v", err)
+	}
+	repo.members[m.Immutable().ID] = m
+
+	return m.Actor()
+}
+
+func TestAuthorizerDefaultMatrix(t *testing.T) {
+	repo := &fakeMemberRepo{members: map[uuid.UUID]*domain.CompanyMember{}}
+	companyID := uuid.New()
+
+	a, err := NewAuthorizer(repo, DefaultRolePermissions())
+	if err != nil {
+		t.Fatalf("error creating authorizer: %v", err)
+	}
+
+	matrix := []struct {
+		name    string
+		actor   domain.Actor
+		allowed []domain.Permission
+	}{
+		{
+			name:  "admin",
+			actor: domain.Actor{ID: uuid.New(), Role: domain.RoleAdmin},
+			allowed: []domain.Permission{
+				domain.PermissionCompanyList,
+				domain.PermissionCompanyApprove,
+				domain.PermissionUniversityList,
+				domain.PermissionUniversityApprove,
+				domain.PermissionVacancyModerate,
+				domain.PermissionSettingsManage,
+				domain.PermissionAuditView,
+			},
+		},
+		{
+			name:  "university",
+			actor: domain.Actor{ID: uuid.New(), Role: domain.RoleUniversity},
+			allowed: []domain.Permission{
+				domain.PermissionUniversityViewProfile,
+				domain.PermissionUniversityEditProfile,
+				domain.PermissionUniversityManageAPIKeys,
+				domain.PermissionUniversityChangePassword,
+				domain.PermissionCatalogExport,
+			},
+		},
+		{
+			name: "university api key",
+			actor: domain.Actor{
+				ID:       uuid.New(),
+				Role:     domain.RoleUniversity,
+				APIKeyID: uuid.New(),
+				Scopes:   []domain.Permission{domain.PermissionCatalogExport, domain.PermissionCompanyApprove},
+			},
+			allowed: []domain.Permission{
+				domain.PermissionCatalogExport,
+			},
+		},
+		{
+			name:  "company owner",
+			actor: newTestMember(t, repo, companyID, domain.CompanyMemberOwner),
+			allowed: []domain.Permission{
+				domain.PermissionCompanyViewProfile,
+				domain.PermissionCompanyEditProfile,
+				domain.PermissionCompanyViewMembers,
+				domain.PermissionCompanyManageMembers,
+				domain.PermissionCompanyChangeCredentials,
+				domain.PermissionVacancyView,
+				domain.PermissionVacancyCreate,
+				domain.PermissionVacancyEdit,
+				domain.PermissionVacancyPublish,
+				domain.PermissionPipelineManage,
+				domain.PermissionResponseView,
+				domain.PermissionResponseViewPII,
+				domain.PermissionResponseChangeStatus,
+			},
+		},
+		{
+			name:  "company hiring manager",
+			actor: newTestMember(t, repo, companyID, domain.CompanyMemberHiringManager),
+			allowed: []domain.Permission{
+				domain.PermissionCompanyViewProfile,
+				domain.PermissionCompanyViewMembers,
+				domain.PermissionCompanyChangeCredentials,
+				domain.PermissionVacancyView,
+				domain.PermissionVacancyCreate,
+				domain.PermissionVacancyEdit,
+				domain.PermissionVacancyPublish,
+				domain.PermissionPipelineManage,
+				domain.PermissionResponseView,
+				domain.PermissionResponseViewPII,
+				domain.PermissionResponseChangeStatus,
+			},
+		},
+		{
+			name:  "company recruiter",
+			actor: newTestMember(t, repo, companyID, domain.CompanyMemberRecruiter),
+			allowed: []domain.Permission{
+				domain.PermissionCompanyViewProfile,
+				domain.PermissionCompanyViewMembers,
+				domain.PermissionCompanyChangeCredentials,
+				domain.PermissionVacancyView,
+				domain.PermissionResponseView,
+				domain.PermissionResponseViewPII,
+				domain.PermissionResponseChangeStatus,
+			},
+		},
+	}
+
+	for _, row := range matrix {
+		allowed := make(map[domain.Permission]bool, len(row.allowed))
+		for _, p := range row.allowed {
+			allowed[p] = true
+		}
+
+		for _, p := range domain.Permissions() {
+			t.Run(row.name+"/"+string(p), func(t *testing.T) {
+				err := a.Authorize(context.Background(), row.actor, p)
+				switch {
+				case allowed[p] && err != nil:
+					t.Errorf("expected %s to be allowed, got %v", p, err)
+				case !allowed[p] && !errors.Is(err, domain.ErrForbidden):
+					t.Errorf("expected %s to be forbidden, got %v", p, err)
+				}
+			})
+		}
+	}
+}
+
+func TestAuthorizerCompanyMember(t *testing.T) {
+	companyID := uuid.New()
+
+	tests := []struct {
+		name    string
+		actor   func(repo *fakeMemberRepo) domain.Actor
+		wantErr error
+	}{
+		{
+			name: "current member",
+			actor: func(repo *fakeMemberRepo) domain.Actor {
+				return newTestMember(t, repo, companyID, domain.CompanyMemberOwner)
+			},
+		},
+		{
+			name: "removed member",
+			actor: func(repo *fakeMemberRepo) domain.Actor {
+				actor := newTestMember(t, repo, companyID, domain.CompanyMemberOwner)
+				delete(repo.members, actor.ID)
+				return actor
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "demoted member",
+			actor: func(repo *fakeMemberRepo) domain.Actor {
+				actor := newTestMember(t, repo, companyID, domain.CompanyMemberOwner)
+				m, err := repo.members[actor.ID].ChangeRole(domain.CompanyMemberRecruiter, time.Now())
+				if err != nil {
+					t.Fatalf("error changing role: %v", err)
+				}
+				repo.members[actor.ID] = m
+				return actor
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "token of another company",
+			actor: func(repo *fakeMemberRepo) domain.Actor {
+				actor := newTestMember(t, repo, companyID, domain.CompanyMemberOwner)
+				actor.CompanyID = uuid.New()
+				return actor
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "unknown role",
+			actor: func(*fakeMemberRepo) domain.Actor {
+				return domain.Actor{ID: uuid.New()}
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeMemberRepo{members: map[uuid.UUID]*domain.CompanyMember{}}
+			a, err := NewAuthorizer(repo, DefaultRolePermissions())
+			if err != nil {
+				t.Fatalf("error creating authorizer: %v", err)
+			}
+
+			err = a.Authorize(context.Background(), tt.actor(repo), domain.PermissionCompanyManageMembers)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewAuthorizerConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions RolePermissions
+		wantErr     error
+	}{
+		{
+			name:        "default",
+			permissions: DefaultRolePermissions(),
+		},
+		{
+			name:        "empty",
+			permissions: RolePermissions{},
+		},
+		{
+			name: "unknown role",
+			permissions: RolePermissions{
+				"moderator": {domain.PermissionCompanyApprove},
+			},
+			wantErr: domain.ErrInvariantViolated,
+		},
+		{
+			name: "unknown permission",
+			permissions: RolePermissions{
+				domain.AccessRoleAdmin: {"company.delete"},
+			},
+			wantErr: domain.ErrInvariantViolated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthorizer(&fakeMemberRepo{}, tt.permissions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizerOverride(t *testing.T) {
+	repo := &fakeMemberRepo{members: map[uuid.UUID]*domain.CompanyMember{}}
+	recruiter := newTestMember(t, repo, uuid.New(), domain.CompanyMemberRecruiter)
+
+	permissions := DefaultRolePermissions()
+	permissions[domain.AccessRoleCompanyRecruiter] = []domain.Permission{domain.PermissionResponseView}
+
+	a, err := NewAuthorizer(repo, permissions)
+	if err != nil {
+		t.Fatalf("error creating authorizer: %v", err)
+	}
+
+	tests := []struct {
+		permission domain.Permission
+		want       bool
+	}{
+		{domain.PermissionResponseView, true},
+		{domain.PermissionResponseViewPII, false},
+		{domain.PermissionVacancyView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.permission), func(t *testing.T) {
+			got, err := a.Can(context.Background(), recruiter, tt.permission)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
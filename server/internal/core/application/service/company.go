@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -11,29 +12,47 @@ import (
 
 type companyService struct {
 	companyRepo     port.CompanyRepository
+	memberRepo      port.CompanyMemberRepository
 	passwordService port.PasswordService
-	tokenService    port.TokenService
+	passwordPolicy  port.PasswordPolicy
+	sessionService  port.SessionService
+	loginThrottler  port.LoginThrottler
+	twoFactor       port.TwoFactorService
+	authorizer      port.Authorizer
+	auditLog        port.AuditLog
 	clock           port.Clock
 }
 
 type CompanyServiceDeps struct {
 	CompanyRepo     port.CompanyRepository
+	MemberRepo      port.CompanyMemberRepository
 	PasswordService port.PasswordService
-	TokenService    port.TokenService
+	PasswordPolicy  port.PasswordPolicy
+	SessionService  port.SessionService
+	LoginThrottler  port.LoginThrottler
+	TwoFactor       port.TwoFactorService
+	Authorizer      port.Authorizer
+	AuditLog        port.AuditLog
 	Clock           port.Clock
 }
 
 func NewCompanyService(d CompanyServiceDeps) *companyService {
 	return &companyService{
 		companyRepo:     d.CompanyRepo,
+		memberRepo:      d.MemberRepo,
 		passwordService: d.PasswordService,
-		tokenService:    d.TokenService,
+		passwordPolicy:  d.PasswordPolicy,
+		sessionService:  d.SessionService,
+		loginThrottler:  d.LoginThrottler,
+		twoFactor:       d.TwoFactor,
+		authorizer:      d.Authorizer,
+		auditLog:        d.AuditLog,
 		clock:           d.Clock,
 	}
 }
 
 func (s *companyService) SignUp(ctx context.Context, data port.SignUpCompanyData) (*port.CompanyWithTokenResult, error) {
-	if err := s.validatePassword(data.Password); err != nil {
+	if err := s.passwordPolicy.Validate(data.Password, data.Login, data.Title, data.OwnerName, data.Email); err != nil {
 		return nil, fmt.Errorf("error validating password: %w", err)
 	}
 
@@ -42,78 +61,137 @@ func (s *companyService) SignUp(ctx context.Context, data port.SignUpCompanyData
 		return nil, fmt.Errorf("error hashing password: %w", err)
 	}
 
+	now := s.clock.Now()
+
 	company, err := domain.CreateCompany(domain.CreateCompanyAttrs{
-		Title:            data.Title,
-		Description:      data.Description,
-		Contacts:         data.Contacts,
-		INN:              data.INN,
-		Address:          data.Address,
-		RepresentativeID: data.RepresentativeID,
-		Login:            data.Login,
-		PasswordHash:     passwordHash,
-	}, s.clock.Now())
+		Title:       data.Title,
+		Description: data.Description,
+		Contacts:    data.Contacts,
+		INN:         data.INN,
+		Address:     data.Address,
+		LogoURL:     data.LogoURL,
+		Email:       data.Email,
+	}, now)
 	if err != nil {
 		return nil, fmt.Errorf("error creating company: %w", err)
 	}
 
-	if err := s.companyRepo.Save(ctx, company); err != nil {
+	// Зарегистрировавший компанию становится её владельцем
+	owner, err := domain.CreateCompanyMember(domain.CreateCompanyMemberAttrs{
+		CompanyID:    company.Immutable().ID,
+		Role:         domain.CompanyMemberOwner,
+		Name:         data.OwnerName,
+		Email:        data.Email,
+		Login:        data.Login,
+		PasswordHash: passwordHash,
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("error creating company owner: %w", err)
+	}
+
+	if err := s.memberRepo.CreateWithCompany(ctx, company, owner); err != nil {
 		return nil, fmt.Errorf("error saving company: %w", err)
 	}
 
-	ci := company.Immutable()
-	token, err := s.tokenService.Generate(port.TokenPayload{
-		Sub:  ci.ID,
-		Role: port.RoleCompany,
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      owner.Actor(),
+		Action:     domain.AuditActionSignUp,
+		TargetType: domain.AuditTargetCompany,
+		TargetID:   company.Immutable().ID,
+		After:      companyAuditFields(company.Immutable()),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error generating token: %w", err)
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	tokens, err := s.sessionService.Issue(ctx, owner.Actor())
+	if err != nil {
+		return nil, fmt.Errorf("error issuing session: %w", err)
 	}
 
 	return &port.CompanyWithTokenResult{
-		CompanyResult: port.CompanyResult{
-			Login: ci.Login,
-			Title: ci.Title,
-			INN:   ci.INN,
-		},
-		Token: token,
+		CompanyResult: newCompanyResult(company.Immutable()),
+		Member:        newCompanyMemberResult(owner.Immutable()),
+		Token:         tokens.AccessToken,
+		RefreshToken:  tokens.RefreshToken,
 	}, nil
 }
 
 func (s *companyService) SignIn(ctx context.Context, data port.SignInCompanyData) (*port.CompanyWithTokenResult, error) {
-	company, err := s.companyRepo.GetByLogin(ctx, data.Login)
+	attempt := port.LoginAttempt{Role: port.RoleCompany, Login: data.Login, IP: data.IP}
+
+	err := s.loginThrottler.Reserve(ctx, attempt)
 	if err != nil {
-		return nil, fmt.Errorf("error getting company by login: %w", err)
+		return nil, fmt.Errorf("error reserving login attempt: %w", err)
 	}
 
-	ci := company.Immutable()
-	if !s.passwordService.Check(data.Password, ci.PasswordHash) {
-		return nil, domain.ErrUnauthorized
+	member, err := s.memberRepo.GetByLogin(ctx, data.Login)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.passwordService.Check(data.Password, dummyPasswordHash)
+			return nil, s.failSignIn(ctx, attempt, uuid.Nil)
+		}
+		return nil, fmt.Errorf("error getting company member by login: %w", err)
+	}
+
+	mi := member.Immutable()
+	if !s.passwordService.Check(data.Password, mi.PasswordHash) {
+		return nil, s.failSignIn(ctx, attempt, mi.ID)
+	}
+
+	err = s.loginThrottler.Succeed(ctx, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("error resetting login attempts: %w", err)
+	}
+
+	if s.passwordService.NeedsRehash(mi.PasswordHash) {
+		member, err = s.rehashPassword(ctx, member, data.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, mi.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
 	}
+
+	ci := company.Immutable()
 	if !ci.Approved {
-		return nil, fmt.Errorf("company is not approved")
+		return nil, fmt.Errorf("company is not approved: %w", &domain.ModerationError{Reason: ci.RejectionReason})
 	}
 
-	token, err := s.tokenService.Generate(port.TokenPayload{
-		Sub:  ci.ID,
-		Role: port.RoleCompany,
-	})
+	actor := member.Actor()
+
+	challenge, err := s.twoFactor.Challenge(ctx, actor)
 	if err != nil {
-		return nil, fmt.Errorf("error generating token: %w", err)
+		return nil, fmt.Errorf("error starting two-factor challenge: %w", err)
+	}
+	if challenge != "" {
+		return &port.CompanyWithTokenResult{TwoFactorToken: challenge}, nil
+	}
+
+	tokens, err := s.sessionService.Issue(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("error issuing session: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, signInAuditEntry(actor, false))
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
 	}
 
 	return &port.CompanyWithTokenResult{
-		CompanyResult: port.CompanyResult{
-			Login: ci.Login,
-			Title: ci.Title,
-			INN:   ci.INN,
-		},
-		Token: token,
+		CompanyResult: newCompanyResult(ci),
+		Member:        newCompanyMemberResult(member.Immutable()),
+		Token:         tokens.AccessToken,
+		RefreshToken:  tokens.RefreshToken,
 	}, nil
 }
 
 func (s *companyService) Approve(ctx context.Context, actor domain.Actor, companyID uuid.UUID) error {
-	if actor.Role != domain.RoleAdmin {
-		return fmt.Errorf("admin role required: %w", domain.ErrForbidden)
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyApprove); err != nil {
+		return err
 	}
 
 	company, err := s.companyRepo.GetByID(ctx, companyID)
@@ -126,22 +204,107 @@ func (s *companyService) Approve(ctx context.Context, actor domain.Actor, compan
 		return fmt.Errorf("error approving company: %w", err)
 	}
 
-	return s.companyRepo.Save(ctx, company2)
+	if err := s.companyRepo.Save(ctx, company2); err != nil {
+		return fmt.Errorf("error saving company: %w", err)
+	}
+
+	return s.recordModeration(ctx, actor, domain.AuditActionCompanyApprove, company, company2)
+}
+
+func (s *companyService) Reject(ctx context.Context, actor domain.Actor, companyID uuid.UUID, reason string) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyApprove); err != nil {
+		return err
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("error getting company by id: %w", err)
+	}
+
+	company2, err := company.Reject(reason, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error rejecting company: %w", err)
+	}
+
+	if err := s.companyRepo.Save(ctx, company2); err != nil {
+		return fmt.Errorf("error saving company: %w", err)
+	}
+
+	return s.recordModeration(ctx, actor, domain.AuditActionCompanyReject, company, company2)
+}
+
+func (s *companyService) RevokeApproval(ctx context.Context, actor domain.Actor, companyID uuid.UUID, reason string) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyApprove); err != nil {
+		return err
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("error getting company by id: %w", err)
+	}
+
+	company2, err := company.RevokeApproval(reason, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking company approval: %w", err)
+	}
+
+	if err := s.companyRepo.Save(ctx, company2); err != nil {
+		return fmt.Errorf("error saving company: %w", err)
+	}
+
+	// Без одобрения сотрудники компании не должны сохранять доступ по ранее
+	// выданным токенам
+	if err := s.sessionService.RevokeCompany(ctx, companyID); err != nil {
+		return fmt.Errorf("error revoking company sessions: %w", err)
+	}
+
+	return s.recordModeration(ctx, actor, domain.AuditActionCompanyRevoke, company, company2)
+}
+
+func (s *companyService) List(ctx context.Context, actor domain.Actor, f domain.CompanyFilter) (port.Paginated[port.CompanyResult], error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyList); err != nil {
+		return port.Paginated[port.CompanyResult]{}, err
+	}
+
+	companies, total, err := s.companyRepo.List(ctx, f)
+	if err != nil {
+		return port.Paginated[port.CompanyResult]{}, fmt.Errorf("error listing companies: %w", err)
+	}
+
+	results := make([]port.CompanyResult, 0, len(companies))
+	for _, company := range companies {
+		results = append(results, newCompanyResult(company.Immutable()))
+	}
+
+	return port.NewPaginated(results, f.Page, total), nil
 }
 
+func (s *companyService) GetProfile(ctx context.Context, actor domain.Actor) (*port.CompanyResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyViewProfile); err != nil {
+		return nil, err
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, actor.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
+	}
+
+	result := newCompanyResult(company.Immutable())
+	return &result, nil
+}
 
 func (s *companyService) UpdateProfile(ctx context.Context, actor domain.Actor, data port.UpdateCompanyProfileData) (*port.CompanyResult, error) {
-	if actor.Role != domain.RoleCompany {
-		return nil, fmt.Errorf("company role required: %w", domain.ErrForbidden)
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyEditProfile); err != nil {
+		return nil, err
 	}
 
-	company, err := s.companyRepo.GetByID(ctx, actor.ID)
+	company, err := s.companyRepo.GetByID(ctx, actor.CompanyID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting company by id: %w", err)
 	}
 
 	company2, err := company.UpdateProfile(
-		data.Title, data.Description, data.Contacts, data.Address,
+		data.Title, data.Description, data.Contacts, data.Address, data.LogoURL,
 		s.clock.Now(),
 	)
 	if err != nil {
@@ -152,27 +315,40 @@ func (s *companyService) UpdateProfile(ctx context.Context, actor domain.Actor,
 		return nil, fmt.Errorf("error saving company: %w", err)
 	}
 
-	ci := company2.Immutable()
-	return &port.CompanyResult{
-		Login: ci.Login,
-		Title: ci.Title,
-		INN:   ci.INN,
-	}, nil
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCompanyUpdate,
+		TargetType: domain.AuditTargetCompany,
+		TargetID:   actor.CompanyID,
+		Before:     companyAuditFields(company.Immutable()),
+		After:      companyAuditFields(company2.Immutable()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	result := newCompanyResult(company2.Immutable())
+	return &result, nil
 }
 
 func (s *companyService) ChangeCredentials(ctx context.Context, actor domain.Actor, data port.ChangeCompanyCredentialsData) error {
-	if actor.Role != domain.RoleCompany {
-		return fmt.Errorf("company role required: %w", domain.ErrForbidden)
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyChangeCredentials); err != nil {
+		return err
 	}
 
-	company, err := s.companyRepo.GetByID(ctx, actor.ID)
+	member, err := s.memberRepo.GetByID(ctx, actor.ID)
 	if err != nil {
-		return fmt.Errorf("error getting company by id: %w", err)
+		return fmt.Errorf("error getting company member by id: %w", err)
 	}
 
 	var newHash string
 	if data.Password != "" {
-		if err := s.validatePassword(data.Password); err != nil {
+		mi := member.Immutable()
+		login := data.Login
+		if login == "" {
+			login = mi.Login
+		}
+		if err := s.passwordPolicy.Validate(data.Password, login, mi.Name, mi.Email); err != nil {
 			return fmt.Errorf("error validating password: %w", err)
 		}
 		h, err := s.passwordService.Hash(data.Password)
@@ -182,17 +358,104 @@ func (s *companyService) ChangeCredentials(ctx context.Context, actor domain.Act
 		newHash = h
 	}
 
-	company2, err := company.ChangeCredentials(data.Login, newHash, s.clock.Now())
+	member2, err := member.ChangeCredentials(data.Login, newHash, s.clock.Now())
 	if err != nil {
 		return fmt.Errorf("error changing credentials: %w", err)
 	}
 
-	return s.companyRepo.Save(ctx, company2)
+	if err := s.memberRepo.Save(ctx, member2); err != nil {
+		return fmt.Errorf("error saving company member: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCredentialsChange,
+		TargetType: domain.AuditTargetCompanyMember,
+		TargetID:   actor.ID,
+		Before:     credentialsAuditFields(member.Immutable().Login, false),
+		After:      credentialsAuditFields(member2.Immutable().Login, newHash != ""),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
 }
 
-func (s *companyService) validatePassword(password string) error {
-	if len(password) < 8 || len(password) > 64 {
-		return fmt.Errorf("%w: invalid password length", domain.ErrInvariantViolated)
+// rehashPassword пересчитывает хеш текущим алгоритмом, пока известен пароль.
+func (s *companyService) rehashPassword(ctx context.Context, member *domain.CompanyMember, password string) (*domain.CompanyMember, error) {
+	passwordHash, err := s.passwordService.Hash(password)
+	if err != nil {
+		return nil, fmt.Errorf("error rehashing password: %w", err)
+	}
+
+	member, err = member.ChangeCredentials("", passwordHash, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error changing company member credentials: %w", err)
+	}
+
+	err = s.memberRepo.Save(ctx, member)
+	if err != nil {
+		return nil, fmt.Errorf("error saving company member: %w", err)
 	}
+
+	return member, nil
+}
+
+// recordModeration пишет в журнал переход модерации компании.
+func (s *companyService) recordModeration(ctx context.Context, actor domain.Actor, action domain.AuditAction, before, after *domain.Company) error {
+	err := s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: domain.AuditTargetCompany,
+		TargetID:   before.Immutable().ID,
+		Before:     companyAuditFields(before.Immutable()),
+		After:      companyAuditFields(after.Immutable()),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
 	return nil
 }
+
+// failSignIn записывает неудачу в журнал: счётчик попыток уже увеличен
+// в Reserve. memberID пуст, если логин не найден.
+func (s *companyService) failSignIn(ctx context.Context, attempt port.LoginAttempt, memberID uuid.UUID) error {
+	err := s.auditLog.Record(ctx, signInFailedAuditEntry(domain.AuditTargetCompanyMember, memberID, attempt.Login))
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return domain.ErrUnauthorized
+}
+
+// companyAuditFields — поля компании, изменения которых попадают в журнал.
+func companyAuditFields(ci domain.CompanyImmutable) map[string]any {
+	return map[string]any{
+		"title":            ci.Title,
+		"description":      ci.Description,
+		"contacts":         ci.Contacts,
+		"address":          ci.Address,
+		"logo_url":         ci.LogoURL,
+		"email":            ci.Email,
+		"approved":         ci.Approved,
+		"rejection_reason": ci.RejectionReason,
+	}
+}
+
+func newCompanyResult(ci domain.CompanyImmutable) port.CompanyResult {
+	return port.CompanyResult{
+		ID:              ci.ID,
+		Title:           ci.Title,
+		INN:             ci.INN,
+		Description:     ci.Description,
+		Contacts:        ci.Contacts,
+		Address:         ci.Address,
+		LogoURL:         ci.LogoURL,
+		Email:           ci.Email,
+		EmailVerified:   ci.EmailVerified,
+		Approved:        ci.Approved,
+		RejectionReason: ci.RejectionReason,
+	}
+}
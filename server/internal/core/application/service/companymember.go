@@ -0,0 +1,399 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type companyMemberService struct {
+	memberRepo      port.CompanyMemberRepository
+	invitationRepo  port.CompanyInvitationRepository
+	companyRepo     port.CompanyRepository
+	passwordService port.PasswordService
+	passwordPolicy  port.PasswordPolicy
+	sessionService  port.SessionService
+	notifier        port.Notifier
+	authorizer      port.Authorizer
+	auditLog        port.AuditLog
+	clock           port.Clock
+	inviteTTL       time.Duration
+	inviteURL       string
+}
+
+type CompanyMemberServiceDeps struct {
+	MemberRepo      port.CompanyMemberRepository
+	InvitationRepo  port.CompanyInvitationRepository
+	CompanyRepo     port.CompanyRepository
+	PasswordService port.PasswordService
+	PasswordPolicy  port.PasswordPolicy
+	SessionService  port.SessionService
+	Notifier        port.Notifier
+	Authorizer      port.Authorizer
+	AuditLog        port.AuditLog
+	Clock           port.Clock
+	InviteTTL       time.Duration
+	// Адрес страницы принятия приглашения; токен дописывается в конец
+	InviteURL string
+}
+
+func NewCompanyMemberService(d CompanyMemberServiceDeps) *companyMemberService {
+	return &companyMemberService{
+		memberRepo:      d.MemberRepo,
+		invitationRepo:  d.InvitationRepo,
+		companyRepo:     d.CompanyRepo,
+		passwordService: d.PasswordService,
+		passwordPolicy:  d.PasswordPolicy,
+		sessionService:  d.SessionService,
+		notifier:        d.Notifier,
+		authorizer:      d.Authorizer,
+		auditLog:        d.AuditLog,
+		clock:           d.Clock,
+		inviteTTL:       d.InviteTTL,
+		inviteURL:       d.InviteURL,
+	}
+}
+
+func (s *companyMemberService) ListMembers(ctx context.Context, actor domain.Actor) ([]port.CompanyMemberResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyViewMembers); err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.ListByCompany(ctx, actor.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing company members: %w", err)
+	}
+
+	results := make([]port.CompanyMemberResult, 0, len(members))
+	for _, member := range members {
+		results = append(results, newCompanyMemberResult(member.Immutable()))
+	}
+
+	return results, nil
+}
+
+func (s *companyMemberService) Invite(ctx context.Context, actor domain.Actor, data port.InviteCompanyMemberData) (*port.CompanyInvitationResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyManageMembers); err != nil {
+		return nil, err
+	}
+
+	company, err := s.companyRepo.GetByID(ctx, actor.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
+	}
+
+	rawToken, err := newSecretToken()
+	if err != nil {
+		return nil, fmt.Errorf("error generating invitation token: %w", err)
+	}
+
+	invitation, err := domain.CreateCompanyInvitation(domain.CreateCompanyInvitationAttrs{
+		CompanyID: actor.CompanyID,
+		Email:     data.Email,
+		Role:      data.Role,
+		TokenHash: hashSecretToken(rawToken),
+		InvitedBy: actor.ID,
+		TTL:       s.inviteTTL,
+	}, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error creating invitation: %w", err)
+	}
+
+	err = s.invitationRepo.Create(ctx, invitation)
+	if err != nil {
+		return nil, fmt.Errorf("error saving invitation: %w", err)
+	}
+
+	ii := invitation.Immutable()
+	err = s.notifier.Send(ctx, port.Message{
+		To:      ii.Email,
+		Subject: "Приглашение в команду компании",
+		Body: fmt.Sprintf(
+			"Вас пригласили в команду компании «%s». Чтобы присоединиться, перейдите по ссылке: %s%s\nСсылка действует до %s.",
+			company.Immutable().Title, s.inviteURL, rawToken, ii.ExpiresAt.Format(time.RFC3339),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending invitation: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionMemberInvite,
+		TargetType: domain.AuditTargetCompanyInvitation,
+		TargetID:   ii.ID,
+		After:      map[string]any{"email": ii.Email, "role": string(ii.Role)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	result := newCompanyInvitationResult(ii)
+	return &result, nil
+}
+
+func (s *companyMemberService) ListInvitations(ctx context.Context, actor domain.Actor) ([]port.CompanyInvitationResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyManageMembers); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.invitationRepo.ListPending(ctx, actor.CompanyID, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error listing invitations: %w", err)
+	}
+
+	results := make([]port.CompanyInvitationResult, 0, len(invitations))
+	for _, invitation := range invitations {
+		results = append(results, newCompanyInvitationResult(invitation.Immutable()))
+	}
+
+	return results, nil
+}
+
+func (s *companyMemberService) RevokeInvitation(ctx context.Context, actor domain.Actor, invitationID uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyManageMembers); err != nil {
+		return err
+	}
+
+	invitation, err := s.invitationRepo.GetByID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("error getting invitation by id: %w", err)
+	}
+	// Чужое приглашение неотличимо от несуществующего
+	if invitation.Immutable().CompanyID != actor.CompanyID {
+		return fmt.Errorf("invitation of another company: %w", domain.ErrNotFound)
+	}
+
+	invitation, err = invitation.Revoke(s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking invitation: %w", err)
+	}
+
+	if err := s.invitationRepo.Revoke(ctx, invitation); err != nil {
+		return err
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionInvitationRevoke,
+		TargetType: domain.AuditTargetCompanyInvitation,
+		TargetID:   invitationID,
+		Before:     map[string]any{"revoked": false},
+		After:      map[string]any{"revoked": true},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *companyMemberService) AcceptInvitation(ctx context.Context, data port.AcceptCompanyInvitationData) (*port.CompanyMemberWithTokenResult, error) {
+	now := s.clock.Now()
+
+	invitation, err := s.invitationRepo.GetByTokenHash(ctx, hashSecretToken(data.Token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("invitation token is invalid: %w", domain.ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("error getting invitation: %w", err)
+	}
+	if !invitation.Pending(now) {
+		return nil, fmt.Errorf("invitation is accepted, revoked or expired: %w", domain.ErrUnauthorized)
+	}
+
+	ii := invitation.Immutable()
+
+	company, err := s.companyRepo.GetByID(ctx, ii.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
+	}
+	ci := company.Immutable()
+	if !ci.Approved {
+		return nil, fmt.Errorf("company is not approved: %w", &domain.ModerationError{Reason: ci.RejectionReason})
+	}
+
+	if err := s.passwordPolicy.Validate(data.Password, data.Login, data.Name, ii.Email); err != nil {
+		return nil, fmt.Errorf("error validating password: %w", err)
+	}
+
+	passwordHash, err := s.passwordService.Hash(data.Password)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	// Ссылка пришла на этот адрес, значит, он уже подтверждён
+	member, err := domain.CreateCompanyMember(domain.CreateCompanyMemberAttrs{
+		CompanyID:     ii.CompanyID,
+		Role:          ii.Role,
+		Name:          data.Name,
+		Email:         ii.Email,
+		EmailVerified: true,
+		Login:         data.Login,
+		PasswordHash:  passwordHash,
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("error creating company member: %w", err)
+	}
+
+	invitation, err = invitation.Accept(now)
+	if err != nil {
+		return nil, fmt.Errorf("error accepting invitation: %w", err)
+	}
+
+	err = s.invitationRepo.Accept(ctx, invitation, member)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("invitation is accepted or revoked: %w", domain.ErrUnauthorized)
+		}
+		return nil, fmt.Errorf("error saving company member: %w", err)
+	}
+
+	after := memberAuditFields(member.Immutable())
+	after["invitation_id"] = ii.ID.String()
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      member.Actor(),
+		Action:     domain.AuditActionInvitationAccept,
+		TargetType: domain.AuditTargetCompanyMember,
+		TargetID:   member.Immutable().ID,
+		After:      after,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	tokens, err := s.sessionService.Issue(ctx, member.Actor())
+	if err != nil {
+		return nil, fmt.Errorf("error issuing session: %w", err)
+	}
+
+	return &port.CompanyMemberWithTokenResult{
+		CompanyMemberResult: newCompanyMemberResult(member.Immutable()),
+		Token:               tokens.AccessToken,
+		RefreshToken:        tokens.RefreshToken,
+	}, nil
+}
+
+func (s *companyMemberService) ChangeRole(ctx context.Context, actor domain.Actor, memberID uuid.UUID, role domain.CompanyMemberRole) (*port.CompanyMemberResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyManageMembers); err != nil {
+		return nil, err
+	}
+
+	member, err := s.teamMember(ctx, actor, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Последнего владельца не даёт понизить репозиторий
+	member2, err := member.ChangeRole(role, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error changing member role: %w", err)
+	}
+
+	if err := s.memberRepo.Save(ctx, member2); err != nil {
+		return nil, fmt.Errorf("error saving company member: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionMemberRoleChange,
+		TargetType: domain.AuditTargetCompanyMember,
+		TargetID:   memberID,
+		Before:     memberAuditFields(member.Immutable()),
+		After:      memberAuditFields(member2.Immutable()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	result := newCompanyMemberResult(member2.Immutable())
+	return &result, nil
+}
+
+func (s *companyMemberService) RemoveMember(ctx context.Context, actor domain.Actor, memberID uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyManageMembers); err != nil {
+		return err
+	}
+
+	member, err := s.teamMember(ctx, actor, memberID)
+	if err != nil {
+		return err
+	}
+
+	// Последнего владельца не даёт удалить репозиторий
+	if err := s.memberRepo.Delete(ctx, memberID); err != nil {
+		return fmt.Errorf("error deleting company member: %w", err)
+	}
+
+	// Удалённый сотрудник не должен сохранять доступ по выданным токенам
+	if err := s.sessionService.RevokeSubject(ctx, memberID); err != nil {
+		return fmt.Errorf("error revoking member sessions: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionMemberRemove,
+		TargetType: domain.AuditTargetCompanyMember,
+		TargetID:   memberID,
+		Before:     memberAuditFields(member.Immutable()),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+// teamMember возвращает сотрудника компании actor; сотрудник другой
+// компании неотличим от несуществующего.
+func (s *companyMemberService) teamMember(ctx context.Context, actor domain.Actor, memberID uuid.UUID) (*domain.CompanyMember, error) {
+	member, err := s.memberRepo.GetByID(ctx, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company member by id: %w", err)
+	}
+	if member.Immutable().CompanyID != actor.CompanyID {
+		return nil, fmt.Errorf("member of another company: %w", domain.ErrNotFound)
+	}
+
+	return member, nil
+}
+
+// memberAuditFields — поля сотрудника, изменения которых попадают в журнал.
+func memberAuditFields(mi domain.CompanyMemberImmutable) map[string]any {
+	return map[string]any{
+		"company_id": mi.CompanyID.String(),
+		"role":       string(mi.Role),
+		"name":       mi.Name,
+		"email":      mi.Email,
+		"login":      mi.Login,
+	}
+}
+
+func newCompanyMemberResult(mi domain.CompanyMemberImmutable) port.CompanyMemberResult {
+	return port.CompanyMemberResult{
+		ID:            mi.ID,
+		CompanyID:     mi.CompanyID,
+		Role:          mi.Role,
+		Name:          mi.Name,
+		Email:         mi.Email,
+		EmailVerified: mi.EmailVerified,
+		Login:         mi.Login,
+		CreatedAt:     mi.CreatedAt,
+	}
+}
+
+func newCompanyInvitationResult(ii domain.CompanyInvitationImmutable) port.CompanyInvitationResult {
+	return port.CompanyInvitationResult{
+		ID:        ii.ID,
+		Email:     ii.Email,
+		Role:      ii.Role,
+		InvitedBy: ii.InvitedBy,
+		ExpiresAt: ii.ExpiresAt,
+		CreatedAt: ii.CreatedAt,
+	}
+}
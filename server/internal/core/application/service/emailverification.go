@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type emailVerificationService struct {
+	companyRepo    port.CompanyRepository
+	memberRepo     port.CompanyMemberRepository
+	universityRepo port.UniversityRepository
+	tokenRepo      port.OneTimeTokenRepository
+	authorizer     port.Authorizer
+	notifier       port.Notifier
+	auditLog       port.AuditLog
+	clock          port.Clock
+	tokenTTL       time.Duration
+	verifyURL      string
+}
+
+type EmailVerificationServiceDeps struct {
+	CompanyRepo    port.CompanyRepository
+	MemberRepo     port.CompanyMemberRepository
+	UniversityRepo port.UniversityRepository
+	TokenRepo      port.OneTimeTokenRepository
+	Authorizer     port.Authorizer
+	Notifier       port.Notifier
+	AuditLog       port.AuditLog
+	Clock          port.Clock
+	TokenTTL       time.Duration
+	// Адрес страницы подтверждения; токен дописывается в конец
+	VerifyURL string
+}
+
+func NewEmailVerificationService(d EmailVerificationServiceDeps) *emailVerificationService {
+	return &emailVerificationService{
+		companyRepo:    d.CompanyRepo,
+		memberRepo:     d.MemberRepo,
+		universityRepo: d.UniversityRepo,
+		tokenRepo:      d.TokenRepo,
+		authorizer:     d.Authorizer,
+		notifier:       d.Notifier,
+		auditLog:       d.AuditLog,
+		clock:          d.Clock,
+		tokenTTL:       d.TokenTTL,
+		verifyURL:      d.VerifyURL,
+	}
+}
+
+// emailChange — адрес аккаунта до и после запроса подтверждения.
+type emailChange struct {
+	previous string
+	address  string
+	verified bool
+}
+
+func (s *emailVerificationService) Request(ctx context.Context, actor domain.Actor, email string) error {
+	if actor.ViaAPIKey() {
+		return fmt.Errorf("email cannot be changed with an api key: %w", domain.ErrForbidden)
+	}
+
+	now := s.clock.Now()
+	requester := actor
+
+	var (
+		change     emailChange
+		targetType domain.AuditTargetType
+		err        error
+	)
+	switch actor.Role {
+	case domain.RoleCompany:
+		if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCompanyEditProfile); err != nil {
+			return err
+		}
+		// Адрес принадлежит компании, а не сотруднику: ссылку подтверждения
+		// выдаём на компанию, чтобы новая ссылка гасила прежние от любого сотрудника
+		actor = domain.Actor{ID: actor.CompanyID, Role: domain.RoleCompany}
+		targetType = domain.AuditTargetCompany
+		change, err = s.changeCompanyEmail(ctx, actor.ID, email, now)
+	case domain.RoleUniversity:
+		if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityEditProfile); err != nil {
+			return err
+		}
+		targetType = domain.AuditTargetUniversity
+		change, err = s.changeUniversityEmail(ctx, actor.ID, email, now)
+	default:
+		return fmt.Errorf("company or university role required: %w", domain.ErrForbidden)
+	}
+	if err != nil {
+		return err
+	}
+
+	address := change.address
+	if address == "" {
+		return fmt.Errorf("%w: account has no email", domain.ErrInvariantViolated)
+	}
+	if change.verified {
+		return fmt.Errorf("email is already verified: %w", domain.ErrConflict)
+	}
+
+	// Ссылки на прежний адрес больше не должны его подтверждать
+	err = s.tokenRepo.Invalidate(ctx, domain.TokenPurposeEmailVerification, actor.ID, now)
+	if err != nil {
+		return fmt.Errorf("error invalidating previous verification tokens: %w", err)
+	}
+
+	rawToken, err := newSecretToken()
+	if err != nil {
+		return fmt.Errorf("error generating verification token: %w", err)
+	}
+
+	token, err := domain.CreateOneTimeToken(domain.CreateOneTimeTokenAttrs{
+		Purpose:   domain.TokenPurposeEmailVerification,
+		Actor:     actor,
+		TokenHash: hashSecretToken(rawToken),
+		TTL:       s.tokenTTL,
+	}, now)
+	if err != nil {
+		return fmt.Errorf("error creating verification token: %w", err)
+	}
+
+	err = s.tokenRepo.Create(ctx, token)
+	if err != nil {
+		return fmt.Errorf("error saving verification token: %w", err)
+	}
+
+	err = s.notifier.Send(ctx, port.Message{
+		To:      address,
+		Subject: "Подтверждение адреса электронной почты",
+		Body: fmt.Sprintf(
+			"Чтобы подтвердить адрес, перейдите по ссылке: %s%s\nСсылка действует до %s.",
+			s.verifyURL, rawToken, token.Immutable().ExpiresAt.Format(time.RFC3339),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("error sending verification link: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      requester,
+		Action:     domain.AuditActionEmailChangeRequest,
+		TargetType: targetType,
+		TargetID:   actor.ID,
+		Before:     map[string]any{"email": change.previous},
+		After:      map[string]any{"email": change.address},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *emailVerificationService) Confirm(ctx context.Context, rawToken string) error {
+	now := s.clock.Now()
+
+	token, err := s.tokenRepo.Consume(ctx, domain.TokenPurposeEmailVerification, hashSecretToken(rawToken), now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("verification token is invalid, used or expired: %w", domain.ErrUnauthorized)
+		}
+		return fmt.Errorf("error consuming verification token: %w", err)
+	}
+
+	actor := token.Actor()
+
+	var targetType domain.AuditTargetType
+	switch actor.Role {
+	case domain.RoleCompany:
+		targetType = domain.AuditTargetCompany
+		err = s.verifyCompanyEmail(ctx, actor.ID, now)
+	case domain.RoleUniversity:
+		targetType = domain.AuditTargetUniversity
+		err = s.verifyUniversityEmail(ctx, actor.ID, now)
+	default:
+		err = fmt.Errorf("email verification is not supported for role %s: %w", actor.Role, domain.ErrForbidden)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Ссылку может открыть кто угодно, у кого она есть, поэтому актор анонимен
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Action:     domain.AuditActionEmailVerify,
+		TargetType: targetType,
+		TargetID:   actor.ID,
+		Before:     map[string]any{"email_verified": false},
+		After:      map[string]any{"email_verified": true},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *emailVerificationService) changeCompanyEmail(ctx context.Context, id uuid.UUID, email string, at time.Time) (emailChange, error) {
+	company, err := s.companyRepo.GetByID(ctx, id)
+	if err != nil {
+		return emailChange{}, fmt.Errorf("error getting company by id: %w", err)
+	}
+	previous := company.Immutable().Email
+
+	if email != "" && email != previous {
+		company, err = company.ChangeEmail(email, at)
+		if err != nil {
+			return emailChange{}, fmt.Errorf("error changing company email: %w", err)
+		}
+
+		err = s.companyRepo.Save(ctx, company)
+		if err != nil {
+			return emailChange{}, fmt.Errorf("error saving company: %w", err)
+		}
+	}
+
+	ci := company.Immutable()
+	return emailChange{previous: previous, address: ci.Email, verified: ci.EmailVerified}, nil
+}
+
+func (s *emailVerificationService) changeUniversityEmail(ctx context.Context, id uuid.UUID, email string, at time.Time) (emailChange, error) {
+	university, err := s.universityRepo.GetByID(ctx, id)
+	if err != nil {
+		return emailChange{}, fmt.Errorf("error getting university by id: %w", err)
+	}
+	previous := university.Immutable().Email
+
+	if email != "" && email != previous {
+		err = university.ChangeEmail(email, at)
+		if err != nil {
+			return emailChange{}, fmt.Errorf("error changing university email: %w", err)
+		}
+
+		err = s.universityRepo.Save(ctx, university)
+		if err != nil {
+			return emailChange{}, fmt.Errorf("error saving university: %w", err)
+		}
+	}
+
+	ui := university.Immutable()
+	return emailChange{previous: previous, address: ui.Email, verified: ui.EmailVerified}, nil
+}
+
+func (s *emailVerificationService) verifyCompanyEmail(ctx context.Context, id uuid.UUID, at time.Time) error {
+	company, err := s.companyRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error getting company by id: %w", err)
+	}
+
+	company, err = company.VerifyEmail(at)
+	if err != nil {
+		return fmt.Errorf("error verifying company email: %w", err)
+	}
+
+	err = s.companyRepo.Save(ctx, company)
+	if err != nil {
+		return fmt.Errorf("error saving company: %w", err)
+	}
+
+	// Сотрудник с тем же адресом (как правило, владелец) подтвердил его вместе с компанией
+	members, err := s.memberRepo.ListByCompany(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error listing company members: %w", err)
+	}
+	email := company.Immutable().Email
+	for _, member := range members {
+		mi := member.Immutable()
+		if mi.EmailVerified || mi.Email != email {
+			continue
+		}
+
+		member, err = member.VerifyEmail(at)
+		if err != nil {
+			return fmt.Errorf("error verifying company member email: %w", err)
+		}
+
+		err = s.memberRepo.Save(ctx, member)
+		if err != nil {
+			return fmt.Errorf("error saving company member: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *emailVerificationService) verifyUniversityEmail(ctx context.Context, id uuid.UUID, at time.Time) error {
+	university, err := s.universityRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error getting university by id: %w", err)
+	}
+
+	err = university.VerifyEmail(at)
+	if err != nil {
+		return fmt.Errorf("error verifying university email: %w", err)
+	}
+
+	err = s.universityRepo.Save(ctx, university)
+	if err != nil {
+		return fmt.Errorf("error saving university: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+// LoginThrottlePolicy задаёт, после скольких неудач включается задержка.
+// Каждая следующая неудача удваивает её, начиная с BaseDelay, но не больше MaxDelay.
+type LoginThrottlePolicy struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+}
+
+type LoginThrottlerConfig struct {
+	// Per-login защищает конкретный аккаунт от перебора пароля
+	PerLogin LoginThrottlePolicy
+	// Per-IP — от перебора логинов с одного адреса; порог выше из-за NAT
+	PerIP LoginThrottlePolicy
+	// Неудачи старше окна забываются
+	Window time.Duration
+}
+
+type loginThrottler struct {
+	store  port.LoginAttemptStore
+	clock  port.Clock
+	config LoginThrottlerConfig
+}
+
+func NewLoginThrottler(store port.LoginAttemptStore, clock port.Clock, config LoginThrottlerConfig) *loginThrottler {
+	return &loginThrottler{store, clock, config}
+}
+
+// Reserve засчитывает попытку по каждому ключу атомарным увеличением
+// счётчика. Из запросов, прочитавших один и тот же счётчик, сверх
+// бесплатных попыток проходит только тот, чьё увеличение оказалось первым:
+// так пачка параллельных запросов не получает лишних проверок пароля.
+func (t *loginThrottler) Reserve(ctx context.Context, attempt port.LoginAttempt) error {
+	now := t.clock.Now()
+
+	var retryAfter time.Duration
+	for _, k := range t.keys(attempt) {
+		wait, err := t.reserve(ctx, k, now)
+		if err != nil {
+			return err
+		}
+		retryAfter = max(retryAfter, wait)
+	}
+
+	if retryAfter > 0 {
+		return &domain.ThrottleError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// reserve возвращает, сколько ждать до следующей попытки по ключу; ноль,
+// если попытка допущена.
+func (t *loginThrottler) reserve(ctx context.Context, k throttleKey, now time.Time) (time.Duration, error) {
+	seen, err := t.store.Get(ctx, k.key)
+	if err != nil {
+		return 0, fmt.Errorf("error getting login attempts: %w", err)
+	}
+
+	// Заблокированная попытка не засчитывается, чтобы ожидание не продлевалось
+	if wait := t.lockedUntil(seen, k.policy).Sub(now); wait > 0 {
+		return wait, nil
+	}
+	if now.Sub(seen.LastFailureAt) > t.config.Window {
+		seen.Failures = 0
+	}
+
+	reserved, err := t.store.RecordFailure(ctx, k.key, now, now.Add(-t.config.Window))
+	if err != nil {
+		return 0, fmt.Errorf("error recording login attempt: %w", err)
+	}
+
+	if reserved.Failures > k.policy.FreeAttempts+1 && reserved.Failures != seen.Failures+1 {
+		return max(t.lockedUntil(reserved, k.policy).Sub(now), time.Second), nil
+	}
+
+	return 0, nil
+}
+
+// Succeed сбрасывает только счётчик логина: иначе злоумышленник мог бы
+// обнулять счётчик своего IP, периодически входя в собственный аккаунт.
+// С IP снимается лишь резерв этой попытки.
+func (t *loginThrottler) Succeed(ctx context.Context, attempt port.LoginAttempt) error {
+	err := t.store.Reset(ctx, loginKey(attempt))
+	if err != nil {
+		return fmt.Errorf("error resetting login attempts: %w", err)
+	}
+
+	if attempt.IP != "" {
+		err = t.store.Release(ctx, "ip:"+attempt.IP)
+		if err != nil {
+			return fmt.Errorf("error releasing login attempt: %w", err)
+		}
+	}
+
+	return nil
+}
+
+type throttleKey struct {
+	key    string
+	policy LoginThrottlePolicy
+}
+
+func (t *loginThrottler) keys(attempt port.LoginAttempt) []throttleKey {
+	keys := []throttleKey{{loginKey(attempt), t.config.PerLogin}}
+	if attempt.IP != "" {
+		keys = append(keys, throttleKey{"ip:" + attempt.IP, t.config.PerIP})
+	}
+	return keys
+}
+
+func (t *loginThrottler) lockedUntil(attempts port.LoginAttempts, policy LoginThrottlePolicy) time.Time {
+	if attempts.Failures <= policy.FreeAttempts {
+		return time.Time{}
+	}
+	if t.clock.Now().Sub(attempts.LastFailureAt) > t.config.Window {
+		return time.Time{}
+	}
+
+	delay := policy.BaseDelay
+	for i := policy.FreeAttempts + 1; i < attempts.Failures && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, policy.MaxDelay)
+
+	return attempts.LastFailureAt.Add(delay)
+}
+
+// Логины сравниваются без учёта регистра, чтобы «Admin» и «admin» делили счётчик.
+func loginKey(attempt port.LoginAttempt) string {
+	return "login:" + string(attempt.Role) + ":" + strings.ToLower(attempt.Login)
+}
@@ -0,0 +1,51 @@
+package service
+
+import "github.com/hr-platform-mosprom/internal/core/application/port"
+
+// dummyPasswordHash проверяется при входе с несуществующим логином, чтобы
+// по времени ответа нельзя было узнать, какие логины заняты. Параметры
+// совпадают с текущими параметрами Argon2id.
+const dummyPasswordHash = "$argon2id$v=19$m=19456,t=2,p=1$ArU4iZTvYfB8rgc2HTyzCw$TK8/XHCpo2D3Moxk0O9g6cPyHuNq0wBHahgIXrkPQdg"
+
+// passwordServiceChain хеширует текущим алгоритмом, а проверяет тем, чей
+// префикс у хеша. Так старые хеши продолжают работать, пока их не
+// пересчитают при входе.
+type passwordServiceChain struct {
+	current port.PasswordAlgorithm
+	legacy  []port.PasswordAlgorithm
+}
+
+func NewPasswordServiceChain(current port.PasswordAlgorithm, legacy ...port.PasswordAlgorithm) *passwordServiceChain {
+	return &passwordServiceChain{current, legacy}
+}
+
+func (c *passwordServiceChain) Hash(raw string) (string, error) {
+	return c.current.Hash(raw)
+}
+
+func (c *passwordServiceChain) Check(raw, hash string) bool {
+	algorithm, ok := c.algorithm(hash)
+	if !ok {
+		return false
+	}
+	return algorithm.Check(raw, hash)
+}
+
+func (c *passwordServiceChain) NeedsRehash(hash string) bool {
+	if !c.current.Owns(hash) {
+		return true
+	}
+	return c.current.NeedsRehash(hash)
+}
+
+func (c *passwordServiceChain) algorithm(hash string) (port.PasswordAlgorithm, bool) {
+	if c.current.Owns(hash) {
+		return c.current, true
+	}
+	for _, algorithm := range c.legacy {
+		if algorithm.Owns(hash) {
+			return algorithm, true
+		}
+	}
+	return nil, false
+}
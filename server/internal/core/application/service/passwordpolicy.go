@@ -0,0 +1,138 @@
+package service
+
+import (
+	_ "embed"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+//go:embed commonpasswords.txt
+var commonPasswordsFile string
+
+// minAccountPartLength — части логина или названия короче этого не
+// проверяются на сходство, иначе под запрет попадут случайные совпадения.
+const minAccountPartLength = 4
+
+type PasswordPolicyConfig struct {
+	MinLength     int
+	MaxLength     int
+	RequireLower  bool
+	RequireUpper  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// RejectCommon запрещает пароли из встроенного списка утёкших
+	RejectCommon bool
+	// RejectSimilar запрещает пароли, содержащие логин или слова названия
+	RejectSimilar bool
+}
+
+type passwordPolicy struct {
+	config PasswordPolicyConfig
+	common map[string]struct{}
+}
+
+func NewPasswordPolicy(config PasswordPolicyConfig) *passwordPolicy {
+	common := make(map[string]struct{})
+	for line := range strings.Lines(commonPasswordsFile) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		common[strings.ToLower(line)] = struct{}{}
+	}
+
+	return &passwordPolicy{config, common}
+}
+
+func (p *passwordPolicy) Validate(password string, account ...string) error {
+	var violations []domain.PasswordViolation
+
+	length := utf8.RuneCountInString(password)
+	if length < p.config.MinLength {
+		violations = append(violations, domain.PasswordTooShort)
+	}
+	if p.config.MaxLength > 0 && length > p.config.MaxLength {
+		violations = append(violations, domain.PasswordTooLong)
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	if p.config.RequireLower && !hasLower {
+		violations = append(violations, domain.PasswordNoLowercase)
+	}
+	if p.config.RequireUpper && !hasUpper {
+		violations = append(violations, domain.PasswordNoUppercase)
+	}
+	if p.config.RequireDigit && !hasDigit {
+		violations = append(violations, domain.PasswordNoDigit)
+	}
+	if p.config.RequireSymbol && !hasSymbol {
+		violations = append(violations, domain.PasswordNoSymbol)
+	}
+
+	if p.config.RejectSimilar && similarToAccount(password, account) {
+		violations = append(violations, domain.PasswordSimilarToAccount)
+	}
+
+	if p.config.RejectCommon {
+		if _, ok := p.common[strings.ToLower(password)]; ok {
+			violations = append(violations, domain.PasswordCommon)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &domain.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// similarToAccount сравнивает пароль с логином и словами названия без учёта
+// регистра и знаков препинания: «Romashka2024!» похож на «ООО "Romashka"».
+func similarToAccount(password string, account []string) bool {
+	normalized := normalizeForComparison(password)
+	if normalized == "" {
+		return false
+	}
+
+	for _, value := range account {
+		parts := append(strings.FieldsFunc(value, isSeparator), value)
+		for _, part := range parts {
+			part = normalizeForComparison(part)
+			if utf8.RuneCountInString(part) < minAccountPartLength {
+				continue
+			}
+			if strings.Contains(normalized, part) || strings.Contains(part, normalized) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func normalizeForComparison(s string) string {
+	return strings.Map(func(r rune) rune {
+		if isSeparator(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, s)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type passwordResetService struct {
+	memberRepo      port.CompanyMemberRepository
+	universityRepo  port.UniversityRepository
+	tokenRepo       port.OneTimeTokenRepository
+	passwordService port.PasswordService
+	passwordPolicy  port.PasswordPolicy
+	sessionService  port.SessionService
+	notifier        port.Notifier
+	auditLog        port.AuditLog
+	clock           port.Clock
+	tokenTTL        time.Duration
+	resetURL        string
+}
+
+type PasswordResetServiceDeps struct {
+	MemberRepo      port.CompanyMemberRepository
+	UniversityRepo  port.UniversityRepository
+	TokenRepo       port.OneTimeTokenRepository
+	PasswordService port.PasswordService
+	PasswordPolicy  port.PasswordPolicy
+	SessionService  port.SessionService
+	Notifier        port.Notifier
+	AuditLog        port.AuditLog
+	Clock           port.Clock
+	TokenTTL        time.Duration
+	// Адрес страницы сброса; токен дописывается в конец
+	ResetURL string
+}
+
+func NewPasswordResetService(d PasswordResetServiceDeps) *passwordResetService {
+	return &passwordResetService{
+		memberRepo:      d.MemberRepo,
+		universityRepo:  d.UniversityRepo,
+		tokenRepo:       d.TokenRepo,
+		passwordService: d.PasswordService,
+		passwordPolicy:  d.PasswordPolicy,
+		sessionService:  d.SessionService,
+		notifier:        d.Notifier,
+		auditLog:        d.AuditLog,
+		clock:           d.Clock,
+		tokenTTL:        d.TokenTTL,
+		resetURL:        d.ResetURL,
+	}
+}
+
+func (s *passwordResetService) Request(ctx context.Context, data port.RequestPasswordResetData) error {
+	actor, email, err := s.findAccount(ctx, data)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	// Ссылку некуда надёжно отправить — ведём себя как для несуществующего аккаунта
+	if email == "" {
+		return nil
+	}
+
+	now := s.clock.Now()
+
+	// Действует только последняя отправленная ссылка
+	err = s.tokenRepo.Invalidate(ctx, domain.TokenPurposePasswordReset, actor.ID, now)
+	if err != nil {
+		return fmt.Errorf("error invalidating previous reset tokens: %w", err)
+	}
+
+	rawToken, err := newSecretToken()
+	if err != nil {
+		return fmt.Errorf("error generating reset token: %w", err)
+	}
+
+	token, err := domain.CreateOneTimeToken(domain.CreateOneTimeTokenAttrs{
+		Purpose:   domain.TokenPurposePasswordReset,
+		Actor:     actor,
+		TokenHash: hashSecretToken(rawToken),
+		TTL:       s.tokenTTL,
+	}, now)
+	if err != nil {
+		return fmt.Errorf("error creating reset token: %w", err)
+	}
+
+	err = s.tokenRepo.Create(ctx, token)
+	if err != nil {
+		return fmt.Errorf("error saving reset token: %w", err)
+	}
+
+	err = s.notifier.Send(ctx, port.Message{
+		To:      email,
+		Subject: "Восстановление пароля",
+		Body: fmt.Sprintf(
+			"Чтобы задать новый пароль, перейдите по ссылке: %s%s\nСсылка действует до %s. Если вы не запрашивали сброс, проигнорируйте это письмо.",
+			s.resetURL, rawToken, token.Immutable().ExpiresAt.Format(time.RFC3339),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("error sending reset link: %w", err)
+	}
+
+	// Запросить сброс может кто угодно, поэтому актор анонимен
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Action:     domain.AuditActionPasswordResetSend,
+		TargetType: accountAuditTarget(actor),
+		TargetID:   actor.ID,
+		After:      map[string]any{"email": email},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *passwordResetService) Confirm(ctx context.Context, data port.ConfirmPasswordResetData) error {
+	now := s.clock.Now()
+	tokenHash := hashSecretToken(data.Token)
+
+	// Пароль проверяется до погашения токена, чтобы отказ политики не сжигал ссылку
+	token, err := s.tokenRepo.GetActive(ctx, domain.TokenPurposePasswordReset, tokenHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("reset token is invalid, used or expired: %w", domain.ErrUnauthorized)
+		}
+		return fmt.Errorf("error getting reset token: %w", err)
+	}
+
+	actor := token.Actor()
+
+	account, err := s.accountNames(ctx, actor)
+	if err != nil {
+		return err
+	}
+
+	err = s.passwordPolicy.Validate(data.NewPassword, account...)
+	if err != nil {
+		return fmt.Errorf("error validating password: %w", err)
+	}
+
+	passwordHash, err := s.passwordService.Hash(data.NewPassword)
+	if err != nil {
+		return fmt.Errorf("error hashing password: %w", err)
+	}
+
+	_, err = s.tokenRepo.Consume(ctx, domain.TokenPurposePasswordReset, tokenHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("reset token is invalid, used or expired: %w", domain.ErrUnauthorized)
+		}
+		return fmt.Errorf("error consuming reset token: %w", err)
+	}
+
+	switch actor.Role {
+	case domain.RoleCompany:
+		err = s.setMemberPassword(ctx, actor.ID, passwordHash, now)
+	case domain.RoleUniversity:
+		err = s.setUniversityPassword(ctx, actor.ID, passwordHash, now)
+	default:
+		err = fmt.Errorf("password reset is not supported for role %s: %w", actor.Role, domain.ErrForbidden)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Пароль мог утечь вместе с сессиями — завершаем их все
+	err = s.sessionService.RevokeSubject(ctx, actor.ID)
+	if err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Action:     domain.AuditActionPasswordReset,
+		TargetType: accountAuditTarget(actor),
+		TargetID:   actor.ID,
+		After:      map[string]any{"password_changed": true},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+// findAccount возвращает владельца логина и его подтверждённый адрес;
+// если адрес не подтверждён, он пуст.
+func (s *passwordResetService) findAccount(ctx context.Context, data port.RequestPasswordResetData) (domain.Actor, string, error) {
+	switch data.Role {
+	case port.RoleCompany:
+		member, err := s.memberRepo.GetByLogin(ctx, data.Login)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.Actor{}, "", err
+			}
+			return domain.Actor{}, "", fmt.Errorf("error getting company member by login: %w", err)
+		}
+		mi := member.Immutable()
+		return member.Actor(), verifiedEmail(mi.Email, mi.EmailVerified), nil
+	case port.RoleUniversity:
+		university, err := s.universityRepo.GetByLogin(ctx, data.Login)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.Actor{}, "", err
+			}
+			return domain.Actor{}, "", fmt.Errorf("error getting university by login: %w", err)
+		}
+		ui := university.Immutable()
+		return domain.Actor{ID: ui.ID, Role: domain.RoleUniversity}, verifiedEmail(ui.Email, ui.EmailVerified), nil
+	default:
+		return domain.Actor{}, "", fmt.Errorf("%w: password reset is not supported for role %q", domain.ErrInvariantViolated, data.Role)
+	}
+}
+
+func (s *passwordResetService) setMemberPassword(ctx context.Context, id uuid.UUID, passwordHash string, at time.Time) error {
+	member, err := s.memberRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error getting company member by id: %w", err)
+	}
+
+	member, err = member.ChangeCredentials("", passwordHash, at)
+	if err != nil {
+		return fmt.Errorf("error changing company member credentials: %w", err)
+	}
+
+	err = s.memberRepo.Save(ctx, member)
+	if err != nil {
+		return fmt.Errorf("error saving company member: %w", err)
+	}
+
+	return nil
+}
+
+func (s *passwordResetService) setUniversityPassword(ctx context.Context, id uuid.UUID, passwordHash string, at time.Time) error {
+	university, err := s.universityRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("error getting university by id: %w", err)
+	}
+
+	err = university.SetPasswordHash(passwordHash, at)
+	if err != nil {
+		return fmt.Errorf("error setting password hash: %w", err)
+	}
+
+	err = s.universityRepo.Save(ctx, university)
+	if err != nil {
+		return fmt.Errorf("error saving university: %w", err)
+	}
+
+	return nil
+}
+
+// accountNames возвращает логин и имя аккаунта для проверки сходства с паролем.
+func (s *passwordResetService) accountNames(ctx context.Context, actor domain.Actor) ([]string, error) {
+	switch actor.Role {
+	case domain.RoleCompany:
+		member, err := s.memberRepo.GetByID(ctx, actor.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting company member by id: %w", err)
+		}
+		mi := member.Immutable()
+		return []string{mi.Login, mi.Name, mi.Email}, nil
+	case domain.RoleUniversity:
+		university, err := s.universityRepo.GetByID(ctx, actor.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting university by id: %w", err)
+		}
+		ui := university.Immutable()
+		return []string{ui.Login, ui.Title}, nil
+	default:
+		return nil, fmt.Errorf("password reset is not supported for role %s: %w", actor.Role, domain.ErrForbidden)
+	}
+}
+
+func verifiedEmail(email string, verified bool) string {
+	if !verified {
+		return ""
+	}
+	return email
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type pipelineService struct {
+	repo       port.PipelineRepository
+	vRepo      port.VacancyRepository
+	authorizer port.Authorizer
+	auditLog   port.AuditLog
+	clock      port.Clock
+}
+
+func NewPipelineService(
+	r port.PipelineRepository,
+	v port.VacancyRepository,
+	a port.Authorizer,
+	al port.AuditLog,
+	c port.Clock,
+) *pipelineService {
+	return &pipelineService{repo: r, vRepo: v, authorizer: a, auditLog: al, clock: c}
+}
+
+func (s *pipelineService) Get(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID) (*domain.Pipeline, error) {
+	if err := s.checkScope(ctx, actor, vacancyID, domain.PermissionVacancyView); err != nil {
+		return nil, err
+	}
+
+	return resolvePipeline(ctx, s.repo, actor.CompanyID, vacancyID)
+}
+
+func (s *pipelineService) Set(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID, stages []domain.PipelineStage) (*domain.Pipeline, error) {
+	if err := s.checkScope(ctx, actor, vacancyID, domain.PermissionPipelineManage); err != nil {
+		return nil, err
+	}
+
+	before, err := s.current(ctx, actor.CompanyID, vacancyID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := domain.CreatePipeline(domain.CreatePipelineAttrs{
+		CompanyID: actor.CompanyID,
+		VacancyID: vacancyID,
+		Stages:    stages,
+	}, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error creating pipeline: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, p); err != nil {
+		return nil, fmt.Errorf("error saving pipeline: %w", err)
+	}
+
+	entry := port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionPipelineUpdate,
+		TargetType: domain.AuditTargetPipeline,
+		TargetID:   p.Immutable().ID,
+		After:      pipelineAuditFields(p.Immutable()),
+	}
+	if before != nil {
+		entry.Before = pipelineAuditFields(before.Immutable())
+	}
+	if err := s.auditLog.Record(ctx, entry); err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return p, nil
+}
+
+func (s *pipelineService) Reset(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID) error {
+	if err := s.checkScope(ctx, actor, vacancyID, domain.PermissionPipelineManage); err != nil {
+		return err
+	}
+
+	before, err := s.current(ctx, actor.CompanyID, vacancyID)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return fmt.Errorf("pipeline is not customized: %w", domain.ErrNotFound)
+	}
+
+	if err := s.repo.Clear(ctx, actor.CompanyID, vacancyID, s.clock.Now()); err != nil {
+		return fmt.Errorf("error clearing pipeline: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionPipelineReset,
+		TargetType: domain.AuditTargetPipeline,
+		TargetID:   before.Immutable().ID,
+		Before:     pipelineAuditFields(before.Immutable()),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+// checkScope проверяет право permission и, для конвейера вакансии, что
+// вакансия принадлежит компании actor.
+func (s *pipelineService) checkScope(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID, permission domain.Permission) error {
+	if err := s.authorizer.Authorize(ctx, actor, permission); err != nil {
+		return err
+	}
+	if vacancyID == uuid.Nil {
+		return nil
+	}
+
+	v, err := s.vRepo.ByID(ctx, vacancyID)
+	if err != nil {
+		return fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+	if v.Immutable().CompanyID != actor.CompanyID {
+		return fmt.Errorf("vacancy belongs to another company: %w", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// current возвращает настроенный конвейер или nil, если его нет.
+func (s *pipelineService) current(ctx context.Context, companyID, vacancyID uuid.UUID) (*domain.Pipeline, error) {
+	p, err := s.repo.Current(ctx, companyID, vacancyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting current pipeline: %w", err)
+	}
+	return p, nil
+}
+
+// resolvePipeline выбирает действующий конвейер: вакансии, затем компании
+// по умолчанию, затем встроенный.
+func resolvePipeline(ctx context.Context, repo port.PipelineRepository, companyID, vacancyID uuid.UUID) (*domain.Pipeline, error) {
+	scopes := []uuid.UUID{uuid.Nil}
+	if vacancyID != uuid.Nil {
+		scopes = []uuid.UUID{vacancyID, uuid.Nil}
+	}
+
+	for _, scope := range scopes {
+		p, err := repo.Current(ctx, companyID, scope)
+		if err == nil {
+			return p, nil
+		}
+		if !errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("error getting current pipeline: %w", err)
+		}
+	}
+
+	return domain.DefaultPipeline(), nil
+}
+
+func pipelineAuditFields(pi domain.PipelineImmutable) map[string]any {
+	stages := make([]map[string]any, 0, len(pi.Stages))
+	for _, st := range pi.Stages {
+		stages = append(stages, map[string]any{"key": st.Key, "name": st.Name, "type": string(st.Type)})
+	}
+
+	fields := map[string]any{"stages": stages}
+	if pi.VacancyID != uuid.Nil {
+		fields["vacancy_id"] = pi.VacancyID.String()
+	}
+	return fields
+}
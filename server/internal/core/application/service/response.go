@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/hr-platform-mosprom/internal/core/application/port"
@@ -9,19 +10,52 @@ import (
 )
 
 type responseService struct {
-	repo  port.ResponseRepository
-	vRepo port.VacancyRepository
-	clock port.Clock
+	repo       port.ResponseRepository
+	vRepo      port.VacancyRepository
+	cRepo      port.CompanyRepository
+	pRepo      port.PipelineRepository
+	authorizer port.Authorizer
+	auditLog   port.AuditLog
+	clock      port.Clock
 }
 
-func NewResponseService(r port.ResponseRepository, v port.VacancyRepository, c port.Clock) *responseService {
-	return &responseService{repo: r, vRepo: v, clock: c}
+func NewResponseService(
+	r port.ResponseRepository,
+	v port.VacancyRepository,
+	co port.CompanyRepository,
+	p port.PipelineRepository,
+	a port.Authorizer,
+	al port.AuditLog,
+	c port.Clock,
+) *responseService {
+	return &responseService{repo: r, vRepo: v, cRepo: co, pRepo: p, authorizer: a, auditLog: al, clock: c}
 }
 
 func (s *responseService) Create(ctx context.Context, in port.CreateResponseInput) (*domain.Response, error) {
-	if _, err := s.vRepo.ByID(ctx, in.VacancyID); err != nil {
+	v, err := s.vRepo.ByID(ctx, in.VacancyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+	if v.Immutable().State != domain.VacancyPublished {
+		return nil, fmt.Errorf("vacancy is not published: %w", domain.ErrNotFound)
+	}
+
+	// Вакансии компании с отозванным одобрением скрыты из ленты — и отклики
+	// на них не принимаются
+	co, err := s.cRepo.GetByID(ctx, v.Immutable().CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
+	}
+	if !co.Immutable().Approved {
+		return nil, fmt.Errorf("company is not approved: %w", domain.ErrNotFound)
+	}
+
+	// Отклик закрепляется за действующей версией конвейера
+	p, err := resolvePipeline(ctx, s.pRepo, v.Immutable().CompanyID, in.VacancyID)
+	if err != nil {
 		return nil, err
 	}
+
 	now := s.clock.Now()
 	r, err := domain.CreateResponse(domain.CreateResponseAttrs{
 		VacancyID:   in.VacancyID,
@@ -30,22 +64,212 @@ func (s *responseService) Create(ctx context.Context, in port.CreateResponseInpu
 		Phone:       in.Phone,
 		CoverLetter: in.CoverLetter,
 		ResumeURL:   in.ResumeURL,
+		Pipeline:    p,
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("error creating response: %w", err)
+	}
+
+	// Отклик оставляет анонимный кандидат; его контакты в журнал не пишутся
+	ri := r.Immutable()
+
+	change, err := domain.CreateResponseStatusChange(domain.CreateResponseStatusChangeAttrs{
+		ResponseID: ri.ID,
+		To:         ri.Status,
+		ToStage:    ri.Stage,
 	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("error creating response status change: %w", err)
+	}
+
+	if err := s.repo.SaveWithStatusChange(ctx, r, change); err != nil {
+		return nil, fmt.Errorf("error saving response: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Action:     domain.AuditActionResponseCreate,
+		TargetType: domain.AuditTargetResponse,
+		TargetID:   ri.ID,
+		After: map[string]any{
+			"vacancy_id": ri.VacancyID.String(),
+			"status":     string(ri.Status),
+			"stage":      ri.Stage,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return r, nil
+}
+
+func (s *responseService) Get(ctx context.Context, actor domain.Actor, id uuid.UUID) (*port.ResponseWithHistory, error) {
+	r, err := s.repo.ByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting response by id: %w", err)
+	}
+
+	if err := s.checkVacancyOwner(ctx, actor, r.Immutable().VacancyID, domain.PermissionResponseView); err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.StatusHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting response status history: %w", err)
+	}
+
+	r, err = s.redact(ctx, actor, r)
 	if err != nil {
 		return nil, err
 	}
-	return r, s.repo.Save(ctx, r)
+
+	return &port.ResponseWithHistory{Response: r, History: history}, nil
+}
+
+func (s *responseService) ListByVacancy(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID, page domain.PageRequest) (port.Paginated[*domain.Response], error) {
+	if err := s.checkVacancyOwner(ctx, actor, vacancyID, domain.PermissionResponseView); err != nil {
+		return port.Paginated[*domain.Response]{}, err
+	}
+
+	responses, total, err := s.repo.ByVacancy(ctx, vacancyID, page)
+	if err != nil {
+		return port.Paginated[*domain.Response]{}, fmt.Errorf("error listing responses by vacancy: %w", err)
+	}
+
+	canViewPII, err := s.authorizer.Can(ctx, actor, domain.PermissionResponseViewPII)
+	if err != nil {
+		return port.Paginated[*domain.Response]{}, err
+	}
+	if !canViewPII {
+		for i, r := range responses {
+			responses[i] = r.WithoutContacts()
+		}
+	}
+
+	return port.NewPaginated(responses, page, total), nil
 }
 
-func (s *responseService) SetStatus(ctx context.Context, id uuid.UUID, status string) (*domain.Response, error) {
+func (s *responseService) MoveToStage(ctx context.Context, actor domain.Actor, id uuid.UUID, stage string) (*domain.Response, error) {
+	return s.move(ctx, actor, id, func(*domain.Pipeline, string) (string, error) {
+		return stage, nil
+	})
+}
+
+func (s *responseService) SetStatus(ctx context.Context, actor domain.Actor, id uuid.UUID, status domain.ResponseStatus) (*domain.Response, error) {
+	return s.move(ctx, actor, id, func(p *domain.Pipeline, from string) (string, error) {
+		return p.NextStageOfType(from, status)
+	})
+}
+
+// move переводит отклик на этап, который pick выбирает в его конвейере по
+// текущему этапу.
+func (s *responseService) move(
+	ctx context.Context,
+	actor domain.Actor,
+	id uuid.UUID,
+	pick func(p *domain.Pipeline, from string) (string, error),
+) (*domain.Response, error) {
 	r, err := s.repo.ByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting response by id: %w", err)
+	}
+
+	if err := s.checkVacancyOwner(ctx, actor, r.Immutable().VacancyID, domain.PermissionResponseChangeStatus); err != nil {
+		return nil, err
+	}
+
+	p, err := s.pipeline(ctx, r.Immutable().PipelineID)
 	if err != nil {
 		return nil, err
 	}
+
+	stage, err := pick(p, r.Immutable().Stage)
+	if err != nil {
+		return nil, fmt.Errorf("error choosing response stage: %w", err)
+	}
+
 	now := s.clock.Now()
-	r2, err := r.SetStatus(status, now)
+
+	r2, err := r.MoveToStage(p, stage, now)
+	if err != nil {
+		return nil, fmt.Errorf("error moving response to stage: %w", err)
+	}
+
+	ri, ri2 := r.Immutable(), r2.Immutable()
+
+	change, err := domain.CreateResponseStatusChange(domain.CreateResponseStatusChangeAttrs{
+		ResponseID: id,
+		From:       ri.Status,
+		To:         ri2.Status,
+		FromStage:  ri.Stage,
+		ToStage:    ri2.Stage,
+		Actor:      actor,
+	}, now)
+	if err != nil {
+		return nil, fmt.Errorf("error creating response status change: %w", err)
+	}
+
+	if err := s.repo.SaveWithStatusChange(ctx, r2, change); err != nil {
+		return nil, fmt.Errorf("error saving response: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionResponseStatusChange,
+		TargetType: domain.AuditTargetResponse,
+		TargetID:   id,
+		Before:     map[string]any{"status": string(ri.Status), "stage": ri.Stage},
+		After:      map[string]any{"status": string(ri2.Status), "stage": ri2.Stage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return s.redact(ctx, actor, r2)
+}
+
+// pipeline возвращает версию конвейера, по которой подан отклик; пустой id —
+// встроенный конвейер.
+func (s *responseService) pipeline(ctx context.Context, id uuid.UUID) (*domain.Pipeline, error) {
+	if id == uuid.Nil {
+		return domain.DefaultPipeline(), nil
+	}
+
+	p, err := s.pRepo.ByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pipeline by id: %w", err)
+	}
+	return p, nil
+}
+
+// checkVacancyOwner проверяет право permission и то, что вакансия
+// принадлежит компании actor.
+func (s *responseService) checkVacancyOwner(ctx context.Context, actor domain.Actor, vacancyID uuid.UUID, permission domain.Permission) error {
+	if err := s.authorizer.Authorize(ctx, actor, permission); err != nil {
+		return err
+	}
+
+	v, err := s.vRepo.ByID(ctx, vacancyID)
+	if err != nil {
+		return fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+
+	if v.Immutable().CompanyID != actor.CompanyID {
+		return fmt.Errorf("vacancy belongs to another company: %w", domain.ErrForbidden)
+	}
+
+	return nil
+}
+
+// redact скрывает контакты кандидата от тех, у кого нет права на них.
+func (s *responseService) redact(ctx context.Context, actor domain.Actor, r *domain.Response) (*domain.Response, error) {
+	canViewPII, err := s.authorizer.Can(ctx, actor, domain.PermissionResponseViewPII)
 	if err != nil {
 		return nil, err
 	}
-	return r2, s.repo.Save(ctx, r2)
+	if !canViewPII {
+		return r.WithoutContacts(), nil
+	}
+
+	return r, nil
 }
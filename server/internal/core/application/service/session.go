@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+type sessionService struct {
+	sessionRepo     port.SessionRepository
+	tokenService    port.TokenService
+	auditLog        port.AuditLog
+	clock           port.Clock
+	refreshTokenTTL time.Duration
+}
+
+type SessionServiceDeps struct {
+	SessionRepo     port.SessionRepository
+	TokenService    port.TokenService
+	AuditLog        port.AuditLog
+	Clock           port.Clock
+	RefreshTokenTTL time.Duration
+}
+
+func NewSessionService(d SessionServiceDeps) *sessionService {
+	return &sessionService{
+		sessionRepo:     d.SessionRepo,
+		tokenService:    d.TokenService,
+		auditLog:        d.AuditLog,
+		clock:           d.Clock,
+		refreshTokenTTL: d.RefreshTokenTTL,
+	}
+}
+
+func (s *sessionService) Issue(ctx context.Context, actor domain.Actor) (port.SessionTokens, error) {
+	refreshToken, session, err := s.newSession(actor, uuid.Nil)
+	if err != nil {
+		return port.SessionTokens{}, err
+	}
+
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return port.SessionTokens{}, fmt.Errorf("error saving session: %w", err)
+	}
+
+	return s.tokens(session, refreshToken)
+}
+
+func (s *sessionService) Refresh(ctx context.Context, refreshToken string) (port.SessionTokens, error) {
+	prev, err := s.sessionRepo.GetByRefreshTokenHash(ctx, hashSecretToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return port.SessionTokens{}, domain.ErrUnauthorized
+		}
+		return port.SessionTokens{}, fmt.Errorf("error getting session: %w", err)
+	}
+
+	now := s.clock.Now()
+
+	if prev.Revoked() || prev.Expired(now) {
+		return port.SessionTokens{}, domain.ErrUnauthorized
+	}
+	if prev.Rotated() {
+		return port.SessionTokens{}, s.revokeReusedFamily(ctx, prev)
+	}
+
+	nextRefreshToken, next, err := s.newSession(prev.Actor(), prev.Immutable().FamilyID)
+	if err != nil {
+		return port.SessionTokens{}, err
+	}
+
+	err = s.sessionRepo.Rotate(ctx, prev, next, now)
+	if err != nil {
+		// Тот же токен успели использовать параллельным запросом
+		if errors.Is(err, domain.ErrConflict) {
+			return port.SessionTokens{}, s.revokeReusedFamily(ctx, prev)
+		}
+		return port.SessionTokens{}, fmt.Errorf("error rotating session: %w", err)
+	}
+
+	return s.tokens(next, nextRefreshToken)
+}
+
+func (s *sessionService) Logout(ctx context.Context, refreshToken string) error {
+	session, err := s.sessionRepo.GetByRefreshTokenHash(ctx, hashSecretToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrUnauthorized
+		}
+		return fmt.Errorf("error getting session: %w", err)
+	}
+
+	err = s.sessionRepo.RevokeFamily(ctx, session.Immutable().FamilyID, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking session family: %w", err)
+	}
+
+	actor := session.Actor()
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionLogout,
+		TargetType: accountAuditTarget(actor),
+		TargetID:   actor.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sessionService) RevokeSubject(ctx context.Context, subjectID uuid.UUID) error {
+	err := s.sessionRepo.RevokeBySubject(ctx, subjectID, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *sessionService) RevokeCompany(ctx context.Context, companyID uuid.UUID) error {
+	err := s.sessionRepo.RevokeByCompany(ctx, companyID, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking company sessions: %w", err)
+	}
+
+	return nil
+}
+
+// revokeReusedFamily вызывается, когда уже использованный refresh-токен
+// предъявлен повторно: им может владеть злоумышленник, поэтому отзываются
+// все сессии семейства, включая действующую.
+func (s *sessionService) revokeReusedFamily(ctx context.Context, session *domain.Session) error {
+	si := session.Immutable()
+
+	err := s.sessionRepo.RevokeFamily(ctx, si.FamilyID, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking session family: %w", err)
+	}
+
+	// Предъявивший токен не обязательно его владелец, поэтому актор анонимен,
+	// а владелец указывается объектом
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Action:     domain.AuditActionSessionReuse,
+		TargetType: accountAuditTarget(session.Actor()),
+		TargetID:   si.SubjectID,
+		After:      map[string]any{"family_id": si.FamilyID.String()},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return fmt.Errorf("refresh token reuse detected: %w", domain.ErrUnauthorized)
+}
+
+func (s *sessionService) newSession(actor domain.Actor, familyID uuid.UUID) (string, *domain.Session, error) {
+	refreshToken, err := newSecretToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("error generating refresh token: %w", err)
+	}
+
+	session, err := domain.CreateSession(domain.CreateSessionAttrs{
+		FamilyID:         familyID,
+		Actor:            actor,
+		RefreshTokenHash: hashSecretToken(refreshToken),
+		TTL:              s.refreshTokenTTL,
+	}, s.clock.Now())
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating session: %w", err)
+	}
+
+	return refreshToken, session, nil
+}
+
+func (s *sessionService) tokens(session *domain.Session, refreshToken string) (port.SessionTokens, error) {
+	si := session.Immutable()
+
+	accessToken, err := s.tokenService.Generate(port.TokenPayload{
+		Sub:       si.SubjectID,
+		Role:      port.Role(si.Role),
+		CompanyID: si.CompanyID,
+		SessionID: si.FamilyID,
+	})
+	if err != nil {
+		return port.SessionTokens{}, fmt.Errorf("error generating token: %w", err)
+	}
+
+	return port.SessionTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// sessionTokenService дополняет проверку подписи access-токена проверкой
+// того, что его семейство сессий не отозвано.
+type sessionTokenService struct {
+	port.TokenService
+	sessionRepo port.SessionRepository
+	clock       port.Clock
+}
+
+func NewSessionTokenService(tokenService port.TokenService, sessionRepo port.SessionRepository, clock port.Clock) *sessionTokenService {
+	return &sessionTokenService{tokenService, sessionRepo, clock}
+}
+
+func (s *sessionTokenService) Validate(ctx context.Context, token string) (port.TokenPayload, error) {
+	payload, err := s.TokenService.Validate(ctx, token)
+	if err != nil {
+		return port.TokenPayload{}, err
+	}
+
+	active, err := s.sessionRepo.IsFamilyActive(ctx, payload.SessionID, s.clock.Now())
+	if err != nil {
+		return port.TokenPayload{}, fmt.Errorf("error checking session: %w", err)
+	}
+	if !active {
+		return port.TokenPayload{}, fmt.Errorf("session is revoked or expired: %w", domain.ErrUnauthorized)
+	}
+
+	return payload, nil
+}
+
+func newSecretToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// В БД хранится только хеш: утечка таблицы не даёт действующих токенов.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
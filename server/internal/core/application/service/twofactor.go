@@ -0,0 +1,592 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
+	"github.com/hr-platform-mosprom/internal/core/domain"
+)
+
+const recoveryCodeCount = 10
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type twoFactorService struct {
+	twoFactorRepo   port.TwoFactorRepository
+	requirementRepo port.TwoFactorRequirementRepository
+	tokenRepo       port.OneTimeTokenRepository
+	memberRepo      port.CompanyMemberRepository
+	adminRepo       port.AdminRepository
+	totp            port.TOTP
+	sessionService  port.SessionService
+	loginThrottler  port.LoginThrottler
+	authorizer      port.Authorizer
+	auditLog        port.AuditLog
+	clock           port.Clock
+	issuer          string
+	challengeTTL    time.Duration
+}
+
+type TwoFactorServiceDeps struct {
+	TwoFactorRepo   port.TwoFactorRepository
+	RequirementRepo port.TwoFactorRequirementRepository
+	TokenRepo       port.OneTimeTokenRepository
+	MemberRepo      port.CompanyMemberRepository
+	AdminRepo       port.AdminRepository
+	TOTP            port.TOTP
+	SessionService  port.SessionService
+	LoginThrottler  port.LoginThrottler
+	Authorizer      port.Authorizer
+	AuditLog        port.AuditLog
+	Clock           port.Clock
+	// Название сервиса, которое приложение-аутентификатор показывает рядом с кодом
+	Issuer string
+	// Сколько живёт промежуточный токен между паролем и кодом
+	ChallengeTTL time.Duration
+}
+
+func NewTwoFactorService(d TwoFactorServiceDeps) *twoFactorService {
+	return &twoFactorService{
+		twoFactorRepo:   d.TwoFactorRepo,
+		requirementRepo: d.RequirementRepo,
+		tokenRepo:       d.TokenRepo,
+		memberRepo:      d.MemberRepo,
+		adminRepo:       d.AdminRepo,
+		totp:            d.TOTP,
+		sessionService:  d.SessionService,
+		loginThrottler:  d.LoginThrottler,
+		authorizer:      d.Authorizer,
+		auditLog:        d.AuditLog,
+		clock:           d.Clock,
+		issuer:          d.Issuer,
+		challengeTTL:    d.ChallengeTTL,
+	}
+}
+
+func (s *twoFactorService) Status(ctx context.Context, actor domain.Actor) (*port.TwoFactorStatus, error) {
+	twoFactor, err := s.twoFactorRepo.Get(ctx, actor.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return &port.TwoFactorStatus{}, nil
+		}
+		return nil, fmt.Errorf("error getting two-factor: %w", err)
+	}
+	if !twoFactor.Confirmed() {
+		return &port.TwoFactorStatus{}, nil
+	}
+
+	left, err := s.twoFactorRepo.CountRecoveryCodes(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error counting recovery codes: %w", err)
+	}
+
+	return &port.TwoFactorStatus{Enabled: true, RecoveryCodesLeft: left}, nil
+}
+
+func (s *twoFactorService) Enroll(ctx context.Context, actor domain.Actor) (*port.TwoFactorEnrollment, error) {
+	account, err := s.accountName(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.twoFactorRepo.Get(ctx, actor.ID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("error getting two-factor: %w", err)
+	}
+	if existing != nil && existing.Confirmed() {
+		return nil, fmt.Errorf("%w: two-factor is already enabled", domain.ErrConflict)
+	}
+
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("error generating secret: %w", err)
+	}
+
+	// Незавершённая настройка перезаписывается: старый QR-код больше не нужен
+	twoFactor, err := domain.CreateTwoFactor(domain.CreateTwoFactorAttrs{
+		Actor:  actor,
+		Secret: secret,
+	}, s.clock.Now())
+	if err != nil {
+		return nil, fmt.Errorf("error creating two-factor: %w", err)
+	}
+
+	err = s.twoFactorRepo.Save(ctx, twoFactor)
+	if err != nil {
+		return nil, fmt.Errorf("error saving two-factor: %w", err)
+	}
+
+	err = s.recordAccount(ctx, actor, domain.AuditActionTwoFactorEnroll, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    s.totp.URI(secret, s.issuer, account),
+	}, nil
+}
+
+func (s *twoFactorService) Confirm(ctx context.Context, actor domain.Actor, code string) ([]string, error) {
+	twoFactor, err := s.twoFactorRepo.Get(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting two-factor: %w", err)
+	}
+
+	now := s.clock.Now()
+
+	step, ok := s.totp.Verify(twoFactor.Immutable().Secret, normalizeCode(code), now)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid two-factor code", domain.ErrUnauthorized)
+	}
+
+	twoFactor, err = twoFactor.Confirm(step, now)
+	if err != nil {
+		return nil, fmt.Errorf("error confirming two-factor: %w", err)
+	}
+
+	err = s.twoFactorRepo.Save(ctx, twoFactor)
+	if err != nil {
+		return nil, fmt.Errorf("error saving two-factor: %w", err)
+	}
+
+	codes, err := s.issueRecoveryCodes(ctx, actor.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.recordAccount(ctx, actor, domain.AuditActionTwoFactorEnable,
+		map[string]any{"two_factor": false}, map[string]any{"two_factor": true})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(ctx context.Context, actor domain.Actor, code string) ([]string, error) {
+	twoFactor, err := s.enabled(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+
+	// Новые коды выдаются только по коду из приложения: резервный код мог
+	// оказаться у того, кто и просит их заменить
+	err = s.checkTOTP(ctx, twoFactor, normalizeCode(code), now)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, err := s.issueRecoveryCodes(ctx, actor.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.recordAccount(ctx, actor, domain.AuditActionTwoFactorRecovery, nil, map[string]any{"recovery_codes": len(codes)})
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *twoFactorService) Disable(ctx context.Context, actor domain.Actor, code string) error {
+	if actor.Role == domain.RoleAdmin {
+		required, err := s.requirementRepo.IsRequired(ctx, port.RoleAdmin)
+		if err != nil {
+			return fmt.Errorf("error getting two-factor requirement: %w", err)
+		}
+		if required {
+			return fmt.Errorf("two-factor is required for admins: %w", domain.ErrForbidden)
+		}
+	}
+
+	twoFactor, err := s.enabled(ctx, actor)
+	if err != nil {
+		return err
+	}
+
+	err = s.checkCode(ctx, twoFactor, code, s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	err = s.twoFactorRepo.Delete(ctx, actor.ID)
+	if err != nil {
+		return fmt.Errorf("error deleting two-factor: %w", err)
+	}
+
+	return s.recordAccount(ctx, actor, domain.AuditActionTwoFactorDisable,
+		map[string]any{"two_factor": true}, map[string]any{"two_factor": false})
+}
+
+func (s *twoFactorService) Challenge(ctx context.Context, actor domain.Actor) (string, error) {
+	twoFactor, err := s.twoFactorRepo.Get(ctx, actor.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error getting two-factor: %w", err)
+	}
+	if !twoFactor.Confirmed() {
+		return "", nil
+	}
+
+	rawToken, err := newSecretToken()
+	if err != nil {
+		return "", fmt.Errorf("error generating challenge token: %w", err)
+	}
+
+	token, err := domain.CreateOneTimeToken(domain.CreateOneTimeTokenAttrs{
+		Purpose:   domain.TokenPurposeTwoFactor,
+		Actor:     actor,
+		TokenHash: hashSecretToken(rawToken),
+		TTL:       s.challengeTTL,
+	}, s.clock.Now())
+	if err != nil {
+		return "", fmt.Errorf("error creating challenge token: %w", err)
+	}
+
+	err = s.tokenRepo.Create(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("error saving challenge token: %w", err)
+	}
+
+	return rawToken, nil
+}
+
+func (s *twoFactorService) Verify(ctx context.Context, data port.VerifyTwoFactorData) (port.SessionTokens, error) {
+	now := s.clock.Now()
+	tokenHash := hashSecretToken(data.Token)
+
+	token, err := s.tokenRepo.GetActive(ctx, domain.TokenPurposeTwoFactor, tokenHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return port.SessionTokens{}, fmt.Errorf("challenge token is invalid, used or expired: %w", domain.ErrUnauthorized)
+		}
+		return port.SessionTokens{}, fmt.Errorf("error getting challenge token: %w", err)
+	}
+
+	actor := token.Actor()
+
+	// Шесть цифр перебираются быстро, поэтому неверные коды считаются
+	// неудачными попытками входа — отдельно от неверных паролей
+	attempt := port.LoginAttempt{
+		Role:  throttleRole(actor),
+		Login: "2fa:" + actor.ID.String(),
+		IP:    data.IP,
+	}
+
+	err = s.loginThrottler.Reserve(ctx, attempt)
+	if err != nil {
+		return port.SessionTokens{}, err
+	}
+
+	// Токен входа гасится до проверки кода: из параллельных запросов с одним
+	// токеном код проверит и потратит только один, а после неверного кода
+	// нужно войти заново
+	_, err = s.tokenRepo.Consume(ctx, domain.TokenPurposeTwoFactor, tokenHash, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return port.SessionTokens{}, fmt.Errorf("challenge token is invalid, used or expired: %w", domain.ErrUnauthorized)
+		}
+		return port.SessionTokens{}, fmt.Errorf("error consuming challenge token: %w", err)
+	}
+
+	twoFactor, err := s.enabled(ctx, actor)
+	if err != nil {
+		return port.SessionTokens{}, err
+	}
+
+	err = s.checkCode(ctx, twoFactor, data.Code, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) {
+			// Пароль введён верно, но код — нет: владелец токена входа ещё
+			// не доказал, что он и есть субъект, поэтому актор анонимен
+			if auditErr := s.auditLog.Record(ctx, port.AuditEntry{
+				Action:     domain.AuditActionTwoFactorFailed,
+				TargetType: accountAuditTarget(actor),
+				TargetID:   actor.ID,
+			}); auditErr != nil {
+				return port.SessionTokens{}, fmt.Errorf("error recording audit event: %w", auditErr)
+			}
+		}
+		return port.SessionTokens{}, err
+	}
+
+	err = s.loginThrottler.Succeed(ctx, attempt)
+	if err != nil {
+		return port.SessionTokens{}, fmt.Errorf("error resetting login attempts: %w", err)
+	}
+
+	// Токен входа помнит только субъекта; компанию сотрудника восстанавливаем
+	if actor.Role == domain.RoleCompany {
+		member, err := s.memberRepo.GetByID(ctx, actor.ID)
+		if err != nil {
+			return port.SessionTokens{}, fmt.Errorf("error getting company member by id: %w", err)
+		}
+		actor = member.Actor()
+	}
+
+	tokens, err := s.sessionService.Issue(ctx, actor)
+	if err != nil {
+		return port.SessionTokens{}, fmt.Errorf("error issuing session: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, signInAuditEntry(actor, true))
+	if err != nil {
+		return port.SessionTokens{}, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *twoFactorService) Satisfied(ctx context.Context, actor domain.Actor) (bool, error) {
+	if actor.Role != domain.RoleAdmin {
+		return true, nil
+	}
+
+	required, err := s.requirementRepo.IsRequired(ctx, port.RoleAdmin)
+	if err != nil {
+		return false, fmt.Errorf("error getting two-factor requirement: %w", err)
+	}
+	if !required {
+		return true, nil
+	}
+
+	twoFactor, err := s.twoFactorRepo.Get(ctx, actor.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error getting two-factor: %w", err)
+	}
+
+	return twoFactor.Confirmed(), nil
+}
+
+func (s *twoFactorService) AdminRequired(ctx context.Context, actor domain.Actor) (bool, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionSettingsManage); err != nil {
+		return false, err
+	}
+
+	required, err := s.requirementRepo.IsRequired(ctx, port.RoleAdmin)
+	if err != nil {
+		return false, fmt.Errorf("error getting two-factor requirement: %w", err)
+	}
+
+	return required, nil
+}
+
+func (s *twoFactorService) SetAdminRequired(ctx context.Context, actor domain.Actor, required bool) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionSettingsManage); err != nil {
+		return err
+	}
+
+	// Иначе включивший требование администратор сразу потеряет доступ
+	if required {
+		if _, err := s.enabled(ctx, actor); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return fmt.Errorf("%w: enable two-factor before requiring it", domain.ErrConflict)
+			}
+			return err
+		}
+	}
+
+	previous, err := s.requirementRepo.IsRequired(ctx, port.RoleAdmin)
+	if err != nil {
+		return fmt.Errorf("error getting two-factor requirement: %w", err)
+	}
+
+	err = s.requirementRepo.SetRequired(ctx, port.RoleAdmin, required, actor.ID, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error setting two-factor requirement: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionSettingsChange,
+		TargetType: domain.AuditTargetSettings,
+		Before:     map[string]any{"admin_two_factor_required": previous},
+		After:      map[string]any{"admin_two_factor_required": required},
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+// recordAccount пишет в журнал действие актора над собственной 2FA.
+func (s *twoFactorService) recordAccount(
+	ctx context.Context,
+	actor domain.Actor,
+	action domain.AuditAction,
+	before, after map[string]any,
+) error {
+	err := s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: accountAuditTarget(actor),
+		TargetID:   actor.ID,
+		Before:     before,
+		After:      after,
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
+}
+
+// enabled возвращает подтверждённую 2FA или ErrNotFound, если она не включена.
+func (s *twoFactorService) enabled(ctx context.Context, actor domain.Actor) (*domain.TwoFactor, error) {
+	twoFactor, err := s.twoFactorRepo.Get(ctx, actor.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("two-factor is not enabled: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("error getting two-factor: %w", err)
+	}
+	if !twoFactor.Confirmed() {
+		return nil, fmt.Errorf("two-factor is not enabled: %w", domain.ErrNotFound)
+	}
+
+	return twoFactor, nil
+}
+
+// checkCode принимает код из приложения или резервный код.
+func (s *twoFactorService) checkCode(ctx context.Context, twoFactor *domain.TwoFactor, code string, at time.Time) error {
+	code = normalizeCode(code)
+	if isTOTPCode(code) {
+		return s.checkTOTP(ctx, twoFactor, code, at)
+	}
+
+	err := s.twoFactorRepo.UseRecoveryCode(ctx, twoFactor.Immutable().SubjectID, hashSecretToken(code), at)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: invalid recovery code", domain.ErrUnauthorized)
+		}
+		return fmt.Errorf("error using recovery code: %w", err)
+	}
+
+	return nil
+}
+
+func (s *twoFactorService) checkTOTP(ctx context.Context, twoFactor *domain.TwoFactor, code string, at time.Time) error {
+	step, ok := s.totp.Verify(twoFactor.Immutable().Secret, code, at)
+	if !ok {
+		return fmt.Errorf("%w: invalid two-factor code", domain.ErrUnauthorized)
+	}
+
+	twoFactor, err := twoFactor.UseStep(step, at)
+	if err != nil {
+		return err
+	}
+
+	// Шаг сдвигается условным UPDATE: из двух одновременных запросов с
+	// одним кодом проходит только один
+	err = s.twoFactorRepo.UseStep(ctx, twoFactor.Immutable().SubjectID, step, at)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: two-factor code already used", domain.ErrUnauthorized)
+		}
+		return fmt.Errorf("error using two-factor step: %w", err)
+	}
+
+	return nil
+}
+
+// issueRecoveryCodes заменяет резервные коды новыми и возвращает их в
+// открытом виде; в хранилище попадают только хеши.
+func (s *twoFactorService) issueRecoveryCodes(ctx context.Context, subjectID uuid.UUID, at time.Time) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := newRecoveryCode()
+		if err != nil {
+			return nil, fmt.Errorf("error generating recovery code: %w", err)
+		}
+		codes[i] = code
+		hashes[i] = hashSecretToken(normalizeCode(code))
+	}
+
+	err := s.twoFactorRepo.ReplaceRecoveryCodes(ctx, subjectID, hashes, at)
+	if err != nil {
+		return nil, fmt.Errorf("error saving recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (s *twoFactorService) accountName(ctx context.Context, actor domain.Actor) (string, error) {
+	switch actor.Role {
+	case domain.RoleCompany:
+		member, err := s.memberRepo.GetByID(ctx, actor.ID)
+		if err != nil {
+			return "", fmt.Errorf("error getting company member by id: %w", err)
+		}
+		return member.Immutable().Login, nil
+	case domain.RoleAdmin:
+		admin, err := s.adminRepo.GetByID(ctx, actor.ID)
+		if err != nil {
+			return "", fmt.Errorf("error getting admin by id: %w", err)
+		}
+		return admin.Immutable().Login, nil
+	default:
+		return "", fmt.Errorf("two-factor is not supported for role %s: %w", actor.Role, domain.ErrForbidden)
+	}
+}
+
+// newRecoveryCode возвращает код вида xxxxx-xxxxx (50 бит) в нижнем регистре.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 10)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeCode убирает пробелы и дефисы, которые пользователи вводят
+// вместе с кодом, и приводит его к нижнему регистру.
+func normalizeCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func throttleRole(actor domain.Actor) port.Role {
+	switch actor.Role {
+	case domain.RoleCompany:
+		return port.RoleCompany
+	case domain.RoleUniversity:
+		return port.RoleUniversity
+	default:
+		return port.RoleAdmin
+	}
+}
@@ -13,26 +13,38 @@ import (
 type universityService struct {
 	universityRepo  port.UniversityRepository
 	passwordService port.PasswordService
-	tokenService    port.TokenService
+	passwordPolicy  port.PasswordPolicy
+	sessionService  port.SessionService
+	loginThrottler  port.LoginThrottler
+	authorizer      port.Authorizer
+	auditLog        port.AuditLog
 	clock           port.Clock
 }
 
 func NewUniversityService(
 	universityRepo port.UniversityRepository,
 	passwordService port.PasswordService,
-	tokenService port.TokenService,
+	passwordPolicy port.PasswordPolicy,
+	sessionService port.SessionService,
+	loginThrottler port.LoginThrottler,
+	authorizer port.Authorizer,
+	auditLog port.AuditLog,
 	clock port.Clock,
 ) *universityService {
 	return &universityService{
 		universityRepo,
 		passwordService,
-		tokenService,
+		passwordPolicy,
+		sessionService,
+		loginThrottler,
+		authorizer,
+		auditLog,
 		clock,
 	}
 }
 
 func (s *universityService) SignUp(ctx context.Context, data port.SignUpUniversityData) (*port.UniversityWithTokenResult, error) {
-	err := s.validatePassword(data.Password)
+	err := s.passwordPolicy.Validate(data.Password, data.Login, data.Title)
 	if err != nil {
 		return nil, fmt.Errorf("error validating password: %w", err)
 	}
@@ -47,6 +59,7 @@ func (s *universityService) SignUp(ctx context.Context, data port.SignUpUniversi
 		Login:        data.Login,
 		PasswordHash: passwordHash,
 		INN:          data.INN,
+		Email:        data.Email,
 	}, s.clock.Now())
 	if err != nil {
 		return nil, fmt.Errorf("error creating university: %w", err)
@@ -58,32 +71,46 @@ func (s *universityService) SignUp(ctx context.Context, data port.SignUpUniversi
 	}
 
 	universityImmutable := university.Immutable()
-
-	token, err := s.tokenService.Generate(port.TokenPayload{
-		Sub:  universityImmutable.ID,
-		Role: port.RoleUniversity,
+	actor := domain.Actor{ID: universityImmutable.ID, Role: domain.RoleUniversity}
+
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionSignUp,
+		TargetType: domain.AuditTargetUniversity,
+		TargetID:   universityImmutable.ID,
+		After:      universityAuditFields(universityImmutable),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("error generating token: %w", err)
+		return nil, fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	tokens, err := s.sessionService.Issue(ctx, actor)
+	if err != nil {
+		return nil, fmt.Errorf("error issuing session: %w", err)
 	}
 
 	universityWithTokenResult := &port.UniversityWithTokenResult{
-		UniversityResult: port.UniversityResult{
-			Login: universityImmutable.Login,
-			Title: universityImmutable.Title,
-			INN:   universityImmutable.INN,
-		},
-		Token: token,
+		UniversityResult: newUniversityResult(universityImmutable),
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
 	}
 
 	return universityWithTokenResult, nil
 }
 
 func (s *universityService) SignIn(ctx context.Context, data port.SignInUniversityData) (*port.UniversityWithTokenResult, error) {
+	attempt := port.LoginAttempt{Role: port.RoleUniversity, Login: data.Login, IP: data.IP}
+
+	err := s.loginThrottler.Reserve(ctx, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("error reserving login attempt: %w", err)
+	}
+
 	university, err := s.universityRepo.GetByLogin(ctx, data.Login)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			return nil, domain.ErrUnauthorized
+			s.passwordService.Check(data.Password, dummyPasswordHash)
+			return nil, s.failSignIn(ctx, attempt, uuid.Nil)
 		}
 		return nil, fmt.Errorf("error getting university by login: %w", err)
 	}
@@ -91,32 +118,62 @@ func (s *universityService) SignIn(ctx context.Context, data port.SignInUniversi
 	universityImmutable := university.Immutable()
 
 	if !s.passwordService.Check(data.Password, universityImmutable.PasswordHash) {
-		return nil, domain.ErrUnauthorized
+		return nil, s.failSignIn(ctx, attempt, universityImmutable.ID)
 	}
 
-	token, err := s.tokenService.Generate(port.TokenPayload{
-		Sub:  universityImmutable.ID,
-		Role: port.RoleUniversity,
-	})
+	err = s.loginThrottler.Succeed(ctx, attempt)
+	if err != nil {
+		return nil, fmt.Errorf("error resetting login attempts: %w", err)
+	}
+
+	if s.passwordService.NeedsRehash(universityImmutable.PasswordHash) {
+		err = s.rehashPassword(ctx, university, data.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	actor := domain.Actor{ID: universityImmutable.ID, Role: domain.RoleUniversity}
+
+	tokens, err := s.sessionService.Issue(ctx, actor)
 	if err != nil {
-		return nil, fmt.Errorf("error generating token: %w", err)
+		return nil, fmt.Errorf("error issuing session: %w", err)
+	}
+
+	err = s.auditLog.Record(ctx, signInAuditEntry(actor, false))
+	if err != nil {
+		return nil, fmt.Errorf("error recording audit event: %w", err)
 	}
 
 	universityWithTokenResult := &port.UniversityWithTokenResult{
-		UniversityResult: port.UniversityResult{
-			Login: universityImmutable.Login,
-			Title: universityImmutable.Title,
-			INN:   universityImmutable.INN,
-		},
-		Token: token,
+		UniversityResult: newUniversityResult(universityImmutable),
+		Token:            tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
 	}
 
 	return universityWithTokenResult, nil
 }
 
+func (s *universityService) GetProfile(ctx context.Context, actor domain.Actor) (*port.UniversityResult, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityViewProfile); err != nil {
+		return nil, err
+	}
+
+	university, err := s.universityRepo.GetByID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting university by id: %w", err)
+	}
+
+	result := newUniversityResult(university.Immutable())
+	return &result, nil
+}
+
 func (s *universityService) ChangePassword(ctx context.Context, actor domain.Actor, data port.ChangeUniversityPasswordData) error {
-	if actor.Role != domain.RoleUniversity {
-		return fmt.Errorf("university role required: %w", domain.ErrForbidden)
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityChangePassword); err != nil {
+		return err
+	}
+	if actor.ViaAPIKey() {
+		return fmt.Errorf("password cannot be changed with an api key: %w", domain.ErrForbidden)
 	}
 
 	university, err := s.universityRepo.GetByID(ctx, actor.ID)
@@ -124,11 +181,13 @@ func (s *universityService) ChangePassword(ctx context.Context, actor domain.Act
 		return fmt.Errorf("error getting university by id: %w", err)
 	}
 
-	if !s.passwordService.Check(data.CurrentPassword, university.Immutable().PasswordHash) {
+	universityImmutable := university.Immutable()
+
+	if !s.passwordService.Check(data.CurrentPassword, universityImmutable.PasswordHash) {
 		return domain.ErrUnauthorized
 	}
 
-	err = s.validatePassword(data.NewPassword)
+	err = s.passwordPolicy.Validate(data.NewPassword, universityImmutable.Login, universityImmutable.Title)
 	if err != nil {
 		return fmt.Errorf("error validating password: %w", err)
 	}
@@ -148,20 +207,128 @@ func (s *universityService) ChangePassword(ctx context.Context, actor domain.Act
 		return fmt.Errorf("error saving university: %w", err)
 	}
 
+	err = s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     domain.AuditActionCredentialsChange,
+		TargetType: domain.AuditTargetUniversity,
+		TargetID:   actor.ID,
+		Before:     credentialsAuditFields(universityImmutable.Login, false),
+		After:      credentialsAuditFields(universityImmutable.Login, true),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
 	return nil
 }
 
 func (s *universityService) Confirm(ctx context.Context, actor domain.Actor, universityID uuid.UUID) error {
-	if actor.Role != domain.RoleAdmin {
-		return fmt.Errorf("admin role required: %w", domain.ErrForbidden)
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityApprove); err != nil {
+		return err
 	}
 
 	university, err := s.universityRepo.GetByID(ctx, universityID)
 	if err != nil {
 		return fmt.Errorf("error getting university by id: %w", err)
 	}
+	before := universityAuditFields(university.Immutable())
 
-	university.Confirm(s.clock.Now())
+	err = university.Confirm(s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error confirming university: %w", err)
+	}
+
+	err = s.universityRepo.Save(ctx, university)
+	if err != nil {
+		return fmt.Errorf("error saving university: %w", err)
+	}
+
+	return s.recordModeration(ctx, actor, domain.AuditActionUniversityConfirm, university, before)
+}
+
+func (s *universityService) Reject(ctx context.Context, actor domain.Actor, universityID uuid.UUID, reason string) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityApprove); err != nil {
+		return err
+	}
+
+	university, err := s.universityRepo.GetByID(ctx, universityID)
+	if err != nil {
+		return fmt.Errorf("error getting university by id: %w", err)
+	}
+	before := universityAuditFields(university.Immutable())
+
+	err = university.Reject(reason, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error rejecting university: %w", err)
+	}
+
+	err = s.universityRepo.Save(ctx, university)
+	if err != nil {
+		return fmt.Errorf("error saving university: %w", err)
+	}
+
+	return s.recordModeration(ctx, actor, domain.AuditActionUniversityReject, university, before)
+}
+
+func (s *universityService) RevokeConfirmation(ctx context.Context, actor domain.Actor, universityID uuid.UUID, reason string) error {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityApprove); err != nil {
+		return err
+	}
+
+	university, err := s.universityRepo.GetByID(ctx, universityID)
+	if err != nil {
+		return fmt.Errorf("error getting university by id: %w", err)
+	}
+	before := universityAuditFields(university.Immutable())
+
+	err = university.RevokeConfirmation(reason, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error revoking university confirmation: %w", err)
+	}
+
+	err = s.universityRepo.Save(ctx, university)
+	if err != nil {
+		return fmt.Errorf("error saving university: %w", err)
+	}
+
+	// Как и у компании, отзыв подтверждения завершает ранее выданные сессии
+	err = s.sessionService.RevokeSubject(ctx, universityID)
+	if err != nil {
+		return fmt.Errorf("error revoking university sessions: %w", err)
+	}
+
+	return s.recordModeration(ctx, actor, domain.AuditActionUniversityRevoke, university, before)
+}
+
+func (s *universityService) List(ctx context.Context, actor domain.Actor, f domain.UniversityFilter) (port.Paginated[port.UniversityResult], error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionUniversityList); err != nil {
+		return port.Paginated[port.UniversityResult]{}, err
+	}
+
+	universities, total, err := s.universityRepo.List(ctx, f)
+	if err != nil {
+		return port.Paginated[port.UniversityResult]{}, fmt.Errorf("error listing universities: %w", err)
+	}
+
+	results := make([]port.UniversityResult, 0, len(universities))
+	for _, university := range universities {
+		results = append(results, newUniversityResult(university.Immutable()))
+	}
+
+	return port.NewPaginated(results, f.Page, total), nil
+}
+
+// rehashPassword пересчитывает хеш текущим алгоритмом, пока известен пароль.
+func (s *universityService) rehashPassword(ctx context.Context, university *domain.University, password string) error {
+	passwordHash, err := s.passwordService.Hash(password)
+	if err != nil {
+		return fmt.Errorf("error rehashing password: %w", err)
+	}
+
+	err = university.SetPasswordHash(passwordHash, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("error setting password hash: %w", err)
+	}
 
 	err = s.universityRepo.Save(ctx, university)
 	if err != nil {
@@ -171,10 +338,63 @@ func (s *universityService) Confirm(ctx context.Context, actor domain.Actor, uni
 	return nil
 }
 
-func (s *universityService) validatePassword(password string) error {
-	if len(password) < 8 || len(password) > 64 {
-		return fmt.Errorf("%w: invalid password length", domain.ErrInvariantViolated)
+// recordModeration пишет в журнал переход модерации вуза; before — поля
+// до перехода, university уже изменён.
+func (s *universityService) recordModeration(
+	ctx context.Context,
+	actor domain.Actor,
+	action domain.AuditAction,
+	university *domain.University,
+	before map[string]any,
+) error {
+	ui := university.Immutable()
+
+	err := s.auditLog.Record(ctx, port.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: domain.AuditTargetUniversity,
+		TargetID:   ui.ID,
+		Before:     before,
+		After:      universityAuditFields(ui),
+	})
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
 	}
 
 	return nil
 }
+
+// failSignIn записывает неудачу в журнал: счётчик попыток уже увеличен
+// в Reserve. universityID пуст, если логин не найден.
+func (s *universityService) failSignIn(ctx context.Context, attempt port.LoginAttempt, universityID uuid.UUID) error {
+	err := s.auditLog.Record(ctx, signInFailedAuditEntry(domain.AuditTargetUniversity, universityID, attempt.Login))
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return domain.ErrUnauthorized
+}
+
+// universityAuditFields — поля вуза, изменения которых попадают в журнал.
+func universityAuditFields(ui domain.UniversityImmutable) map[string]any {
+	return map[string]any{
+		"title":            ui.Title,
+		"login":            ui.Login,
+		"email":            ui.Email,
+		"confirmed":        ui.Confirmed,
+		"rejection_reason": ui.RejectionReason,
+	}
+}
+
+func newUniversityResult(universityImmutable domain.UniversityImmutable) port.UniversityResult {
+	return port.UniversityResult{
+		ID:              universityImmutable.ID,
+		Login:           universityImmutable.Login,
+		Title:           universityImmutable.Title,
+		INN:             universityImmutable.INN,
+		Email:           universityImmutable.Email,
+		EmailVerified:   universityImmutable.EmailVerified,
+		Confirmed:       universityImmutable.Confirmed,
+		RejectionReason: universityImmutable.RejectionReason,
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hr-platform-mosprom/internal/core/application/port"
@@ -10,28 +11,38 @@ import (
 )
 
 type vacancyService struct {
-	repo    port.VacancyRepository
-	company port.CompanyRepository
-	clock   port.Clock
+	repo       port.VacancyRepository
+	company    port.CompanyRepository
+	authorizer port.Authorizer
+	auditLog   port.AuditLog
+	clock      port.Clock
 }
 
-func NewVacancyService(r port.VacancyRepository, cr port.CompanyRepository, c port.Clock) *vacancyService {
-	return &vacancyService{repo: r, company: cr, clock: c}
+func NewVacancyService(
+	r port.VacancyRepository,
+	cr port.CompanyRepository,
+	a port.Authorizer,
+	al port.AuditLog,
+	c port.Clock,
+) *vacancyService {
+	return &vacancyService{repo: r, company: cr, authorizer: a, auditLog: al, clock: c}
 }
 
-func (s *vacancyService) Create(ctx context.Context, in port.CreateVacancyInput) (*domain.Vacancy, error) {
-	co, err := s.company.GetByID(ctx, in.CompanyID)
-	if err != nil || co == nil {
-		return nil, fmt.Errorf("company not found")
+func (s *vacancyService) Create(ctx context.Context, actor domain.Actor, in port.CreateVacancyInput) (*domain.Vacancy, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionVacancyCreate); err != nil {
+		return nil, err
+	}
+
+	co, err := s.company.GetByID(ctx, actor.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
 	}
 	if !co.Immutable().Approved {
-		return nil, fmt.Errorf("company not approved")
+		return nil, fmt.Errorf("company is not approved: %w", domain.ErrForbidden)
 	}
 
-	now := s.clock.Now()
-
 	v, err := domain.CreateVacancy(domain.CreateVacancyAttrs{
-		CompanyID:        in.CompanyID,
+		CompanyID:        actor.CompanyID,
 		Title:            in.Title,
 		Description:      in.Description,
 		Contacts:         in.Contacts,
@@ -44,21 +55,27 @@ func (s *vacancyService) Create(ctx context.Context, in port.CreateVacancyInput)
 		Experience:       in.Experience,
 		Education:        in.Education,
 		Location:         in.Location,
-	}, now)
+	}, s.clock.Now())
 	if err != nil {
+		return nil, fmt.Errorf("error creating vacancy: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, v); err != nil {
+		return nil, fmt.Errorf("error saving vacancy: %w", err)
+	}
+
+	if err := s.record(ctx, actor, domain.AuditActionVacancyCreate, nil, v); err != nil {
 		return nil, err
 	}
-	return v, s.repo.Save(ctx, v)
+
+	return v, nil
 }
 
-func (s *vacancyService) Update(ctx context.Context, id uuid.UUID, in port.UpdateVacancyInput) (*domain.Vacancy, error) {
-	v, err := s.repo.ByID(ctx, id)
+func (s *vacancyService) Update(ctx context.Context, actor domain.Actor, id uuid.UUID, in port.UpdateVacancyInput) (*domain.Vacancy, error) {
+	v, err := s.getOwned(ctx, actor, id, domain.PermissionVacancyEdit)
 	if err != nil {
 		return nil, err
 	}
-
-	now := s.clock.Now()
-
 	v2, err := v.Update(domain.CreateVacancyAttrs{
 		Title:            in.Title,
 		Description:      in.Description,
@@ -72,67 +89,268 @@ func (s *vacancyService) Update(ctx context.Context, id uuid.UUID, in port.Updat
 		Experience:       in.Experience,
 		Education:        in.Education,
 		Location:         in.Location,
-	}, now)
+	}, s.clock.Now())
 	if err != nil {
+		return nil, fmt.Errorf("error updating vacancy: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, v2); err != nil {
+		return nil, fmt.Errorf("error saving vacancy: %w", err)
+	}
+
+	if err := s.record(ctx, actor, domain.AuditActionVacancyUpdate, v, v2); err != nil {
 		return nil, err
 	}
 
-	if in.IsActive != nil {
-		if *in.IsActive {
-			v2, err = v2.Activate(now)
-		} else {
-			v2, err = v2.Deactivate(now)
-		}
-		if err != nil {
-			return nil, err
-		}
+	return v2, nil
+}
+
+func (s *vacancyService) Get(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error) {
+	return s.getOwned(ctx, actor, id, domain.PermissionVacancyView)
+}
+
+func (s *vacancyService) ListByCompany(ctx context.Context, actor domain.Actor, page domain.PageRequest) (port.Paginated[*domain.Vacancy], error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionVacancyView); err != nil {
+		return port.Paginated[*domain.Vacancy]{}, err
+	}
+
+	vacancies, total, err := s.repo.ByCompany(ctx, actor.CompanyID, page)
+	if err != nil {
+		return port.Paginated[*domain.Vacancy]{}, fmt.Errorf("error listing vacancies by company: %w", err)
 	}
 
-	return v2, s.repo.Save(ctx, v2)
+	return port.NewPaginated(vacancies, page, total), nil
 }
 
-func (s *vacancyService) Get(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error) {
-	return s.repo.ByID(ctx, id)
+func (s *vacancyService) Submit(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error) {
+	v, err := s.getOwned(ctx, actor, id, domain.PermissionVacancyPublish)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, actor, v, domain.AuditActionVacancySubmit, (*domain.Vacancy).Submit)
 }
 
-func (s *vacancyService) ListByCompany(ctx context.Context, companyID uuid.UUID, limit, offset int) ([]*domain.Vacancy, error) {
-	return s.repo.ByCompany(ctx, companyID, limit, offset)
+func (s *vacancyService) Archive(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error) {
+	v, err := s.getOwned(ctx, actor, id, domain.PermissionVacancyPublish)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, actor, v, domain.AuditActionVacancyArchive, (*domain.Vacancy).Archive)
 }
 
-func (s *vacancyService) ListAll(ctx context.Context, limit, offset int) ([]*domain.Vacancy, error) {
-	return s.repo.All(ctx, limit, offset)
+func (s *vacancyService) Restore(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error) {
+	v, err := s.getOwned(ctx, actor, id, domain.PermissionVacancyPublish)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.transition(ctx, actor, v, domain.AuditActionVacancyRestore, (*domain.Vacancy).Restore)
 }
 
-func (s *vacancyService) Search(ctx context.Context, f domain.VacancyFilter) ([]*domain.Vacancy, error) {
-	return s.repo.Search(ctx, f)
+// ListForModeration по умолчанию отдаёт очередь вакансий на проверке.
+func (s *vacancyService) ListForModeration(ctx context.Context, actor domain.Actor, f domain.VacancyFilter) (port.Paginated[*domain.Vacancy], error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionVacancyModerate); err != nil {
+		return port.Paginated[*domain.Vacancy]{}, err
+	}
+
+	if f.State == nil {
+		onReview := domain.VacancyOnReview
+		f.State = &onReview
+	}
+
+	vacancies, total, err := s.repo.Search(ctx, f)
+	if err != nil {
+		return port.Paginated[*domain.Vacancy]{}, fmt.Errorf("error searching vacancies: %w", err)
+	}
+
+	return port.NewPaginated(vacancies, f.Page, total), nil
 }
 
-func (s *vacancyService) Activate(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error) {
-	v, err := s.repo.ByID(ctx, id)
+func (s *vacancyService) Publish(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error) {
+	v, err := s.getForModeration(ctx, actor, id)
 	if err != nil {
 		return nil, err
 	}
-	now := s.clock.Now()
-	v2, err := v.Activate(now)
+
+	return s.transition(ctx, actor, v, domain.AuditActionVacancyPublish, (*domain.Vacancy).Publish)
+}
+
+func (s *vacancyService) Reject(ctx context.Context, actor domain.Actor, id uuid.UUID, comment string) (*domain.Vacancy, error) {
+	v, err := s.getForModeration(ctx, actor, id)
 	if err != nil {
 		return nil, err
 	}
-	return v2, s.repo.Save(ctx, v2)
+
+	reject := func(v *domain.Vacancy, at time.Time) (*domain.Vacancy, error) {
+		return v.Reject(comment, at)
+	}
+
+	return s.transition(ctx, actor, v, domain.AuditActionVacancyReject, reject)
 }
 
-func (s *vacancyService) Deactivate(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error) {
+func (s *vacancyService) GetPublished(ctx context.Context, id uuid.UUID) (*domain.Vacancy, error) {
 	v, err := s.repo.ByID(ctx, id)
 	if err != nil {
+		return nil, fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+
+	vi := v.Immutable()
+	if vi.State != domain.VacancyPublished {
+		return nil, fmt.Errorf("vacancy is not published: %w", domain.ErrNotFound)
+	}
+
+	co, err := s.company.GetByID(ctx, vi.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting company by id: %w", err)
+	}
+	if !co.Immutable().Approved {
+		return nil, fmt.Errorf("company is not approved: %w", domain.ErrNotFound)
+	}
+
+	return v, nil
+}
+
+func (s *vacancyService) SearchPublished(ctx context.Context, f domain.VacancyFilter) (port.Paginated[*domain.Vacancy], error) {
+	published, approved := domain.VacancyPublished, true
+	f.State = &published
+	f.CompanyApproved = &approved
+
+	vacancies, total, err := s.repo.Search(ctx, f)
+	if err != nil {
+		return port.Paginated[*domain.Vacancy]{}, fmt.Errorf("error searching vacancies: %w", err)
+	}
+
+	return port.NewPaginated(vacancies, f.Page, total), nil
+}
+
+func (s *vacancyService) FeedPublished(ctx context.Context, f domain.VacancyFilter, after *domain.Cursor, limit int) (port.CursorPaginated[*domain.Vacancy], error) {
+	published, approved := domain.VacancyPublished, true
+	f.State = &published
+	f.CompanyApproved = &approved
+
+	vacancies, next, err := s.repo.SearchByCursor(ctx, f, after, limit)
+	if err != nil {
+		return port.CursorPaginated[*domain.Vacancy]{}, fmt.Errorf("error searching vacancies by cursor: %w", err)
+	}
+
+	return port.CursorPaginated[*domain.Vacancy]{Data: vacancies, NextCursor: next}, nil
+}
+
+func (s *vacancyService) ExportPublished(ctx context.Context, actor domain.Actor, f domain.VacancyFilter, after *domain.Cursor, limit int) (port.CursorPaginated[*domain.Vacancy], error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionCatalogExport); err != nil {
+		return port.CursorPaginated[*domain.Vacancy]{}, err
+	}
+
+	return s.FeedPublished(ctx, f, after, limit)
+}
+
+// getOwned возвращает вакансию, только если у actor есть право permission
+// и вакансия принадлежит его компании.
+func (s *vacancyService) getOwned(ctx context.Context, actor domain.Actor, id uuid.UUID, permission domain.Permission) (*domain.Vacancy, error) {
+	if err := s.authorizer.Authorize(ctx, actor, permission); err != nil {
+		return nil, err
+	}
+
+	v, err := s.repo.ByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+
+	if v.Immutable().CompanyID != actor.CompanyID {
+		return nil, fmt.Errorf("vacancy belongs to another company: %w", domain.ErrForbidden)
+	}
+
+	return v, nil
+}
+
+// getForModeration возвращает вакансию любой компании, если у actor есть
+// право на модерацию.
+func (s *vacancyService) getForModeration(ctx context.Context, actor domain.Actor, id uuid.UUID) (*domain.Vacancy, error) {
+	if err := s.authorizer.Authorize(ctx, actor, domain.PermissionVacancyModerate); err != nil {
 		return nil, err
 	}
-	now := s.clock.Now()
-	v2, err := v.Deactivate(now)
+
+	v, err := s.repo.ByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("error getting vacancy by id: %w", err)
+	}
+
+	return v, nil
+}
+
+// transition переводит вакансию в новое состояние, сохраняет её и пишет
+// переход в журнал. Допустимость перехода проверяет домен.
+func (s *vacancyService) transition(
+	ctx context.Context,
+	actor domain.Actor,
+	v *domain.Vacancy,
+	action domain.AuditAction,
+	move func(*domain.Vacancy, time.Time) (*domain.Vacancy, error),
+) (*domain.Vacancy, error) {
+	v2, err := move(v, s.clock.Now())
 	if err != nil {
+		return nil, fmt.Errorf("error changing vacancy state: %w", err)
+	}
+
+	if err := s.repo.Save(ctx, v2); err != nil {
+		return nil, fmt.Errorf("error saving vacancy: %w", err)
+	}
+
+	if err := s.record(ctx, actor, action, v, v2); err != nil {
 		return nil, err
 	}
-	return v2, s.repo.Save(ctx, v2)
+
+	return v2, nil
+}
+
+// record пишет в журнал изменение вакансии; before пуст при создании.
+func (s *vacancyService) record(ctx context.Context, actor domain.Actor, action domain.AuditAction, before, after *domain.Vacancy) error {
+	entry := port.AuditEntry{
+		Actor:      actor,
+		Action:     action,
+		TargetType: domain.AuditTargetVacancy,
+		TargetID:   after.Immutable().ID,
+		After:      vacancyAuditFields(after.Immutable()),
+	}
+	if before != nil {
+		entry.Before = vacancyAuditFields(before.Immutable())
+	}
+
+	if err := s.auditLog.Record(ctx, entry); err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+
+	return nil
 }
 
-func (s *vacancyService) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.repo.Delete(ctx, id)
+// vacancyAuditFields — поля вакансии, изменения которых попадают в журнал.
+func vacancyAuditFields(vi domain.VacancyImmutable) map[string]any {
+	fields := map[string]any{
+		"title":              vi.Title,
+		"description":        vi.Description,
+		"contacts":           vi.Contacts,
+		"requirements":       vi.Requirements,
+		"responsibilities":   vi.Responsibilities,
+		"conditions":         vi.Conditions,
+		"employment":         vi.Employment,
+		"schedule":           vi.Schedule,
+		"experience":         vi.Experience,
+		"education":          vi.Education,
+		"location":           vi.Location,
+		"state":              string(vi.State),
+		"moderation_comment": vi.ModerationComment,
+		"salary":             nil,
+	}
+	if vi.Salary != nil {
+		fields["salary"] = map[string]any{
+			"min":      vi.Salary.Min,
+			"max":      vi.Salary.Max,
+			"currency": vi.Salary.Currency,
+			"gross":    vi.Salary.Gross,
+		}
+	}
+
+	return fields
 }
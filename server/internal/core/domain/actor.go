@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+
 	"github.com/google/uuid"
 )
 
@@ -15,4 +17,26 @@ const (
 type Actor struct {
 	ID   uuid.UUID
 	Role role
+	// Для роли компании ID — сотрудник (CompanyMember), а CompanyID — его
+	// компания; для остальных ролей пуст
+	CompanyID uuid.UUID
+	// Для запросов по API-ключу — ключ и его области: такой актор получает
+	// только права из Scopes
+	APIKeyID uuid.UUID
+	Scopes   []Permission
+}
+
+// ViaAPIKey сообщает, что актор пришёл с API-ключом, а не с сессией.
+func (a Actor) ViaAPIKey() bool {
+	return a.APIKeyID != uuid.Nil
+}
+
+// ParseRole восстанавливает роль из строки, например при чтении из БД.
+func ParseRole(s string) (role, error) {
+	switch r := role(s); r {
+	case RoleCompany, RoleUniversity, RoleAdmin:
+		return r, nil
+	default:
+		return "", fmt.Errorf("%w: unknown role %q", ErrInvariantViolated, s)
+	}
 }
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Admin struct {
+		id           uuid.UUID
+		login        string
+		passwordHash string
+		createdAt    time.Time
+		updatedAt    time.Time
+	}
+
+	AdminImmutable struct {
+		ID           uuid.UUID
+		Login        string
+		PasswordHash string
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+	}
+
+	CreateAdminAttrs struct {
+		Login        string
+		PasswordHash string
+	}
+)
+
+func (a *Admin) Immutable() AdminImmutable {
+	return AdminImmutable{
+		ID:           a.id,
+		Login:        a.login,
+		PasswordHash: a.passwordHash,
+		CreatedAt:    a.createdAt,
+		UpdatedAt:    a.updatedAt,
+	}
+}
+
+func (a *Admin) checkInvariants() error {
+	if a.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if l := len(a.login); l < 4 || l > 128 {
+		return fmt.Errorf("%w: invalid login length", ErrInvariantViolated)
+	}
+	if len(a.passwordHash) < 10 {
+		return fmt.Errorf("%w: weak password hash", ErrInvariantViolated)
+	}
+	if a.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	if a.updatedAt.IsZero() {
+		return fmt.Errorf("%w: zero updation time", ErrInvariantViolated)
+	}
+	if a.createdAt.After(a.updatedAt) {
+		return fmt.Errorf("%w: creation time is after updation time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateAdmin(attrs CreateAdminAttrs, at time.Time) (*Admin, error) {
+	imm := AdminImmutable{
+		ID:           uuid.New(),
+		Login:        attrs.Login,
+		PasswordHash: attrs.PasswordHash,
+		CreatedAt:    at,
+		UpdatedAt:    at,
+	}
+	return ReconstructAdmin(imm)
+}
+
+func ReconstructAdmin(immutable AdminImmutable) (*Admin, error) {
+	a := &Admin{
+		id:           immutable.ID,
+		login:        immutable.Login,
+		passwordHash: immutable.PasswordHash,
+		createdAt:    immutable.CreatedAt,
+		updatedAt:    immutable.UpdatedAt,
+	}
+	return a, a.checkInvariants()
+}
+
+func (a *Admin) ChangePasswordHash(passwordHash string, at time.Time) (*Admin, error) {
+	imm := a.Immutable()
+	imm.PasswordHash = passwordHash
+	imm.UpdatedAt = at
+	return ReconstructAdmin(imm)
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyScopes — права, которые можно выдать API-ключу. Управлять ключами
+// по ключу нельзя: иначе утёкший ключ выпускал бы новые.
+func APIKeyScopes() []Permission {
+	return []Permission{
+		PermissionUniversityViewProfile,
+		PermissionCatalogExport,
+	}
+}
+
+type (
+	// APIKey — ключ интеграции вуза. Префикс хранится открыто и по нему ключ
+	// находится, секрет — только хешем.
+	APIKey struct {
+		id           uuid.UUID
+		universityID uuid.UUID
+		name         string
+		prefix       string
+		secretHash   string
+		scopes       []Permission
+		lastUsedAt   *time.Time
+		createdAt    time.Time
+		revokedAt    *time.Time
+	}
+
+	APIKeyImmutable struct {
+		ID           uuid.UUID
+		UniversityID uuid.UUID
+		Name         string
+		Prefix       string
+		SecretHash   string
+		Scopes       []Permission
+		LastUsedAt   *time.Time
+		CreatedAt    time.Time
+		RevokedAt    *time.Time
+	}
+
+	CreateAPIKeyAttrs struct {
+		UniversityID uuid.UUID
+		Name         string
+		Prefix       string
+		SecretHash   string
+		Scopes       []Permission
+	}
+)
+
+func (k *APIKey) Immutable() APIKeyImmutable {
+	return APIKeyImmutable{
+		ID:           k.id,
+		UniversityID: k.universityID,
+		Name:         k.name,
+		Prefix:       k.prefix,
+		SecretHash:   k.secretHash,
+		Scopes:       slices.Clone(k.scopes),
+		LastUsedAt:   k.lastUsedAt,
+		CreatedAt:    k.createdAt,
+		RevokedAt:    k.revokedAt,
+	}
+}
+
+// Actor возвращает вуз-владельца, ограниченного областями ключа.
+func (k *APIKey) Actor() Actor {
+	return Actor{
+		ID:       k.universityID,
+		Role:     RoleUniversity,
+		APIKeyID: k.id,
+		Scopes:   slices.Clone(k.scopes),
+	}
+}
+
+func (k *APIKey) Active() bool {
+	return k.revokedAt == nil
+}
+
+func (k *APIKey) checkInvariants() error {
+	if k.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if k.universityID == uuid.Nil {
+		return fmt.Errorf("%w: nil university id", ErrInvariantViolated)
+	}
+	if l := len(k.name); l < 1 || l > 128 {
+		return fmt.Errorf("%w: invalid name length", ErrInvariantViolated)
+	}
+	if k.prefix == "" {
+		return fmt.Errorf("%w: empty prefix", ErrInvariantViolated)
+	}
+	if k.secretHash == "" {
+		return fmt.Errorf("%w: empty secret hash", ErrInvariantViolated)
+	}
+	if len(k.scopes) == 0 {
+		return fmt.Errorf("%w: api key without scopes", ErrInvariantViolated)
+	}
+	allowed := APIKeyScopes()
+	for i, scope := range k.scopes {
+		if !slices.Contains(allowed, scope) {
+			return fmt.Errorf("%w: scope %q is not allowed for api keys", ErrInvariantViolated, scope)
+		}
+		if slices.Contains(k.scopes[:i], scope) {
+			return fmt.Errorf("%w: duplicate scope %q", ErrInvariantViolated, scope)
+		}
+	}
+	if k.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateAPIKey(attrs CreateAPIKeyAttrs, at time.Time) (*APIKey, error) {
+	return ReconstructAPIKey(APIKeyImmutable{
+		ID:           uuid.New(),
+		UniversityID: attrs.UniversityID,
+		Name:         attrs.Name,
+		Prefix:       attrs.Prefix,
+		SecretHash:   attrs.SecretHash,
+		Scopes:       attrs.Scopes,
+		CreatedAt:    at,
+	})
+}
+
+func ReconstructAPIKey(immutable APIKeyImmutable) (*APIKey, error) {
+	k := &APIKey{
+		id:           immutable.ID,
+		universityID: immutable.UniversityID,
+		name:         immutable.Name,
+		prefix:       immutable.Prefix,
+		secretHash:   immutable.SecretHash,
+		scopes:       slices.Clone(immutable.Scopes),
+		lastUsedAt:   immutable.LastUsedAt,
+		createdAt:    immutable.CreatedAt,
+		revokedAt:    immutable.RevokedAt,
+	}
+	return k, k.checkInvariants()
+}
+
+// Revoke отзывает ключ; отозванный ключ повторно не отзывается.
+func (k *APIKey) Revoke(at time.Time) (*APIKey, error) {
+	if !k.Active() {
+		return nil, fmt.Errorf("%w: api key is already revoked", ErrConflict)
+	}
+	imm := k.Immutable()
+	imm.RevokedAt = &at
+	return ReconstructAPIKey(imm)
+}
@@ -0,0 +1,253 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction — действие, попадающее в журнал аудита.
+type AuditAction string
+
+const (
+	AuditActionSignUp               AuditAction = "auth.sign_up"
+	AuditActionSignIn               AuditAction = "auth.sign_in"
+	AuditActionSignInFailed         AuditAction = "auth.sign_in_failed"
+	AuditActionLogout               AuditAction = "auth.logout"
+	AuditActionSessionReuse         AuditAction = "auth.session_reuse"
+	AuditActionCredentialsChange    AuditAction = "auth.credentials_change"
+	AuditActionPasswordResetSend    AuditAction = "auth.password_reset_request"
+	AuditActionPasswordReset        AuditAction = "auth.password_reset"
+	AuditActionEmailChangeRequest   AuditAction = "auth.email_change_request"
+	AuditActionEmailVerify          AuditAction = "auth.email_verify"
+	AuditActionTwoFactorEnroll      AuditAction = "two_factor.enroll"
+	AuditActionTwoFactorEnable      AuditAction = "two_factor.enable"
+	AuditActionTwoFactorDisable     AuditAction = "two_factor.disable"
+	AuditActionTwoFactorRecovery    AuditAction = "two_factor.recovery_codes_regenerate"
+	AuditActionTwoFactorFailed      AuditAction = "two_factor.verify_failed"
+	AuditActionSettingsChange       AuditAction = "settings.change"
+	AuditActionAdminCreate          AuditAction = "admin.create"
+	AuditActionCompanyApprove       AuditAction = "company.approve"
+	AuditActionCompanyReject        AuditAction = "company.reject"
+	AuditActionCompanyRevoke        AuditAction = "company.revoke_approval"
+	AuditActionCompanyUpdate        AuditAction = "company.update_profile"
+	AuditActionUniversityConfirm    AuditAction = "university.confirm"
+	AuditActionUniversityReject     AuditAction = "university.reject"
+	AuditActionUniversityRevoke     AuditAction = "university.revoke_confirmation"
+	AuditActionMemberInvite         AuditAction = "company_member.invite"
+	AuditActionInvitationRevoke     AuditAction = "company_member.invitation_revoke"
+	AuditActionInvitationAccept     AuditAction = "company_member.invitation_accept"
+	AuditActionMemberRoleChange     AuditAction = "company_member.role_change"
+	AuditActionMemberRemove         AuditAction = "company_member.remove"
+	AuditActionAPIKeyCreate         AuditAction = "api_key.create"
+	AuditActionAPIKeyRotate         AuditAction = "api_key.rotate"
+	AuditActionAPIKeyRevoke         AuditAction = "api_key.revoke"
+	AuditActionVacancyCreate        AuditAction = "vacancy.create"
+	AuditActionVacancyUpdate        AuditAction = "vacancy.update"
+	AuditActionVacancySubmit        AuditAction = "vacancy.submit"
+	AuditActionVacancyPublish       AuditAction = "vacancy.publish"
+	AuditActionVacancyReject        AuditAction = "vacancy.reject"
+	AuditActionVacancyArchive       AuditAction = "vacancy.archive"
+	AuditActionVacancyRestore       AuditAction = "vacancy.restore"
+	AuditActionPipelineUpdate       AuditAction = "pipeline.update"
+	AuditActionPipelineReset        AuditAction = "pipeline.reset"
+	AuditActionResponseCreate       AuditAction = "response.create"
+	AuditActionResponseStatusChange AuditAction = "response.status_change"
+)
+
+// AuditTargetType — вид объекта, над которым совершено действие.
+type AuditTargetType string
+
+const (
+	AuditTargetCompany           AuditTargetType = "company"
+	AuditTargetUniversity        AuditTargetType = "university"
+	AuditTargetAdmin             AuditTargetType = "admin"
+	AuditTargetCompanyMember     AuditTargetType = "company_member"
+	AuditTargetCompanyInvitation AuditTargetType = "company_invitation"
+	AuditTargetAPIKey            AuditTargetType = "api_key"
+	AuditTargetVacancy           AuditTargetType = "vacancy"
+	AuditTargetPipeline          AuditTargetType = "pipeline"
+	AuditTargetResponse          AuditTargetType = "response"
+	AuditTargetSettings          AuditTargetType = "settings"
+)
+
+// AuditTargetTypes возвращает все виды объектов журнала.
+func AuditTargetTypes() []AuditTargetType {
+	return []AuditTargetType{
+		AuditTargetCompany,
+		AuditTargetUniversity,
+		AuditTargetAdmin,
+		AuditTargetCompanyMember,
+		AuditTargetCompanyInvitation,
+		AuditTargetAPIKey,
+		AuditTargetVacancy,
+		AuditTargetPipeline,
+		AuditTargetResponse,
+		AuditTargetSettings,
+	}
+}
+
+// ParseAuditTargetType восстанавливает вид объекта из строки запроса или БД.
+func ParseAuditTargetType(s string) (AuditTargetType, error) {
+	for _, t := range AuditTargetTypes() {
+		if string(t) == s {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown audit target type %q", ErrInvariantViolated, s)
+}
+
+type (
+	// AuditEvent — запись журнала аудита. Записи только добавляются: ни
+	// сервисы, ни БД не позволяют их менять.
+	AuditEvent struct {
+		id         uuid.UUID
+		occurredAt time.Time
+		actor      Actor
+		action     AuditAction
+		targetType AuditTargetType
+		targetID   uuid.UUID
+		before     map[string]any
+		after      map[string]any
+		ip         string
+		userAgent  string
+	}
+
+	AuditEventImmutable struct {
+		ID         uuid.UUID
+		OccurredAt time.Time
+		// Для анонимных действий, например неудачного входа, актор пуст;
+		// из актора сохраняются только ID, роль и API-ключ
+		Actor      Actor
+		Action     AuditAction
+		TargetType AuditTargetType
+		// Пуст, если объект не найден, например при входе с неизвестным логином
+		TargetID  uuid.UUID
+		Before    map[string]any
+		After     map[string]any
+		IP        string
+		UserAgent string
+	}
+
+	// CreateAuditEventAttrs — действие со значениями полей объекта до и
+	// после него; в запись попадают только изменившиеся поля.
+	CreateAuditEventAttrs struct {
+		Actor      Actor
+		Action     AuditAction
+		TargetType AuditTargetType
+		TargetID   uuid.UUID
+		Before     map[string]any
+		After      map[string]any
+		IP         string
+		UserAgent  string
+	}
+
+	AuditEventFilter struct {
+		ActorID    *uuid.UUID
+		TargetType *AuditTargetType
+		TargetID   *uuid.UUID
+		From       *time.Time
+		To         *time.Time
+		Page       PageRequest
+	}
+)
+
+func (e *AuditEvent) Immutable() AuditEventImmutable {
+	return AuditEventImmutable{
+		ID:         e.id,
+		OccurredAt: e.occurredAt,
+		Actor:      e.actor,
+		Action:     e.action,
+		TargetType: e.targetType,
+		TargetID:   e.targetID,
+		Before:     maps.Clone(e.before),
+		After:      maps.Clone(e.after),
+		IP:         e.ip,
+		UserAgent:  e.userAgent,
+	}
+}
+
+func (e *AuditEvent) checkInvariants() error {
+	if e.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if e.occurredAt.IsZero() {
+		return fmt.Errorf("%w: zero occurrence time", ErrInvariantViolated)
+	}
+	if e.actor.ID != uuid.Nil {
+		if _, err := ParseRole(string(e.actor.Role)); err != nil {
+			return err
+		}
+	} else if e.actor.Role != "" || e.actor.APIKeyID != uuid.Nil {
+		return fmt.Errorf("%w: actor role without actor", ErrInvariantViolated)
+	}
+	if e.action == "" {
+		return fmt.Errorf("%w: empty action", ErrInvariantViolated)
+	}
+	if _, err := ParseAuditTargetType(string(e.targetType)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func CreateAuditEvent(attrs CreateAuditEventAttrs, at time.Time) (*AuditEvent, error) {
+	before, after := auditDiff(attrs.Before, attrs.After)
+
+	return ReconstructAuditEvent(AuditEventImmutable{
+		ID:         uuid.New(),
+		OccurredAt: at,
+		Actor: Actor{
+			ID:       attrs.Actor.ID,
+			Role:     attrs.Actor.Role,
+			APIKeyID: attrs.Actor.APIKeyID,
+		},
+		Action:     attrs.Action,
+		TargetType: attrs.TargetType,
+		TargetID:   attrs.TargetID,
+		Before:     before,
+		After:      after,
+		IP:         attrs.IP,
+		UserAgent:  attrs.UserAgent,
+	})
+}
+
+func ReconstructAuditEvent(immutable AuditEventImmutable) (*AuditEvent, error) {
+	e := &AuditEvent{
+		id:         immutable.ID,
+		occurredAt: immutable.OccurredAt,
+		actor:      immutable.Actor,
+		action:     immutable.Action,
+		targetType: immutable.TargetType,
+		targetID:   immutable.TargetID,
+		before:     maps.Clone(immutable.Before),
+		after:      maps.Clone(immutable.After),
+		ip:         immutable.IP,
+		userAgent:  immutable.UserAgent,
+	}
+	return e, e.checkInvariants()
+}
+
+// auditDiff оставляет только поля, значение которых изменилось. Поле,
+// известное лишь с одной стороны, сохраняется как есть.
+func auditDiff(before, after map[string]any) (map[string]any, map[string]any) {
+	b := make(map[string]any, len(before))
+	a := make(map[string]any, len(after))
+
+	for k, v := range before {
+		if av, ok := after[k]; ok && reflect.DeepEqual(v, av) {
+			continue
+		}
+		b[k] = v
+	}
+	for k, v := range after {
+		if bv, ok := before[k]; ok && reflect.DeepEqual(v, bv) {
+			continue
+		}
+		a[k] = v
+	}
+
+	return b, a
+}
@@ -9,63 +9,68 @@ import (
 
 type (
 	Company struct {
-		id               uuid.UUID
-		title            string
-		description      string
-		contacts         string
-		inn              string
-		address          string
-		approved         bool
-		representativeID uuid.UUID
-		login            string
-		passwordHash     string
-		createdAt        time.Time
-		updatedAt        time.Time
+		id              uuid.UUID
+		title           string
+		description     string
+		contacts        string
+		inn             string
+		address         string
+		logoURL         string
+		email           string
+		emailVerified   bool
+		approved        bool
+		rejectionReason string
+		createdAt       time.Time
+		updatedAt       time.Time
 	}
 
 	CompanyImmutable struct {
-		ID               uuid.UUID
-		Title            string
-		Description      string
-		Contacts         string
-		INN              string
-		Address          string
-		LogoURL          string
-		Approved         bool
-		RepresentativeID uuid.UUID
-		Login            string
-		PasswordHash     string
-		CreatedAt        time.Time
-		UpdatedAt        time.Time
+		ID              uuid.UUID
+		Title           string
+		Description     string
+		Contacts        string
+		INN             string
+		Address         string
+		LogoURL         string
+		Email           string
+		EmailVerified   bool
+		Approved        bool
+		RejectionReason string
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
 	}
 
 	CreateCompanyAttrs struct {
-		Title            string
-		Description      string
-		Contacts         string
-		INN              string
-		Address          string
-		LogoURL          string
-		RepresentativeID uuid.UUID
-		Login            string
-		PasswordHash     string
+		Title       string
+		Description string
+		Contacts    string
+		INN         string
+		Address     string
+		LogoURL     string
+		Email       string
+	}
+
+	CompanyFilter struct {
+		Approved *bool
+		Page     PageRequest
 	}
 )
 
 func (c *Company) Immutable() CompanyImmutable {
 	return CompanyImmutable{
-		ID:               c.id,
-		Title:            c.title,
-		Description:      c.description,
-		Contacts:         c.contacts,
-		INN:              c.inn,
-		Address:          c.address,
-		Approved:         c.approved,
-		RepresentativeID: c.representativeID,
-		Login:            c.login,
-		PasswordHash:     c.passwordHash,
-		CreatedAt:        c.createdAt,
-		UpdatedAt:        c.updatedAt,
+		ID:              c.id,
+		Title:           c.title,
+		Description:     c.description,
+		Contacts:        c.contacts,
+		INN:             c.inn,
+		Address:         c.address,
+		LogoURL:         c.logoURL,
+		Email:           c.email,
+		EmailVerified:   c.emailVerified,
+		Approved:        c.approved,
+		RejectionReason: c.rejectionReason,
+		CreatedAt:       c.createdAt,
+		UpdatedAt:       c.updatedAt,
 	}
 }
 
@@ -79,11 +84,17 @@ func (c *Company) checkInvariants() error {
 	if len(c.inn) != 10 {
 		return fmt.Errorf("%w: invalid INN length", ErrInvariantViolated)
 	}
-	if l := len(c.login); l < 4 || l > 128 {
-		return fmt.Errorf("%w: invalid login length", ErrInvariantViolated)
+	if len(c.logoURL) > 2048 {
+		return fmt.Errorf("%w: invalid logo url length", ErrInvariantViolated)
+	}
+	if err := checkEmail(c.email, c.emailVerified); err != nil {
+		return err
+	}
+	if c.approved && c.rejectionReason != "" {
+		return fmt.Errorf("%w: approved company with rejection reason", ErrInvariantViolated)
 	}
-	if len(c.passwordHash) < 10 {
-		return fmt.Errorf("%w: weak password hash", ErrInvariantViolated)
+	if len(c.rejectionReason) > 2048 {
+		return fmt.Errorf("%w: invalid rejection reason length", ErrInvariantViolated)
 	}
 	if c.createdAt.IsZero() {
 		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
@@ -99,51 +110,82 @@ func (c *Company) checkInvariants() error {
 
 func CreateCompany(attrs CreateCompanyAttrs, at time.Time) (*Company, error) {
 	imm := CompanyImmutable{
-		ID:               uuid.New(),
-		Title:            attrs.Title,
-		Description:      attrs.Description,
-		Contacts:         attrs.Contacts,
-		INN:              attrs.INN,
-		Address:          attrs.Address,
-		LogoURL:          attrs.LogoURL,
-		Approved:         false,
-		RepresentativeID: attrs.RepresentativeID,
-		Login:            attrs.Login,
-		PasswordHash:     attrs.PasswordHash,
-		CreatedAt:        at,
-		UpdatedAt:        at,
+		ID:          uuid.New(),
+		Title:       attrs.Title,
+		Description: attrs.Description,
+		Contacts:    attrs.Contacts,
+		INN:         attrs.INN,
+		Address:     attrs.Address,
+		LogoURL:     attrs.LogoURL,
+		Email:       attrs.Email,
+		Approved:    false,
+		CreatedAt:   at,
+		UpdatedAt:   at,
 	}
 	return ReconstructCompany(imm)
 }
 
 func ReconstructCompany(immutable CompanyImmutable) (*Company, error) {
 	c := &Company{
-		id:               immutable.ID,
-		title:            immutable.Title,
-		description:      immutable.Description,
-		contacts:         immutable.Contacts,
-		inn:              immutable.INN,
-		address:          immutable.Address,
-		
-		approved:         immutable.Approved,
-		representativeID: immutable.RepresentativeID,
-		login:            immutable.Login,
-		passwordHash:     immutable.PasswordHash,
-		createdAt:        immutable.CreatedAt,
-		updatedAt:        immutable.UpdatedAt,
+		id:              immutable.ID,
+		title:           immutable.Title,
+		description:     immutable.Description,
+		contacts:        immutable.Contacts,
+		inn:             immutable.INN,
+		address:         immutable.Address,
+		logoURL:         immutable.LogoURL,
+		email:           immutable.Email,
+		emailVerified:   immutable.EmailVerified,
+		approved:        immutable.Approved,
+		rejectionReason: immutable.RejectionReason,
+		createdAt:       immutable.CreatedAt,
+		updatedAt:       immutable.UpdatedAt,
 	}
 	return c, c.checkInvariants()
 }
 
 // Мутации через Immutable + Reconstruct
 func (c *Company) Approve(at time.Time) (*Company, error) {
+	if !c.emailVerified {
+		return nil, fmt.Errorf("%w: company email is not verified", ErrConflict)
+	}
 	imm := c.Immutable()
 	imm.Approved = true
+	imm.RejectionReason = ""
+	imm.UpdatedAt = at
+	return ReconstructCompany(imm)
+}
+
+// Reject отклоняет заявку компании, ещё не прошедшей модерацию.
+func (c *Company) Reject(reason string, at time.Time) (*Company, error) {
+	if c.approved {
+		return nil, fmt.Errorf("%w: company is already approved", ErrConflict)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("%w: empty rejection reason", ErrInvariantViolated)
+	}
+	imm := c.Immutable()
+	imm.RejectionReason = reason
+	imm.UpdatedAt = at
+	return ReconstructCompany(imm)
+}
+
+// RevokeApproval снимает ранее выданное одобрение.
+func (c *Company) RevokeApproval(reason string, at time.Time) (*Company, error) {
+	if !c.approved {
+		return nil, fmt.Errorf("%w: company is not approved", ErrConflict)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("%w: empty rejection reason", ErrInvariantViolated)
+	}
+	imm := c.Immutable()
+	imm.Approved = false
+	imm.RejectionReason = reason
 	imm.UpdatedAt = at
 	return ReconstructCompany(imm)
 }
 
-func (c *Company) UpdateProfile(title, description, contacts, address string, at time.Time) (*Company, error) {
+func (c *Company) UpdateProfile(title, description, contacts, address, logoURL string, at time.Time) (*Company, error) {
 	imm := c.Immutable()
 	if title != "" {
 		imm.Title = title
@@ -157,19 +199,27 @@ func (c *Company) UpdateProfile(title, description, contacts, address string, at
 	if address != "" {
 		imm.Address = address
 	}
-	
+	if logoURL != "" {
+		imm.LogoURL = logoURL
+	}
 	imm.UpdatedAt = at
 	return ReconstructCompany(imm)
 }
 
-func (c *Company) ChangeCredentials(login, passwordHash string, at time.Time) (*Company, error) {
+// ChangeEmail меняет контактный адрес; новый адрес требует подтверждения.
+func (c *Company) ChangeEmail(email string, at time.Time) (*Company, error) {
 	imm := c.Immutable()
-	if login != "" {
-		imm.Login = login
-	}
-	if passwordHash != "" {
-		imm.PasswordHash = passwordHash
+	if email != imm.Email {
+		imm.Email = email
+		imm.EmailVerified = false
 	}
 	imm.UpdatedAt = at
 	return ReconstructCompany(imm)
 }
+
+func (c *Company) VerifyEmail(at time.Time) (*Company, error) {
+	imm := c.Immutable()
+	imm.EmailVerified = true
+	imm.UpdatedAt = at
+	return ReconstructCompany(imm)
+}
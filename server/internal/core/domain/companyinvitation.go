@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CompanyInvitation — приглашение в команду компании, отправленное на почту.
+// Принявший его заводит собственные логин и пароль и получает роль,
+// выбранную владельцем. В хранилище попадает только хеш токена.
+type (
+	CompanyInvitation struct {
+		id         uuid.UUID
+		companyID  uuid.UUID
+		email      string
+		role       CompanyMemberRole
+		tokenHash  string
+		invitedBy  uuid.UUID
+		expiresAt  time.Time
+		createdAt  time.Time
+		acceptedAt *time.Time
+		revokedAt  *time.Time
+	}
+
+	CompanyInvitationImmutable struct {
+		ID         uuid.UUID
+		CompanyID  uuid.UUID
+		Email      string
+		Role       CompanyMemberRole
+		TokenHash  string
+		InvitedBy  uuid.UUID
+		ExpiresAt  time.Time
+		CreatedAt  time.Time
+		AcceptedAt *time.Time
+		RevokedAt  *time.Time
+	}
+
+	CreateCompanyInvitationAttrs struct {
+		CompanyID uuid.UUID
+		Email     string
+		Role      CompanyMemberRole
+		TokenHash string
+		InvitedBy uuid.UUID
+		TTL       time.Duration
+	}
+)
+
+func (i *CompanyInvitation) Immutable() CompanyInvitationImmutable {
+	return CompanyInvitationImmutable{
+		ID:         i.id,
+		CompanyID:  i.companyID,
+		Email:      i.email,
+		Role:       i.role,
+		TokenHash:  i.tokenHash,
+		InvitedBy:  i.invitedBy,
+		ExpiresAt:  i.expiresAt,
+		CreatedAt:  i.createdAt,
+		AcceptedAt: i.acceptedAt,
+		RevokedAt:  i.revokedAt,
+	}
+}
+
+// Pending сообщает, что по приглашению ещё можно вступить в команду.
+func (i *CompanyInvitation) Pending(at time.Time) bool {
+	return i.acceptedAt == nil && i.revokedAt == nil && at.Before(i.expiresAt)
+}
+
+func (i *CompanyInvitation) checkInvariants() error {
+	if i.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if i.companyID == uuid.Nil {
+		return fmt.Errorf("%w: nil company id", ErrInvariantViolated)
+	}
+	if i.email == "" {
+		return fmt.Errorf("%w: empty email", ErrInvariantViolated)
+	}
+	if err := checkEmail(i.email, false); err != nil {
+		return err
+	}
+	if _, err := ParseCompanyMemberRole(string(i.role)); err != nil {
+		return err
+	}
+	if i.tokenHash == "" {
+		return fmt.Errorf("%w: empty token hash", ErrInvariantViolated)
+	}
+	if i.invitedBy == uuid.Nil {
+		return fmt.Errorf("%w: nil inviter id", ErrInvariantViolated)
+	}
+	if i.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	if !i.expiresAt.After(i.createdAt) {
+		return fmt.Errorf("%w: expiration time is not after creation time", ErrInvariantViolated)
+	}
+	if i.acceptedAt != nil && i.revokedAt != nil {
+		return fmt.Errorf("%w: invitation is both accepted and revoked", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateCompanyInvitation(attrs CreateCompanyInvitationAttrs, at time.Time) (*CompanyInvitation, error) {
+	return ReconstructCompanyInvitation(CompanyInvitationImmutable{
+		ID:        uuid.New(),
+		CompanyID: attrs.CompanyID,
+		Email:     attrs.Email,
+		Role:      attrs.Role,
+		TokenHash: attrs.TokenHash,
+		InvitedBy: attrs.InvitedBy,
+		ExpiresAt: at.Add(attrs.TTL),
+		CreatedAt: at,
+	})
+}
+
+func ReconstructCompanyInvitation(immutable CompanyInvitationImmutable) (*CompanyInvitation, error) {
+	i := &CompanyInvitation{
+		id:         immutable.ID,
+		companyID:  immutable.CompanyID,
+		email:      immutable.Email,
+		role:       immutable.Role,
+		tokenHash:  immutable.TokenHash,
+		invitedBy:  immutable.InvitedBy,
+		expiresAt:  immutable.ExpiresAt,
+		createdAt:  immutable.CreatedAt,
+		acceptedAt: immutable.AcceptedAt,
+		revokedAt:  immutable.RevokedAt,
+	}
+	return i, i.checkInvariants()
+}
+
+// Accept отмечает приглашение принятым; истёкшее или отозванное
+// приглашение принять нельзя.
+func (i *CompanyInvitation) Accept(at time.Time) (*CompanyInvitation, error) {
+	if !i.Pending(at) {
+		return nil, fmt.Errorf("%w: invitation is not pending", ErrNotFound)
+	}
+	imm := i.Immutable()
+	imm.AcceptedAt = &at
+	return ReconstructCompanyInvitation(imm)
+}
+
+func (i *CompanyInvitation) Revoke(at time.Time) (*CompanyInvitation, error) {
+	if !i.Pending(at) {
+		return nil, fmt.Errorf("%w: invitation is not pending", ErrConflict)
+	}
+	imm := i.Immutable()
+	imm.RevokedAt = &at
+	return ReconstructCompanyInvitation(imm)
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CompanyMemberRole string
+
+const (
+	// Владелец управляет составом команды и профилем компании
+	CompanyMemberOwner         CompanyMemberRole = "owner"
+	CompanyMemberHiringManager CompanyMemberRole = "hiring_manager"
+	CompanyMemberRecruiter     CompanyMemberRole = "recruiter"
+)
+
+// ParseCompanyMemberRole восстанавливает роль сотрудника из строки.
+func ParseCompanyMemberRole(s string) (CompanyMemberRole, error) {
+	switch r := CompanyMemberRole(s); r {
+	case CompanyMemberOwner, CompanyMemberHiringManager, CompanyMemberRecruiter:
+		return r, nil
+	default:
+		return "", fmt.Errorf("%w: unknown company member role %q", ErrInvariantViolated, s)
+	}
+}
+
+// CompanyMember — сотрудник компании со своими учётными данными. Токены
+// выдаются сотруднику, а не компании, поэтому действия в аудите и проверки
+// прав относятся к конкретному человеку.
+type (
+	CompanyMember struct {
+		id            uuid.UUID
+		companyID     uuid.UUID
+		role          CompanyMemberRole
+		name          string
+		email         string
+		emailVerified bool
+		login         string
+		passwordHash  string
+		createdAt     time.Time
+		updatedAt     time.Time
+	}
+
+	CompanyMemberImmutable struct {
+		ID            uuid.UUID
+		CompanyID     uuid.UUID
+		Role          CompanyMemberRole
+		Name          string
+		Email         string
+		EmailVerified bool
+		Login         string
+		PasswordHash  string
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+	}
+
+	CreateCompanyMemberAttrs struct {
+		CompanyID uuid.UUID
+		Role      CompanyMemberRole
+		Name      string
+		Email     string
+		// Адрес из принятого приглашения уже подтверждён
+		EmailVerified bool
+		Login         string
+		PasswordHash  string
+	}
+)
+
+func (m *CompanyMember) Immutable() CompanyMemberImmutable {
+	return CompanyMemberImmutable{
+		ID:            m.id,
+		CompanyID:     m.companyID,
+		Role:          m.role,
+		Name:          m.name,
+		Email:         m.email,
+		EmailVerified: m.emailVerified,
+		Login:         m.login,
+		PasswordHash:  m.passwordHash,
+		CreatedAt:     m.createdAt,
+		UpdatedAt:     m.updatedAt,
+	}
+}
+
+func (m *CompanyMember) Actor() Actor {
+	return Actor{ID: m.id, Role: RoleCompany, CompanyID: m.companyID}
+}
+
+func (m *CompanyMember) Owner() bool {
+	return m.role == CompanyMemberOwner
+}
+
+func (m *CompanyMember) checkInvariants() error {
+	if m.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if m.companyID == uuid.Nil {
+		return fmt.Errorf("%w: nil company id", ErrInvariantViolated)
+	}
+	if _, err := ParseCompanyMemberRole(string(m.role)); err != nil {
+		return err
+	}
+	if len(m.name) > 256 {
+		return fmt.Errorf("%w: invalid name length", ErrInvariantViolated)
+	}
+	if err := checkEmail(m.email, m.emailVerified); err != nil {
+		return err
+	}
+	if l := len(m.login); l < 4 || l > 128 {
+		return fmt.Errorf("%w: invalid login length", ErrInvariantViolated)
+	}
+	if len(m.passwordHash) < 10 {
+		return fmt.Errorf("%w: weak password hash", ErrInvariantViolated)
+	}
+	if m.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	if m.updatedAt.IsZero() {
+		return fmt.Errorf("%w: zero updation time", ErrInvariantViolated)
+	}
+	if m.createdAt.After(m.updatedAt) {
+		return fmt.Errorf("%w: creation time is after updation time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateCompanyMember(attrs CreateCompanyMemberAttrs, at time.Time) (*CompanyMember, error) {
+	return ReconstructCompanyMember(CompanyMemberImmutable{
+		ID:            uuid.New(),
+		CompanyID:     attrs.CompanyID,
+		Role:          attrs.Role,
+		Name:          attrs.Name,
+		Email:         attrs.Email,
+		EmailVerified: attrs.EmailVerified,
+		Login:         attrs.Login,
+		PasswordHash:  attrs.PasswordHash,
+		CreatedAt:     at,
+		UpdatedAt:     at,
+	})
+}
+
+func ReconstructCompanyMember(immutable CompanyMemberImmutable) (*CompanyMember, error) {
+	m := &CompanyMember{
+		id:            immutable.ID,
+		companyID:     immutable.CompanyID,
+		role:          immutable.Role,
+		name:          immutable.Name,
+		email:         immutable.Email,
+		emailVerified: immutable.EmailVerified,
+		login:         immutable.Login,
+		passwordHash:  immutable.PasswordHash,
+		createdAt:     immutable.CreatedAt,
+		updatedAt:     immutable.UpdatedAt,
+	}
+	return m, m.checkInvariants()
+}
+
+func (m *CompanyMember) ChangeRole(role CompanyMemberRole, at time.Time) (*CompanyMember, error) {
+	imm := m.Immutable()
+	imm.Role = role
+	imm.UpdatedAt = at
+	return ReconstructCompanyMember(imm)
+}
+
+func (m *CompanyMember) ChangeCredentials(login, passwordHash string, at time.Time) (*CompanyMember, error) {
+	imm := m.Immutable()
+	if login != "" {
+		imm.Login = login
+	}
+	if passwordHash != "" {
+		imm.PasswordHash = passwordHash
+	}
+	imm.UpdatedAt = at
+	return ReconstructCompanyMember(imm)
+}
+
+func (m *CompanyMember) VerifyEmail(at time.Time) (*CompanyMember, error) {
+	imm := m.Immutable()
+	imm.EmailVerified = true
+	imm.UpdatedAt = at
+	return ReconstructCompanyMember(imm)
+}
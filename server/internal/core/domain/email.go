@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+)
+
+const maxEmailLength = 254
+
+// checkEmail проверяет контактный адрес аккаунта. Пустой адрес допустим
+// только для неподтверждённых аккаунтов, заведённых до появления поля.
+func checkEmail(email string, verified bool) error {
+	if email == "" {
+		if verified {
+			return fmt.Errorf("%w: verified empty email", ErrInvariantViolated)
+		}
+		return nil
+	}
+	if len(email) > maxEmailLength {
+		return fmt.Errorf("%w: invalid email length", ErrInvariantViolated)
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("%w: invalid email", ErrInvariantViolated)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 var (
 	ErrConflict          = errors.New("conflict")
@@ -8,4 +11,36 @@ var (
 	ErrForbidden         = errors.New("forbidden")
 	ErrUnauthorized      = errors.New("unauthorized")
 	ErrInvariantViolated = errors.New("invariant violated")
+	ErrTooManyRequests   = errors.New("too many requests")
 )
+
+// ModerationError означает, что аккаунт не прошёл модерацию.
+// Reason пуст, пока заявка ожидает рассмотрения.
+type ModerationError struct {
+	Reason string
+}
+
+func (e *ModerationError) Error() string {
+	if e.Reason == "" {
+		return "awaiting moderation"
+	}
+	return "rejected by moderator: " + e.Reason
+}
+
+func (e *ModerationError) Unwrap() error {
+	return ErrForbidden
+}
+
+// ThrottleError означает, что попытки временно заблокированы после серии
+// неудач; повторить можно не раньше чем через RetryAfter.
+type ThrottleError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string {
+	return "too many attempts, retry after " + e.RetryAfter.String()
+}
+
+func (e *ThrottleError) Unwrap() error {
+	return ErrTooManyRequests
+}
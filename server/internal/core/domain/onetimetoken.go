@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TokenPurpose string
+
+const (
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+	// Промежуточный токен входа, который обменивается на сессию по коду 2FA
+	TokenPurposeTwoFactor TokenPurpose = "two_factor"
+)
+
+// OneTimeToken — одноразовый секрет, отправляемый владельцу аккаунта
+// по внешнему каналу. В хранилище попадает только его хеш.
+type (
+	OneTimeToken struct {
+		id        uuid.UUID
+		purpose   TokenPurpose
+		subjectID uuid.UUID
+		role      role
+		tokenHash string
+		expiresAt time.Time
+		createdAt time.Time
+		usedAt    *time.Time
+	}
+
+	OneTimeTokenImmutable struct {
+		ID        uuid.UUID
+		Purpose   TokenPurpose
+		SubjectID uuid.UUID
+		Role      role
+		TokenHash string
+		ExpiresAt time.Time
+		CreatedAt time.Time
+		UsedAt    *time.Time
+	}
+
+	CreateOneTimeTokenAttrs struct {
+		Purpose   TokenPurpose
+		Actor     Actor
+		TokenHash string
+		TTL       time.Duration
+	}
+)
+
+func (t *OneTimeToken) Immutable() OneTimeTokenImmutable {
+	return OneTimeTokenImmutable{
+		ID:        t.id,
+		Purpose:   t.purpose,
+		SubjectID: t.subjectID,
+		Role:      t.role,
+		TokenHash: t.tokenHash,
+		ExpiresAt: t.expiresAt,
+		CreatedAt: t.createdAt,
+		UsedAt:    t.usedAt,
+	}
+}
+
+func (t *OneTimeToken) Actor() Actor {
+	return Actor{ID: t.subjectID, Role: t.role}
+}
+
+func (t *OneTimeToken) checkInvariants() error {
+	if t.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	switch t.purpose {
+	case TokenPurposePasswordReset, TokenPurposeEmailVerification, TokenPurposeTwoFactor:
+	default:
+		return fmt.Errorf("%w: unknown token purpose", ErrInvariantViolated)
+	}
+	if t.subjectID == uuid.Nil {
+		return fmt.Errorf("%w: nil subject id", ErrInvariantViolated)
+	}
+	switch t.role {
+	case RoleCompany, RoleUniversity, RoleAdmin:
+	default:
+		return fmt.Errorf("%w: unknown role", ErrInvariantViolated)
+	}
+	if t.tokenHash == "" {
+		return fmt.Errorf("%w: empty token hash", ErrInvariantViolated)
+	}
+	if t.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	if !t.expiresAt.After(t.createdAt) {
+		return fmt.Errorf("%w: expiration time is not after creation time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateOneTimeToken(attrs CreateOneTimeTokenAttrs, at time.Time) (*OneTimeToken, error) {
+	return ReconstructOneTimeToken(OneTimeTokenImmutable{
+		ID:        uuid.New(),
+		Purpose:   attrs.Purpose,
+		SubjectID: attrs.Actor.ID,
+		Role:      attrs.Actor.Role,
+		TokenHash: attrs.TokenHash,
+		ExpiresAt: at.Add(attrs.TTL),
+		CreatedAt: at,
+	})
+}
+
+func ReconstructOneTimeToken(immutable OneTimeTokenImmutable) (*OneTimeToken, error) {
+	t := &OneTimeToken{
+		id:        immutable.ID,
+		purpose:   immutable.Purpose,
+		subjectID: immutable.SubjectID,
+		role:      immutable.Role,
+		tokenHash: immutable.TokenHash,
+		expiresAt: immutable.ExpiresAt,
+		createdAt: immutable.CreatedAt,
+		usedAt:    immutable.UsedAt,
+	}
+	return t, t.checkInvariants()
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortBySalary    SortField = "salary"
+	SortByTitle     SortField = "title"
+)
+
+// Поля, по которым разрешено сортировать списки
+var (
+	VacancySortFields    = []SortField{SortByCreatedAt, SortBySalary, SortByTitle}
+	ResponseSortFields   = []SortField{SortByCreatedAt}
+	CompanySortFields    = []SortField{SortByCreatedAt, SortByTitle}
+	UniversitySortFields = []SortField{SortByCreatedAt, SortByTitle}
+)
+
+type (
+	// Sort — поле и направление сортировки. Нулевое значение означает
+	// сортировку по умолчанию: сначала новые.
+	Sort struct {
+		Field SortField
+		Desc  bool
+	}
+
+	// PageRequest — запрос страницы, нумерация с 1.
+	PageRequest struct {
+		Page int
+		Size int
+		Sort Sort
+	}
+
+	// Cursor — позиция в ленте, упорядоченной по (created_at, id) по убыванию.
+	// В отличие от смещения не «съезжает», когда в начало ленты добавляются записи.
+	Cursor struct {
+		CreatedAt time.Time
+		ID        uuid.UUID
+	}
+)
+
+// ParseSort разбирает значение вида "title" или "-created_at", где минус
+// означает сортировку по убыванию.
+func ParseSort(raw string, allowed []SortField) (Sort, error) {
+	if raw == "" {
+		return Sort{}, nil
+	}
+
+	s := Sort{Field: SortField(strings.TrimPrefix(raw, "-")), Desc: strings.HasPrefix(raw, "-")}
+	if !slices.Contains(allowed, s.Field) {
+		return Sort{}, fmt.Errorf("%w: unsupported sort field %q", ErrInvariantViolated, s.Field)
+	}
+
+	return s, nil
+}
+
+// String возвращает сортировку в том же виде, в каком её принимает ParseSort.
+func (s Sort) String() string {
+	if s.Field == "" {
+		return ""
+	}
+	if s.Desc {
+		return "-" + string(s.Field)
+	}
+	return string(s.Field)
+}
+
+func (p PageRequest) Limit() int {
+	return p.Size
+}
+
+func (p PageRequest) Offset() int {
+	if p.Page < 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.Size
+}
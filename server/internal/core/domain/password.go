@@ -0,0 +1,35 @@
+package domain
+
+import "strings"
+
+// PasswordViolation — код нарушенного правила парольной политики; коды
+// уходят клиенту как есть, поэтому менять их нельзя.
+type PasswordViolation string
+
+const (
+	PasswordTooShort         PasswordViolation = "too_short"
+	PasswordTooLong          PasswordViolation = "too_long"
+	PasswordNoLowercase      PasswordViolation = "no_lowercase"
+	PasswordNoUppercase      PasswordViolation = "no_uppercase"
+	PasswordNoDigit          PasswordViolation = "no_digit"
+	PasswordNoSymbol         PasswordViolation = "no_symbol"
+	PasswordSimilarToAccount PasswordViolation = "similar_to_account"
+	PasswordCommon           PasswordViolation = "common_password"
+)
+
+// PasswordPolicyError перечисляет все правила, которым не удовлетворил пароль.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	codes := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		codes[i] = string(v)
+	}
+	return "password policy violated: " + strings.Join(codes, ", ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrInvariantViolated
+}
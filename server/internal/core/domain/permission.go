@@ -0,0 +1,120 @@
+package domain
+
+import "fmt"
+
+// Permission — право на действие в сервисах. Сервисы проверяют права, а не
+// роли: какие права у какой роли, задаётся при запуске.
+type Permission string
+
+const (
+	PermissionCompanyList              Permission = "company.list"
+	PermissionCompanyApprove           Permission = "company.approve"
+	PermissionCompanyViewProfile       Permission = "company.view_profile"
+	PermissionCompanyEditProfile       Permission = "company.edit_profile"
+	PermissionCompanyViewMembers       Permission = "company.view_members"
+	PermissionCompanyManageMembers     Permission = "company.manage_members"
+	PermissionCompanyChangeCredentials Permission = "company.change_credentials"
+
+	PermissionUniversityList           Permission = "university.list"
+	PermissionUniversityApprove        Permission = "university.approve"
+	PermissionUniversityViewProfile    Permission = "university.view_profile"
+	PermissionUniversityEditProfile    Permission = "university.edit_profile"
+	PermissionUniversityManageAPIKeys  Permission = "university.manage_api_keys"
+	PermissionUniversityChangePassword Permission = "university.change_password"
+
+	PermissionCatalogExport Permission = "catalog.export"
+
+	PermissionVacancyView     Permission = "vacancy.view"
+	PermissionVacancyCreate   Permission = "vacancy.create"
+	PermissionVacancyEdit     Permission = "vacancy.edit"
+	PermissionVacancyPublish  Permission = "vacancy.publish"
+	PermissionVacancyModerate Permission = "vacancy.moderate"
+
+	PermissionPipelineManage Permission = "pipeline.manage"
+
+	PermissionResponseView         Permission = "response.view"
+	PermissionResponseViewPII      Permission = "response.view_pii"
+	PermissionResponseChangeStatus Permission = "response.change_status"
+
+	PermissionSettingsManage Permission = "settings.manage"
+	PermissionAuditView      Permission = "audit.view"
+)
+
+// Permissions возвращает полный каталог прав.
+func Permissions() []Permission {
+	return []Permission{
+		PermissionCompanyList,
+		PermissionCompanyApprove,
+		PermissionCompanyViewProfile,
+		PermissionCompanyEditProfile,
+		PermissionCompanyViewMembers,
+		PermissionCompanyManageMembers,
+		PermissionCompanyChangeCredentials,
+		PermissionUniversityList,
+		PermissionUniversityApprove,
+		PermissionUniversityViewProfile,
+		PermissionUniversityEditProfile,
+		PermissionUniversityManageAPIKeys,
+		PermissionUniversityChangePassword,
+		PermissionCatalogExport,
+		PermissionVacancyView,
+		PermissionVacancyCreate,
+		PermissionVacancyEdit,
+		PermissionVacancyPublish,
+		PermissionVacancyModerate,
+		PermissionPipelineManage,
+		PermissionResponseView,
+		PermissionResponseViewPII,
+		PermissionResponseChangeStatus,
+		PermissionSettingsManage,
+		PermissionAuditView,
+	}
+}
+
+// ParsePermission восстанавливает право из строки, например из конфигурации.
+func ParsePermission(s string) (Permission, error) {
+	for _, p := range Permissions() {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown permission %q", ErrInvariantViolated, s)
+}
+
+// AccessRole — строка матрицы прав. Сотрудники компании различаются ролью
+// в команде, остальные акторы — ролью аккаунта.
+type AccessRole string
+
+const (
+	AccessRoleAdmin                AccessRole = "admin"
+	AccessRoleUniversity           AccessRole = "university"
+	AccessRoleCompanyOwner         AccessRole = "company.owner"
+	AccessRoleCompanyHiringManager AccessRole = "company.hiring_manager"
+	AccessRoleCompanyRecruiter     AccessRole = "company.recruiter"
+)
+
+// AccessRoles возвращает все строки матрицы прав.
+func AccessRoles() []AccessRole {
+	return []AccessRole{
+		AccessRoleAdmin,
+		AccessRoleUniversity,
+		AccessRoleCompanyOwner,
+		AccessRoleCompanyHiringManager,
+		AccessRoleCompanyRecruiter,
+	}
+}
+
+// ParseAccessRole восстанавливает строку матрицы прав из конфигурации.
+func ParseAccessRole(s string) (AccessRole, error) {
+	for _, r := range AccessRoles() {
+		if string(r) == s {
+			return r, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown access role %q", ErrInvariantViolated, s)
+}
+
+// CompanyAccessRole сопоставляет роль в команде строке матрицы прав.
+func CompanyAccessRole(role CompanyMemberRole) AccessRole {
+	return AccessRole("company." + string(role))
+}
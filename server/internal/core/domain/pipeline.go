@@ -0,0 +1,268 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var pipelineStageKeyRe = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// responseFunnel — порядок промежуточных типов этапов. Этапы конвейера
+// идут в этом порядке, чтобы аналитика по типам оставалась сопоставимой
+// между компаниями.
+var responseFunnel = []ResponseStatus{
+	ResponseStatusNew,
+	ResponseStatusScreening,
+	ResponseStatusInterview,
+	ResponseStatusOffer,
+}
+
+// IsTerminal сообщает, завершает ли этап этого типа отбор.
+func (s ResponseStatus) IsTerminal() bool {
+	return s == ResponseStatusHired || s == ResponseStatusRejected || s == ResponseStatusWithdrawn
+}
+
+type (
+	// Pipeline — упорядоченные этапы отбора по откликам компании: для одной
+	// вакансии или по умолчанию для всех. Конвейер не меняется: правка
+	// сохраняется новой версией, а отклики остаются на версии, по которой
+	// были поданы.
+	Pipeline struct {
+		id        uuid.UUID
+		companyID uuid.UUID
+		vacancyID uuid.UUID
+		stages    []PipelineStage
+		createdAt time.Time
+	}
+
+	PipelineImmutable struct {
+		// У встроенного конвейера пусты ID, CompanyID и CreatedAt
+		ID        uuid.UUID
+		CompanyID uuid.UUID
+		// Пуст у конвейера компании по умолчанию
+		VacancyID uuid.UUID
+		Stages    []PipelineStage
+		CreatedAt time.Time
+	}
+
+	// PipelineStage — этап конвейера. Type сопоставляет этап одному из
+	// общих этапов отбора: по нему считается аналитика и определяется,
+	// завершает ли этап отбор.
+	PipelineStage struct {
+		Key  string
+		Name string
+		Type ResponseStatus
+	}
+
+	CreatePipelineAttrs struct {
+		CompanyID uuid.UUID
+		VacancyID uuid.UUID
+		Stages    []PipelineStage
+	}
+)
+
+// DefaultPipeline — встроенный конвейер для компаний, не настроивших свой.
+// Ключи его этапов совпадают с их типами.
+func DefaultPipeline() *Pipeline {
+	return &Pipeline{
+		stages: []PipelineStage{
+			{Key: "new", Name: "Новый", Type: ResponseStatusNew},
+			{Key: "screening", Name: "Скрининг", Type: ResponseStatusScreening},
+			{Key: "interview", Name: "Собеседование", Type: ResponseStatusInterview},
+			{Key: "offer", Name: "Оффер", Type: ResponseStatusOffer},
+			{Key: "hired", Name: "Принят", Type: ResponseStatusHired},
+			{Key: "rejected", Name: "Отказ", Type: ResponseStatusRejected},
+			{Key: "withdrawn", Name: "Отказ кандидата", Type: ResponseStatusWithdrawn},
+		},
+	}
+}
+
+func (p *Pipeline) Immutable() PipelineImmutable {
+	return PipelineImmutable{
+		ID:        p.id,
+		CompanyID: p.companyID,
+		VacancyID: p.vacancyID,
+		Stages:    slices.Clone(p.stages),
+		CreatedAt: p.createdAt,
+	}
+}
+
+func (p *Pipeline) checkInvariants() error {
+	if p.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if p.companyID == uuid.Nil {
+		return fmt.Errorf("%w: nil company id", ErrInvariantViolated)
+	}
+	if p.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	return checkPipelineStages(p.stages)
+}
+
+// checkPipelineStages проверяет, что конвейер начинается с этапа new,
+// промежуточные этапы идут по воронке, за ними следуют итоговые, и среди
+// итоговых есть найм и отказ.
+func checkPipelineStages(stages []PipelineStage) error {
+	if l := len(stages); l < 3 || l > 20 {
+		return fmt.Errorf("%w: invalid pipeline stage count", ErrInvariantViolated)
+	}
+	if stages[0].Type != ResponseStatusNew {
+		return fmt.Errorf("%w: first pipeline stage must be of type new", ErrInvariantViolated)
+	}
+
+	keys := make(map[string]bool, len(stages))
+	funnelPos := 0
+	terminalSeen := false
+	var hired, rejected bool
+
+	for i, st := range stages {
+		if !pipelineStageKeyRe.MatchString(st.Key) {
+			return fmt.Errorf("%w: invalid pipeline stage key %q", ErrInvariantViolated, st.Key)
+		}
+		if keys[st.Key] {
+			return fmt.Errorf("%w: duplicate pipeline stage key %q", ErrInvariantViolated, st.Key)
+		}
+		keys[st.Key] = true
+
+		if l := len(st.Name); l < 1 || l > 256 {
+			return fmt.Errorf("%w: invalid pipeline stage name length", ErrInvariantViolated)
+		}
+		if _, err := ParseResponseStatus(string(st.Type)); err != nil {
+			return err
+		}
+
+		if st.Type.IsTerminal() {
+			terminalSeen = true
+			hired = hired || st.Type == ResponseStatusHired
+			rejected = rejected || st.Type == ResponseStatusRejected
+			continue
+		}
+		if terminalSeen {
+			return fmt.Errorf("%w: pipeline stage %q follows a terminal stage", ErrInvariantViolated, st.Key)
+		}
+		if i > 0 && st.Type == ResponseStatusNew {
+			return fmt.Errorf("%w: only the first pipeline stage can be of type new", ErrInvariantViolated)
+		}
+		pos := slices.Index(responseFunnel, st.Type)
+		if pos < funnelPos {
+			return fmt.Errorf("%w: pipeline stage %q is out of funnel order", ErrInvariantViolated, st.Key)
+		}
+		funnelPos = pos
+	}
+
+	if !hired || !rejected {
+		return fmt.Errorf("%w: pipeline must have hired and rejected stages", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreatePipeline(attrs CreatePipelineAttrs, at time.Time) (*Pipeline, error) {
+	return ReconstructPipeline(PipelineImmutable{
+		ID:        uuid.New(),
+		CompanyID: attrs.CompanyID,
+		VacancyID: attrs.VacancyID,
+		Stages:    attrs.Stages,
+		CreatedAt: at,
+	})
+}
+
+func ReconstructPipeline(immutable PipelineImmutable) (*Pipeline, error) {
+	p := &Pipeline{
+		id:        immutable.ID,
+		companyID: immutable.CompanyID,
+		vacancyID: immutable.VacancyID,
+		stages:    slices.Clone(immutable.Stages),
+		createdAt: immutable.CreatedAt,
+	}
+	return p, p.checkInvariants()
+}
+
+// First — этап, на который попадает новый отклик.
+func (p *Pipeline) First() PipelineStage {
+	return p.stages[0]
+}
+
+// Stage ищет этап по ключу.
+func (p *Pipeline) Stage(key string) (PipelineStage, bool) {
+	i := p.stageIndex(key)
+	if i < 0 {
+		return PipelineStage{}, false
+	}
+	return p.stages[i], true
+}
+
+// CanMove проверяет переход между этапами: вперёд по конвейеру с пропусками,
+// на отказ — с любого промежуточного этапа, на найм — только с последнего
+// промежуточного. С итоговых этапов переходов нет.
+func (p *Pipeline) CanMove(from, to string) error {
+	fromIdx, toIdx := p.stageIndex(from), p.stageIndex(to)
+	if fromIdx < 0 {
+		return fmt.Errorf("%w: unknown pipeline stage %q", ErrInvariantViolated, from)
+	}
+	if toIdx < 0 {
+		return fmt.Errorf("%w: unknown pipeline stage %q", ErrInvariantViolated, to)
+	}
+
+	fromStage, toStage := p.stages[fromIdx], p.stages[toIdx]
+
+	switch {
+	case fromStage.Type.IsTerminal():
+		return fmt.Errorf("%w: stage %q is terminal", ErrConflict, from)
+	case toStage.Type == ResponseStatusHired:
+		if fromIdx != p.lastOpenIndex() {
+			return fmt.Errorf("%w: hire is allowed only from the last open stage", ErrConflict)
+		}
+	case toStage.Type.IsTerminal():
+	case toIdx <= fromIdx:
+		return fmt.Errorf("%w: response cannot move back from %q to %q", ErrConflict, from, to)
+	}
+
+	return nil
+}
+
+// NextStageOfType выбирает этап типа t, на который отклик может перейти с
+// этапа from: первый допустимый по порядку конвейера. ErrConflict, если
+// этапы этого типа есть, но перейти ни на один нельзя.
+func (p *Pipeline) NextStageOfType(from string, t ResponseStatus) (string, error) {
+	if _, err := ParseResponseStatus(string(t)); err != nil {
+		return "", err
+	}
+
+	found := false
+	for _, st := range p.stages {
+		if st.Type != t {
+			continue
+		}
+		found = true
+		if p.CanMove(from, st.Key) == nil {
+			return st.Key, nil
+		}
+	}
+
+	if !found {
+		return "", fmt.Errorf("%w: pipeline has no stage of type %q", ErrConflict, t)
+	}
+	return "", fmt.Errorf("%w: response cannot move from %q to a stage of type %q", ErrConflict, from, t)
+}
+
+func (p *Pipeline) stageIndex(key string) int {
+	return slices.IndexFunc(p.stages, func(st PipelineStage) bool {
+		return st.Key == key
+	})
+}
+
+// lastOpenIndex — индекс последнего промежуточного этапа.
+func (p *Pipeline) lastOpenIndex() int {
+	last := 0
+	for i, st := range p.stages {
+		if !st.Type.IsTerminal() {
+			last = i
+		}
+	}
+	return last
+}
@@ -7,25 +7,47 @@ import (
 	"github.com/google/uuid"
 )
 
+// ResponseStatus — общий этап отбора кандидата по отклику. Этапы конвейеров
+// компаний сопоставляются этим значениям, поэтому по ним сравнивается
+// аналитика разных компаний.
+type ResponseStatus string
+
 const (
-	ResponseStatusNew      = "new"
-	ResponseStatusViewed   = "viewed"
-	ResponseStatusRejected = "rejected"
-	ResponseStatusAccepted = "accepted"
+	ResponseStatusNew       ResponseStatus = "new"
+	ResponseStatusScreening ResponseStatus = "screening"
+	ResponseStatusInterview ResponseStatus = "interview"
+	ResponseStatusOffer     ResponseStatus = "offer"
+	ResponseStatusHired     ResponseStatus = "hired"
+	ResponseStatusRejected  ResponseStatus = "rejected"
+	// Кандидат сам отказался от вакансии
+	ResponseStatusWithdrawn ResponseStatus = "withdrawn"
 )
 
+// ParseResponseStatus восстанавливает этап отклика из строки.
+func ParseResponseStatus(s string) (ResponseStatus, error) {
+	switch st := ResponseStatus(s); st {
+	case ResponseStatusNew, ResponseStatusScreening, ResponseStatusInterview, ResponseStatusOffer,
+		ResponseStatusHired, ResponseStatusRejected, ResponseStatusWithdrawn:
+		return st, nil
+	default:
+		return "", fmt.Errorf("%w: unknown response status %q", ErrInvariantViolated, s)
+	}
+}
+
 type (
 	Response struct {
-		id        uuid.UUID
-		vacancyID uuid.UUID
-		fullName  string
-		email     string
-		phone     string
+		id          uuid.UUID
+		vacancyID   uuid.UUID
+		fullName    string
+		email       string
+		phone       string
 		coverLetter string
-		resumeURL string
-		status    string
-		createdAt time.Time
-		updatedAt time.Time
+		resumeURL   string
+		status      ResponseStatus
+		pipelineID  uuid.UUID
+		stage       string
+		createdAt   time.Time
+		updatedAt   time.Time
 	}
 
 	ResponseImmutable struct {
@@ -36,9 +58,13 @@ type (
 		Phone       string
 		CoverLetter string
 		ResumeURL   string
-		Status      string
-		CreatedAt   time.Time
-		UpdatedAt   time.Time
+		// Тип текущего этапа конвейера
+		Status ResponseStatus
+		// Пуст, если отклик идёт по встроенному конвейеру
+		PipelineID uuid.UUID
+		Stage      string
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
 	}
 
 	CreateResponseAttrs struct {
@@ -48,13 +74,13 @@ type (
 		Phone       string
 		CoverLetter string
 		ResumeURL   string
+		Pipeline    *Pipeline
 	}
 
 	ResponseFilter struct {
 		VacancyID *uuid.UUID
-		Status    *string
-		Limit     int
-		Offset    int
+		Status    *ResponseStatus
+		Page      PageRequest
 	}
 )
 
@@ -68,6 +94,8 @@ func (r *Response) Immutable() ResponseImmutable {
 		CoverLetter: r.coverLetter,
 		ResumeURL:   r.resumeURL,
 		Status:      r.status,
+		PipelineID:  r.pipelineID,
+		Stage:       r.stage,
 		CreatedAt:   r.createdAt,
 		UpdatedAt:   r.updatedAt,
 	}
@@ -86,10 +114,11 @@ func (r *Response) checkInvariants() error {
 	if l := len(r.email); l < 3 || l > 256 {
 		return fmt.Errorf("%w: invalid email length", ErrInvariantViolated)
 	}
-	switch r.status {
-	case ResponseStatusNew, ResponseStatusViewed, ResponseStatusRejected, ResponseStatusAccepted:
-	default:
-		return fmt.Errorf("%w: invalid status", ErrInvariantViolated)
+	if _, err := ParseResponseStatus(string(r.status)); err != nil {
+		return err
+	}
+	if !pipelineStageKeyRe.MatchString(r.stage) {
+		return fmt.Errorf("%w: invalid stage %q", ErrInvariantViolated, r.stage)
 	}
 	if r.createdAt.IsZero() {
 		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
@@ -103,7 +132,9 @@ func (r *Response) checkInvariants() error {
 	return nil
 }
 
+// CreateResponse ставит отклик на первый этап конвейера attrs.Pipeline.
 func CreateResponse(attrs CreateResponseAttrs, at time.Time) (*Response, error) {
+	first := attrs.Pipeline.First()
 	imm := ResponseImmutable{
 		ID:          uuid.New(),
 		VacancyID:   attrs.VacancyID,
@@ -112,7 +143,9 @@ func CreateResponse(attrs CreateResponseAttrs, at time.Time) (*Response, error)
 		Phone:       attrs.Phone,
 		CoverLetter: attrs.CoverLetter,
 		ResumeURL:   attrs.ResumeURL,
-		Status:      ResponseStatusNew,
+		Status:      first.Type,
+		PipelineID:  attrs.Pipeline.id,
+		Stage:       first.Key,
 		CreatedAt:   at,
 		UpdatedAt:   at,
 	}
@@ -129,6 +162,8 @@ func ReconstructResponse(immutable ResponseImmutable) (*Response, error) {
 		coverLetter: immutable.CoverLetter,
 		resumeURL:   immutable.ResumeURL,
 		status:      immutable.Status,
+		pipelineID:  immutable.PipelineID,
+		stage:       immutable.Stage,
 		createdAt:   immutable.CreatedAt,
 		updatedAt:   immutable.UpdatedAt,
 	}
@@ -136,9 +171,20 @@ func ReconstructResponse(immutable ResponseImmutable) (*Response, error) {
 }
 
 // Мутации через Immutable + Reconstruct
-func (r *Response) SetStatus(status string, at time.Time) (*Response, error) {
+
+// MoveToStage переводит отклик на этап stage его конвейера p; статус
+// становится типом этапа.
+func (r *Response) MoveToStage(p *Pipeline, stage string, at time.Time) (*Response, error) {
+	if p.id != r.pipelineID {
+		return nil, fmt.Errorf("%w: response is on another pipeline", ErrInvariantViolated)
+	}
+	if err := p.CanMove(r.stage, stage); err != nil {
+		return nil, err
+	}
+	to, _ := p.Stage(stage)
 	imm := r.Immutable()
-	imm.Status = status
+	imm.Status = to.Type
+	imm.Stage = to.Key
 	imm.UpdatedAt = at
 	return ReconstructResponse(imm)
 }
@@ -157,3 +203,13 @@ func (r *Response) UpdateContacts(fullName, email, phone string, at time.Time) (
 	imm.UpdatedAt = at
 	return ReconstructResponse(imm)
 }
+
+// WithoutContacts возвращает копию отклика без контактов кандидата. Копия
+// предназначена только для выдачи и не сохраняется.
+func (r *Response) WithoutContacts() *Response {
+	c := *r
+	c.email = ""
+	c.phone = ""
+	c.resumeURL = ""
+	return &c
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// ResponseStatusChange — запись истории этапов отклика. Первая запись
+	// истории — создание отклика, у неё пустые from и fromStage. Статусы
+	// хранят типы этапов, stage — их ключи в конвейере отклика.
+	ResponseStatusChange struct {
+		id         uuid.UUID
+		responseID uuid.UUID
+		from       ResponseStatus
+		to         ResponseStatus
+		fromStage  string
+		toStage    string
+		actor      Actor
+		changedAt  time.Time
+	}
+
+	ResponseStatusChangeImmutable struct {
+		ID         uuid.UUID
+		ResponseID uuid.UUID
+		From       ResponseStatus
+		To         ResponseStatus
+		FromStage  string
+		ToStage    string
+		// Пуст, если этап сменил кандидат; из актора сохраняются ID и роль
+		Actor     Actor
+		ChangedAt time.Time
+	}
+
+	CreateResponseStatusChangeAttrs struct {
+		ResponseID uuid.UUID
+		From       ResponseStatus
+		To         ResponseStatus
+		FromStage  string
+		ToStage    string
+		Actor      Actor
+	}
+)
+
+func (c *ResponseStatusChange) Immutable() ResponseStatusChangeImmutable {
+	return ResponseStatusChangeImmutable{
+		ID:         c.id,
+		ResponseID: c.responseID,
+		From:       c.from,
+		To:         c.to,
+		FromStage:  c.fromStage,
+		ToStage:    c.toStage,
+		Actor:      c.actor,
+		ChangedAt:  c.changedAt,
+	}
+}
+
+func (c *ResponseStatusChange) checkInvariants() error {
+	if c.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if c.responseID == uuid.Nil {
+		return fmt.Errorf("%w: nil response id", ErrInvariantViolated)
+	}
+	if c.from != "" {
+		if _, err := ParseResponseStatus(string(c.from)); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseResponseStatus(string(c.to)); err != nil {
+		return err
+	}
+	if (c.from == "") != (c.fromStage == "") {
+		return fmt.Errorf("%w: from status and stage mismatch", ErrInvariantViolated)
+	}
+	if c.fromStage != "" && !pipelineStageKeyRe.MatchString(c.fromStage) {
+		return fmt.Errorf("%w: invalid from stage %q", ErrInvariantViolated, c.fromStage)
+	}
+	if !pipelineStageKeyRe.MatchString(c.toStage) {
+		return fmt.Errorf("%w: invalid to stage %q", ErrInvariantViolated, c.toStage)
+	}
+	if c.fromStage == c.toStage {
+		return fmt.Errorf("%w: status change without change", ErrInvariantViolated)
+	}
+	if c.actor.ID != uuid.Nil {
+		if _, err := ParseRole(string(c.actor.Role)); err != nil {
+			return err
+		}
+	} else if c.actor.Role != "" {
+		return fmt.Errorf("%w: actor role without actor", ErrInvariantViolated)
+	}
+	if c.changedAt.IsZero() {
+		return fmt.Errorf("%w: zero change time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateResponseStatusChange(attrs CreateResponseStatusChangeAttrs, at time.Time) (*ResponseStatusChange, error) {
+	return ReconstructResponseStatusChange(ResponseStatusChangeImmutable{
+		ID:         uuid.New(),
+		ResponseID: attrs.ResponseID,
+		From:       attrs.From,
+		To:         attrs.To,
+		FromStage:  attrs.FromStage,
+		ToStage:    attrs.ToStage,
+		Actor:      Actor{ID: attrs.Actor.ID, Role: attrs.Actor.Role},
+		ChangedAt:  at,
+	})
+}
+
+func ReconstructResponseStatusChange(immutable ResponseStatusChangeImmutable) (*ResponseStatusChange, error) {
+	c := &ResponseStatusChange{
+		id:         immutable.ID,
+		responseID: immutable.ResponseID,
+		from:       immutable.From,
+		to:         immutable.To,
+		fromStage:  immutable.FromStage,
+		toStage:    immutable.ToStage,
+		actor:      immutable.Actor,
+		changedAt:  immutable.ChangedAt,
+	}
+	return c, c.checkInvariants()
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session — одно звено цепочки refresh-токенов. При каждом обновлении
+// текущее звено помечается использованным, а в том же семействе (familyID)
+// создаётся новое. Повторное предъявление использованного токена означает
+// утечку, и всё семейство отзывается.
+type (
+	Session struct {
+		id               uuid.UUID
+		familyID         uuid.UUID
+		subjectID        uuid.UUID
+		role             role
+		companyID        uuid.UUID
+		refreshTokenHash string
+		expiresAt        time.Time
+		createdAt        time.Time
+		rotatedAt        *time.Time
+		revokedAt        *time.Time
+	}
+
+	SessionImmutable struct {
+		ID        uuid.UUID
+		FamilyID  uuid.UUID
+		SubjectID uuid.UUID
+		Role      role
+		// Компания сотрудника, для остальных ролей пуста
+		CompanyID        uuid.UUID
+		RefreshTokenHash string
+		ExpiresAt        time.Time
+		CreatedAt        time.Time
+		RotatedAt        *time.Time
+		RevokedAt        *time.Time
+	}
+
+	CreateSessionAttrs struct {
+		// Пустой FamilyID начинает новое семейство
+		FamilyID         uuid.UUID
+		Actor            Actor
+		RefreshTokenHash string
+		TTL              time.Duration
+	}
+)
+
+func (s *Session) Immutable() SessionImmutable {
+	return SessionImmutable{
+		ID:               s.id,
+		FamilyID:         s.familyID,
+		SubjectID:        s.subjectID,
+		Role:             s.role,
+		CompanyID:        s.companyID,
+		RefreshTokenHash: s.refreshTokenHash,
+		ExpiresAt:        s.expiresAt,
+		CreatedAt:        s.createdAt,
+		RotatedAt:        s.rotatedAt,
+		RevokedAt:        s.revokedAt,
+	}
+}
+
+func (s *Session) Actor() Actor {
+	return Actor{ID: s.subjectID, Role: s.role, CompanyID: s.companyID}
+}
+
+func (s *Session) checkInvariants() error {
+	if s.id == uuid.Nil {
+		return fmt.Errorf("%w: nil id", ErrInvariantViolated)
+	}
+	if s.familyID == uuid.Nil {
+		return fmt.Errorf("%w: nil family id", ErrInvariantViolated)
+	}
+	if s.subjectID == uuid.Nil {
+		return fmt.Errorf("%w: nil subject id", ErrInvariantViolated)
+	}
+	switch s.role {
+	case RoleCompany, RoleUniversity, RoleAdmin:
+	default:
+		return fmt.Errorf("%w: unknown role", ErrInvariantViolated)
+	}
+	if (s.role == RoleCompany) != (s.companyID != uuid.Nil) {
+		return fmt.Errorf("%w: company id must be set only for company role", ErrInvariantViolated)
+	}
+	if s.refreshTokenHash == "" {
+		return fmt.Errorf("%w: empty refresh token hash", ErrInvariantViolated)
+	}
+	if s.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	if !s.expiresAt.After(s.createdAt) {
+		return fmt.Errorf("%w: expiration time is not after creation time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateSession(attrs CreateSessionAttrs, at time.Time) (*Session, error) {
+	familyID := attrs.FamilyID
+	if familyID == uuid.Nil {
+		familyID = uuid.New()
+	}
+
+	return ReconstructSession(SessionImmutable{
+		ID:               uuid.New(),
+		FamilyID:         familyID,
+		SubjectID:        attrs.Actor.ID,
+		Role:             attrs.Actor.Role,
+		CompanyID:        attrs.Actor.CompanyID,
+		RefreshTokenHash: attrs.RefreshTokenHash,
+		ExpiresAt:        at.Add(attrs.TTL),
+		CreatedAt:        at,
+	})
+}
+
+func ReconstructSession(immutable SessionImmutable) (*Session, error) {
+	s := &Session{
+		id:               immutable.ID,
+		familyID:         immutable.FamilyID,
+		subjectID:        immutable.SubjectID,
+		role:             immutable.Role,
+		companyID:        immutable.CompanyID,
+		refreshTokenHash: immutable.RefreshTokenHash,
+		expiresAt:        immutable.ExpiresAt,
+		createdAt:        immutable.CreatedAt,
+		rotatedAt:        immutable.RotatedAt,
+		revokedAt:        immutable.RevokedAt,
+	}
+	return s, s.checkInvariants()
+}
+
+// Rotated сообщает, что по токену этого звена уже выдавалось следующее.
+func (s *Session) Rotated() bool {
+	return s.rotatedAt != nil
+}
+
+func (s *Session) Revoked() bool {
+	return s.revokedAt != nil
+}
+
+func (s *Session) Expired(at time.Time) bool {
+	return !at.Before(s.expiresAt)
+}
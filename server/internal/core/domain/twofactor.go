@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactor — TOTP-секрет аккаунта (RFC 6238). Пока он не подтверждён первым
+// верным кодом, вход по-прежнему выполняется только по паролю. lastUsedStep
+// хранит временной шаг последнего принятого кода, чтобы перехваченный код
+// нельзя было предъявить повторно.
+type (
+	TwoFactor struct {
+		subjectID    uuid.UUID
+		role         role
+		secret       string
+		confirmedAt  *time.Time
+		lastUsedStep int64
+		createdAt    time.Time
+		updatedAt    time.Time
+	}
+
+	TwoFactorImmutable struct {
+		SubjectID    uuid.UUID
+		Role         role
+		Secret       string
+		ConfirmedAt  *time.Time
+		LastUsedStep int64
+		CreatedAt    time.Time
+		UpdatedAt    time.Time
+	}
+
+	CreateTwoFactorAttrs struct {
+		Actor  Actor
+		Secret string
+	}
+)
+
+func (t *TwoFactor) Immutable() TwoFactorImmutable {
+	return TwoFactorImmutable{
+		SubjectID:    t.subjectID,
+		Role:         t.role,
+		Secret:       t.secret,
+		ConfirmedAt:  t.confirmedAt,
+		LastUsedStep: t.lastUsedStep,
+		CreatedAt:    t.createdAt,
+		UpdatedAt:    t.updatedAt,
+	}
+}
+
+func (t *TwoFactor) Actor() Actor {
+	return Actor{ID: t.subjectID, Role: t.role}
+}
+
+func (t *TwoFactor) Confirmed() bool {
+	return t.confirmedAt != nil
+}
+
+// Confirm включает 2FA по первому верному коду из приложения.
+func (t *TwoFactor) Confirm(step int64, at time.Time) (*TwoFactor, error) {
+	if t.Confirmed() {
+		return nil, fmt.Errorf("%w: two-factor is already confirmed", ErrConflict)
+	}
+	imm := t.Immutable()
+	imm.ConfirmedAt = &at
+	imm.LastUsedStep = step
+	imm.UpdatedAt = at
+	return ReconstructTwoFactor(imm)
+}
+
+// UseStep принимает код временного шага step. Код того же или более раннего
+// шага уже мог быть использован и отклоняется.
+func (t *TwoFactor) UseStep(step int64, at time.Time) (*TwoFactor, error) {
+	if step <= t.lastUsedStep {
+		return nil, fmt.Errorf("%w: two-factor code already used", ErrUnauthorized)
+	}
+	imm := t.Immutable()
+	imm.LastUsedStep = step
+	imm.UpdatedAt = at
+	return ReconstructTwoFactor(imm)
+}
+
+func (t *TwoFactor) checkInvariants() error {
+	if t.subjectID == uuid.Nil {
+		return fmt.Errorf("%w: nil subject id", ErrInvariantViolated)
+	}
+	switch t.role {
+	case RoleCompany, RoleAdmin:
+	default:
+		return fmt.Errorf("%w: two-factor is not supported for role %s", ErrInvariantViolated, t.role)
+	}
+	if t.secret == "" {
+		return fmt.Errorf("%w: empty two-factor secret", ErrInvariantViolated)
+	}
+	if t.lastUsedStep < 0 {
+		return fmt.Errorf("%w: negative last used step", ErrInvariantViolated)
+	}
+	if t.createdAt.IsZero() {
+		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
+	}
+	if t.updatedAt.IsZero() {
+		return fmt.Errorf("%w: zero updation time", ErrInvariantViolated)
+	}
+	if t.createdAt.After(t.updatedAt) {
+		return fmt.Errorf("%w: creation time is after updation time", ErrInvariantViolated)
+	}
+	return nil
+}
+
+func CreateTwoFactor(attrs CreateTwoFactorAttrs, at time.Time) (*TwoFactor, error) {
+	return ReconstructTwoFactor(TwoFactorImmutable{
+		SubjectID: attrs.Actor.ID,
+		Role:      attrs.Actor.Role,
+		Secret:    attrs.Secret,
+		CreatedAt: at,
+		UpdatedAt: at,
+	})
+}
+
+func ReconstructTwoFactor(immutable TwoFactorImmutable) (*TwoFactor, error) {
+	t := &TwoFactor{
+		subjectID:    immutable.SubjectID,
+		role:         immutable.Role,
+		secret:       immutable.Secret,
+		confirmedAt:  immutable.ConfirmedAt,
+		lastUsedStep: immutable.LastUsedStep,
+		createdAt:    immutable.CreatedAt,
+		updatedAt:    immutable.UpdatedAt,
+	}
+	return t, t.checkInvariants()
+}
@@ -9,25 +9,31 @@ import (
 
 type (
 	University struct {
-		id           uuid.UUID
-		title        string
-		login        string
-		passwordHash string
-		inn          string
-		confirmed    bool
-		createdAt    time.Time
-		updatedAt    time.Time
+		id              uuid.UUID
+		title           string
+		login           string
+		passwordHash    string
+		inn             string
+		email           string
+		emailVerified   bool
+		confirmed       bool
+		rejectionReason string
+		createdAt       time.Time
+		updatedAt       time.Time
 	}
 
 	UniversityImmutable struct {
-		ID           uuid.UUID
-		Title        string
-		Login        string
-		PasswordHash string
-		INN          string
-		Confirmed    bool
-		CreatedAt    time.Time
-		UpdatedAt    time.Time
+		ID              uuid.UUID
+		Title           string
+		Login           string
+		PasswordHash    string
+		INN             string
+		Email           string
+		EmailVerified   bool
+		Confirmed       bool
+		RejectionReason string
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
 	}
 
 	CreateUniversityAttrs struct {
@@ -35,17 +41,28 @@ type (
 		Login        string
 		PasswordHash string
 		INN          string
+		Email        string
+	}
+
+	UniversityFilter struct {
+		Confirmed *bool
+		Page      PageRequest
 	}
 )
 
 func (u *University) Immutable() UniversityImmutable {
 	return UniversityImmutable{
-		ID:           u.id,
-		Title:        u.title,
-		Login:        u.login,
-		PasswordHash: u.passwordHash,
-		INN:          u.inn,
-		Confirmed:    u.confirmed,
+		ID:              u.id,
+		Title:           u.title,
+		Login:           u.login,
+		PasswordHash:    u.passwordHash,
+		INN:             u.inn,
+		Email:           u.email,
+		EmailVerified:   u.emailVerified,
+		Confirmed:       u.confirmed,
+		RejectionReason: u.rejectionReason,
+		CreatedAt:       u.createdAt,
+		UpdatedAt:       u.updatedAt,
 	}
 }
 
@@ -57,7 +74,61 @@ func (u *University) SetPasswordHash(newPasswordHash string, at time.Time) error
 }
 
 func (u *University) Confirm(at time.Time) error {
+	if !u.emailVerified {
+		return fmt.Errorf("%w: university email is not verified", ErrConflict)
+	}
+
 	u.confirmed = true
+	u.rejectionReason = ""
+	u.updatedAt = at
+
+	return u.checkInvariants()
+}
+
+// ChangeEmail меняет контактный адрес; новый адрес требует подтверждения.
+func (u *University) ChangeEmail(email string, at time.Time) error {
+	if email != u.email {
+		u.email = email
+		u.emailVerified = false
+	}
+	u.updatedAt = at
+
+	return u.checkInvariants()
+}
+
+func (u *University) VerifyEmail(at time.Time) error {
+	u.emailVerified = true
+	u.updatedAt = at
+
+	return u.checkInvariants()
+}
+
+// Reject отклоняет заявку вуза, ещё не прошедшего подтверждение.
+func (u *University) Reject(reason string, at time.Time) error {
+	if u.confirmed {
+		return fmt.Errorf("%w: university is already confirmed", ErrConflict)
+	}
+	if reason == "" {
+		return fmt.Errorf("%w: empty rejection reason", ErrInvariantViolated)
+	}
+
+	u.rejectionReason = reason
+	u.updatedAt = at
+
+	return u.checkInvariants()
+}
+
+// RevokeConfirmation снимает ранее выданное подтверждение.
+func (u *University) RevokeConfirmation(reason string, at time.Time) error {
+	if !u.confirmed {
+		return fmt.Errorf("%w: university is not confirmed", ErrConflict)
+	}
+	if reason == "" {
+		return fmt.Errorf("%w: empty rejection reason", ErrInvariantViolated)
+	}
+
+	u.confirmed = false
+	u.rejectionReason = reason
 	u.updatedAt = at
 
 	return u.checkInvariants()
@@ -72,6 +143,18 @@ func (u *University) checkInvariants() error {
 		return fmt.Errorf("%w: invalid title length", ErrInvariantViolated)
 	}
 
+	if err := checkEmail(u.email, u.emailVerified); err != nil {
+		return err
+	}
+
+	if u.confirmed && u.rejectionReason != "" {
+		return fmt.Errorf("%w: confirmed university with rejection reason", ErrInvariantViolated)
+	}
+
+	if len(u.rejectionReason) > 2048 {
+		return fmt.Errorf("%w: invalid rejection reason length", ErrInvariantViolated)
+	}
+
 	if u.createdAt.IsZero() {
 		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
 	}
@@ -94,6 +177,7 @@ func CreateUniversity(attrs CreateUniversityAttrs, at time.Time) (*University, e
 		login:        attrs.Login,
 		passwordHash: attrs.PasswordHash,
 		inn:          attrs.INN,
+		email:        attrs.Email,
 		confirmed:    false,
 		createdAt:    at,
 		updatedAt:    at,
@@ -104,14 +188,17 @@ func CreateUniversity(attrs CreateUniversityAttrs, at time.Time) (*University, e
 
 func ReconstructUniversity(immutable UniversityImmutable) (*University, error) {
 	u := &University{
-		id:           immutable.ID,
-		title:        immutable.Title,
-		login:        immutable.Login,
-		passwordHash: immutable.PasswordHash,
-		inn:          immutable.INN,
-		confirmed:    immutable.Confirmed,
-		createdAt:    immutable.CreatedAt,
-		updatedAt:    immutable.UpdatedAt,
+		id:              immutable.ID,
+		title:           immutable.Title,
+		login:           immutable.Login,
+		passwordHash:    immutable.PasswordHash,
+		inn:             immutable.INN,
+		email:           immutable.Email,
+		emailVerified:   immutable.EmailVerified,
+		confirmed:       immutable.Confirmed,
+		rejectionReason: immutable.RejectionReason,
+		createdAt:       immutable.CreatedAt,
+		updatedAt:       immutable.UpdatedAt,
 	}
 
 	return u, u.checkInvariants()
@@ -2,11 +2,44 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// VacancyState — этап модерации вакансии. В каталоге видны только
+// опубликованные вакансии.
+type VacancyState string
+
+const (
+	VacancyDraft     VacancyState = "draft"
+	VacancyOnReview  VacancyState = "on_review"
+	VacancyPublished VacancyState = "published"
+	VacancyRejected  VacancyState = "rejected"
+	VacancyArchived  VacancyState = "archived"
+)
+
+// vacancyTransitions — допустимые переходы между состояниями. Снятая с
+// публикации вакансия возвращается в черновик и заново проходит модерацию.
+var vacancyTransitions = map[VacancyState][]VacancyState{
+	VacancyDraft:     {VacancyOnReview, VacancyArchived},
+	VacancyOnReview:  {VacancyPublished, VacancyRejected},
+	VacancyPublished: {VacancyArchived},
+	VacancyRejected:  {VacancyOnReview, VacancyArchived},
+	VacancyArchived:  {VacancyDraft},
+}
+
+// ParseVacancyState восстанавливает состояние вакансии из строки.
+func ParseVacancyState(s string) (VacancyState, error) {
+	switch st := VacancyState(s); st {
+	case VacancyDraft, VacancyOnReview, VacancyPublished, VacancyRejected, VacancyArchived:
+		return st, nil
+	default:
+		return "", fmt.Errorf("%w: unknown vacancy state %q", ErrInvariantViolated, s)
+	}
+}
+
 type (
 	Vacancy struct {
 		id          uuid.UUID
@@ -19,7 +52,7 @@ type (
 		responsibilities string
 		conditions       string
 
-		salary *int
+		salary *Salary
 
 		employment string
 		schedule   string
@@ -27,9 +60,11 @@ type (
 		education  string
 		location   string
 
-		isActive  bool
-		createdAt time.Time
-		updatedAt time.Time
+		state VacancyState
+		// Комментарий модератора к отклонению
+		moderationComment string
+		createdAt         time.Time
+		updatedAt         time.Time
 	}
 
 	VacancyImmutable struct {
@@ -41,15 +76,17 @@ type (
 		Requirements     string
 		Responsibilities string
 		Conditions       string
-		Salary           *int
+		Salary           *Salary
 		Employment       string
 		Schedule         string
 		Experience       string
 		Education        string
 		Location         string
-		IsActive         bool
-		CreatedAt        time.Time
-		UpdatedAt        time.Time
+		State            VacancyState
+		// Заполнен у отклонённой вакансии
+		ModerationComment string
+		CreatedAt         time.Time
+		UpdatedAt         time.Time
 	}
 
 	CreateVacancyAttrs struct {
@@ -60,7 +97,7 @@ type (
 		Requirements     string
 		Responsibilities string
 		Conditions       string
-		Salary           *int
+		Salary           *Salary
 		Employment       string
 		Schedule         string
 		Experience       string
@@ -68,6 +105,16 @@ type (
 		Location         string
 	}
 
+	// Salary — зарплатная вилка. Любая из границ может быть не указана,
+	// но хотя бы одна обязательна.
+	Salary struct {
+		Min      *int
+		Max      *int
+		Currency string
+		// Gross — сумма до вычета НДФЛ
+		Gross bool
+	}
+
 	VacancyFilter struct {
 		CompanyID  *uuid.UUID
 		Location   *string
@@ -75,31 +122,36 @@ type (
 		Schedule   *string
 		Experience *string
 		Education  *string
-		IsActive   *bool
-		Limit      int
-		Offset     int
+		State      *VacancyState
+		// Ожидаемая зарплата должна попадать в вилку вакансии
+		Salary         *int
+		SalaryCurrency *string
+		// Только вакансии компаний с заданным статусом одобрения
+		CompanyApproved *bool
+		Page            PageRequest
 	}
 )
 
 func (v *Vacancy) Immutable() VacancyImmutable {
 	return VacancyImmutable{
-		ID:               v.id,
-		CompanyID:        v.companyID,
-		Title:            v.title,
-		Description:      v.description,
-		Contacts:         v.contacts,
-		Requirements:     v.requirements,
-		Responsibilities: v.responsibilities,
-		Conditions:       v.conditions,
-		Salary:           v.salary,
-		Employment:       v.employment,
-		Schedule:         v.schedule,
-		Experience:       v.experience,
-		Education:        v.education,
-		Location:         v.location,
-		IsActive:         v.isActive,
-		CreatedAt:        v.createdAt,
-		UpdatedAt:        v.updatedAt,
+		ID:                v.id,
+		CompanyID:         v.companyID,
+		Title:             v.title,
+		Description:       v.description,
+		Contacts:          v.contacts,
+		Requirements:      v.requirements,
+		Responsibilities:  v.responsibilities,
+		Conditions:        v.conditions,
+		Salary:            v.salary,
+		Employment:        v.employment,
+		Schedule:          v.schedule,
+		Experience:        v.experience,
+		Education:         v.education,
+		Location:          v.location,
+		State:             v.state,
+		ModerationComment: v.moderationComment,
+		CreatedAt:         v.createdAt,
+		UpdatedAt:         v.updatedAt,
 	}
 }
 
@@ -116,9 +168,20 @@ func (v *Vacancy) checkInvariants() error {
 	if l := len(v.description); l < 1 {
 		return fmt.Errorf("%w: empty description", ErrInvariantViolated)
 	}
-	
-	if v.salary != nil && *v.salary < 0 {
-		return fmt.Errorf("%w: negative salary", ErrInvariantViolated)
+
+	if v.salary != nil {
+		if err := v.salary.validate(); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseVacancyState(string(v.state)); err != nil {
+		return err
+	}
+	if v.state == VacancyRejected && v.moderationComment == "" {
+		return fmt.Errorf("%w: rejected vacancy without moderation comment", ErrInvariantViolated)
+	}
+	if len(v.moderationComment) > 2048 {
+		return fmt.Errorf("%w: invalid moderation comment length", ErrInvariantViolated)
 	}
 	if v.createdAt.IsZero() {
 		return fmt.Errorf("%w: zero creation time", ErrInvariantViolated)
@@ -148,7 +211,7 @@ func CreateVacancy(attrs CreateVacancyAttrs, at time.Time) (*Vacancy, error) {
 		Experience:       attrs.Experience,
 		Education:        attrs.Education,
 		Location:         attrs.Location,
-		IsActive:         true,
+		State:            VacancyDraft,
 		CreatedAt:        at,
 		UpdatedAt:        at,
 	}
@@ -157,29 +220,36 @@ func CreateVacancy(attrs CreateVacancyAttrs, at time.Time) (*Vacancy, error) {
 
 func ReconstructVacancy(immutable VacancyImmutable) (*Vacancy, error) {
 	v := &Vacancy{
-		id:               immutable.ID,
-		companyID:        immutable.CompanyID,
-		title:            immutable.Title,
-		description:      immutable.Description,
-		contacts:         immutable.Contacts,
-		requirements:     immutable.Requirements,
-		responsibilities: immutable.Responsibilities,
-		conditions:       immutable.Conditions,
-		salary:           immutable.Salary,
-		employment:       immutable.Employment,
-		schedule:         immutable.Schedule,
-		experience:       immutable.Experience,
-		education:        immutable.Education,
-		location:         immutable.Location,
-		isActive:         immutable.IsActive,
-		createdAt:        immutable.CreatedAt,
-		updatedAt:        immutable.UpdatedAt,
+		id:                immutable.ID,
+		companyID:         immutable.CompanyID,
+		title:             immutable.Title,
+		description:       immutable.Description,
+		contacts:          immutable.Contacts,
+		requirements:      immutable.Requirements,
+		responsibilities:  immutable.Responsibilities,
+		conditions:        immutable.Conditions,
+		salary:            immutable.Salary,
+		employment:        immutable.Employment,
+		schedule:          immutable.Schedule,
+		experience:        immutable.Experience,
+		education:         immutable.Education,
+		location:          immutable.Location,
+		state:             immutable.State,
+		moderationComment: immutable.ModerationComment,
+		createdAt:         immutable.CreatedAt,
+		updatedAt:         immutable.UpdatedAt,
 	}
 	return v, v.checkInvariants()
 }
 
 // Мутации через Immutable + Reconstruct
+
+// Update меняет содержание вакансии. Править можно только черновик и
+// отклонённую вакансию: иначе в каталог попал бы непроверенный текст.
 func (v *Vacancy) Update(patch CreateVacancyAttrs, at time.Time) (*Vacancy, error) {
+	if v.state != VacancyDraft && v.state != VacancyRejected {
+		return nil, fmt.Errorf("%w: vacancy in state %q cannot be edited", ErrConflict, v.state)
+	}
 	imm := v.Immutable()
 	if patch.Title != "" {
 		imm.Title = patch.Title
@@ -221,16 +291,59 @@ func (v *Vacancy) Update(patch CreateVacancyAttrs, at time.Time) (*Vacancy, erro
 	return ReconstructVacancy(imm)
 }
 
-func (v *Vacancy) Activate(at time.Time) (*Vacancy, error) {
-	imm := v.Immutable()
-	imm.IsActive = true
-	imm.UpdatedAt = at
-	return ReconstructVacancy(imm)
+// Submit отправляет черновик или исправленную после отклонения вакансию
+// на модерацию.
+func (v *Vacancy) Submit(at time.Time) (*Vacancy, error) {
+	return v.transition(VacancyOnReview, "", at)
 }
 
-func (v *Vacancy) Deactivate(at time.Time) (*Vacancy, error) {
+func (v *Vacancy) Publish(at time.Time) (*Vacancy, error) {
+	return v.transition(VacancyPublished, "", at)
+}
+
+func (v *Vacancy) Reject(comment string, at time.Time) (*Vacancy, error) {
+	if comment == "" {
+		return nil, fmt.Errorf("%w: empty moderation comment", ErrInvariantViolated)
+	}
+	return v.transition(VacancyRejected, comment, at)
+}
+
+// Archive снимает вакансию с публикации; комментарий модератора сохраняется.
+func (v *Vacancy) Archive(at time.Time) (*Vacancy, error) {
+	return v.transition(VacancyArchived, v.moderationComment, at)
+}
+
+// Restore возвращает архивную вакансию в черновики.
+func (v *Vacancy) Restore(at time.Time) (*Vacancy, error) {
+	return v.transition(VacancyDraft, "", at)
+}
+
+func (v *Vacancy) transition(to VacancyState, comment string, at time.Time) (*Vacancy, error) {
+	if !slices.Contains(vacancyTransitions[v.state], to) {
+		return nil, fmt.Errorf("%w: vacancy cannot move from %q to %q", ErrConflict, v.state, to)
+	}
 	imm := v.Immutable()
-	imm.IsActive = false
+	imm.State = to
+	imm.ModerationComment = comment
 	imm.UpdatedAt = at
 	return ReconstructVacancy(imm)
 }
+
+func (s Salary) validate() error {
+	if s.Min == nil && s.Max == nil {
+		return fmt.Errorf("%w: salary without bounds", ErrInvariantViolated)
+	}
+	if s.Min != nil && *s.Min < 0 {
+		return fmt.Errorf("%w: negative salary min", ErrInvariantViolated)
+	}
+	if s.Max != nil && *s.Max < 0 {
+		return fmt.Errorf("%w: negative salary max", ErrInvariantViolated)
+	}
+	if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+		return fmt.Errorf("%w: salary min is greater than max", ErrInvariantViolated)
+	}
+	if len(s.Currency) != 3 {
+		return fmt.Errorf("%w: invalid salary currency", ErrInvariantViolated)
+	}
+	return nil
+}
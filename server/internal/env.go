@@ -1,14 +1,51 @@
 package internal
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	envparser "github.com/caarlos0/env/v11"
 )
 
 type environment struct {
-	SecretKey   string `env:"SECRET_KEY,required"`
-	PostgresDSN string `env:"POSTGRES_DSN,required"`
+	// SECRET_KEY используется, только если не задан JWT_PRIVATE_KEY_FILE
+	SecretKey         string        `env:"SECRET_KEY"`
+	JWTPrivateKeyFile string        `env:"JWT_PRIVATE_KEY_FILE"`
+	JWTPublicKeyFiles []string      `env:"JWT_PUBLIC_KEY_FILES" envSeparator:","`
+	PostgresDSN       string        `env:"POSTGRES_DSN,required"`
+	AccessTokenTTL    time.Duration `env:"ACCESS_TOKEN_TTL" envDefault:"15m"`
+	RefreshTokenTTL   time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"720h"`
+	// log — в лог приложения, file — дописывать в NOTIFIER_FILE
+	Notifier         string        `env:"NOTIFIER" envDefault:"log"`
+	NotifierFile     string        `env:"NOTIFIER_FILE" envDefault:"outbox.txt"`
+	PasswordResetURL string        `env:"PASSWORD_RESET_URL" envDefault:"http://localhost:3000/reset-password?token="`
+	PasswordResetTTL time.Duration `env:"PASSWORD_RESET_TTL" envDefault:"1h"`
+	EmailVerifyURL   string        `env:"EMAIL_VERIFY_URL" envDefault:"http://localhost:3000/verify-email?token="`
+	EmailVerifyTTL   time.Duration `env:"EMAIL_VERIFY_TTL" envDefault:"24h"`
+	CompanyInviteURL string        `env:"COMPANY_INVITE_URL" envDefault:"http://localhost:3000/accept-invitation?token="`
+	CompanyInviteTTL time.Duration `env:"COMPANY_INVITE_TTL" envDefault:"72h"`
+	// postgres — общий для всех экземпляров, memory — только для одного
+	LoginAttemptStore string `env:"LOGIN_ATTEMPT_STORE" envDefault:"postgres"`
+	// Адреса или CIDR прокси, чьему X-Forwarded-For можно верить; пусто —
+	// IP клиента берётся из соединения
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
+	// Классы символов не требуются по умолчанию: длина и проверка по
+	// списку утечек защищают лучше навязанных правил состава
+	PasswordMinLength     int  `env:"PASSWORD_MIN_LENGTH" envDefault:"8"`
+	PasswordMaxLength     int  `env:"PASSWORD_MAX_LENGTH" envDefault:"64"`
+	PasswordRequireLower  bool `env:"PASSWORD_REQUIRE_LOWER" envDefault:"false"`
+	PasswordRequireUpper  bool `env:"PASSWORD_REQUIRE_UPPER" envDefault:"false"`
+	PasswordRequireDigit  bool `env:"PASSWORD_REQUIRE_DIGIT" envDefault:"false"`
+	PasswordRequireSymbol bool `env:"PASSWORD_REQUIRE_SYMBOL" envDefault:"false"`
+	PasswordRejectCommon  bool `env:"PASSWORD_REJECT_COMMON" envDefault:"true"`
+	PasswordRejectSimilar bool `env:"PASSWORD_REJECT_SIMILAR" envDefault:"true"`
+	// JSON вида {"company.recruiter": ["vacancy.view", ...]}; перечисленные
+	// роли получают ровно указанные права, остальные — права по умолчанию
+	RolePermissionsFile string `env:"ROLE_PERMISSIONS_FILE"`
+	// Название в приложении-аутентификаторе и срок промежуточного токена входа
+	TwoFactorIssuer       string        `env:"TWO_FACTOR_ISSUER" envDefault:"HR Platform Mosprom"`
+	TwoFactorChallengeTTL time.Duration `env:"TWO_FACTOR_CHALLENGE_TTL" envDefault:"5m"`
 }
 
 func LoadEnv() (environment, error) {
@@ -19,5 +56,13 @@ func LoadEnv() (environment, error) {
 		return environment{}, fmt.Errorf("error parsing config: %w", err)
 	}
 
+	if env.SecretKey == "" && env.JWTPrivateKeyFile == "" {
+		return environment{}, errors.New("error parsing config: either SECRET_KEY or JWT_PRIVATE_KEY_FILE is required")
+	}
+
+	if env.PasswordMinLength < 1 || (env.PasswordMaxLength > 0 && env.PasswordMaxLength < env.PasswordMinLength) {
+		return environment{}, errors.New("error parsing config: invalid PASSWORD_MIN_LENGTH/PASSWORD_MAX_LENGTH")
+	}
+
 	return env, nil
 }
@@ -1,22 +1,36 @@
 package internal
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/hr-platform-mosprom/internal/adapter/argon2"
 	"github.com/hr-platform-mosprom/internal/adapter/bcrypt"
 	"github.com/hr-platform-mosprom/internal/adapter/ginhandler"
 	"github.com/hr-platform-mosprom/internal/adapter/jwt"
+	"github.com/hr-platform-mosprom/internal/adapter/memory"
+	"github.com/hr-platform-mosprom/internal/adapter/notifier"
 	"github.com/hr-platform-mosprom/internal/adapter/postgres"
 	"github.com/hr-platform-mosprom/internal/adapter/postgres/pgqueries"
 	clock "github.com/hr-platform-mosprom/internal/adapter/time"
+	"github.com/hr-platform-mosprom/internal/adapter/totp"
+	"github.com/hr-platform-mosprom/internal/core/application/port"
 	"github.com/hr-platform-mosprom/internal/core/application/service"
 )
 
+// Первые 5 неудач по логину и 50 с одного IP в течение часа проходят без
+// задержки, далее задержка удваивается от секунды до 15 минут.
+var loginThrottlerConfig = service.LoginThrottlerConfig{
+	PerLogin: service.LoginThrottlePolicy{FreeAttempts: 5, BaseDelay: time.Second, MaxDelay: 15 * time.Minute},
+	PerIP:    service.LoginThrottlePolicy{FreeAttempts: 50, BaseDelay: time.Second, MaxDelay: 15 * time.Minute},
+	Window:   time.Hour,
+}
+
 func Run(env environment) error {
 	fmt.Println("hello world")
 
@@ -30,14 +44,105 @@ func Run(env environment) error {
 
 	utcClock := clock.NewUTCClock()
 	postgresUniversityRepo := postgres.NewUniversityRepo(queries)
-	jwtService := jwt.NewJWTService(utcClock, time.Hour*72, env.SecretKey)
-	bcryptPasswordService := bcrypt.NewBcryptPasswordService(12)
+	tokenKeys, err := loadTokenKeys(env)
+	if err != nil {
+		return err
+	}
+	jwtService := jwt.NewJWTService(utcClock, env.AccessTokenTTL, tokenKeys)
+	postgresSessionRepo := postgres.NewSessionRepo(db)
+	postgresAuditEventRepo := postgres.NewAuditEventRepo(queries)
+	auditLog := service.NewAuditLog(postgresAuditEventRepo, utcClock)
+	sessionService := service.NewSessionService(service.SessionServiceDeps{
+		SessionRepo:     postgresSessionRepo,
+		TokenService:    jwtService,
+		AuditLog:        auditLog,
+		Clock:           utcClock,
+		RefreshTokenTTL: env.RefreshTokenTTL,
+	})
+	passwordService := newPasswordService()
+	passwordPolicy := newPasswordPolicy(env)
+	loginAttemptStore, err := newLoginAttemptStore(env, queries, utcClock)
+	if err != nil {
+		return err
+	}
+	loginThrottler := service.NewLoginThrottler(loginAttemptStore, utcClock, loginThrottlerConfig)
+	postgresCompanyRepo := postgres.NewCompanyRepo(queries)
+	postgresCompanyMemberRepo := postgres.NewCompanyMemberRepo(db)
+	rolePermissions, err := loadRolePermissions(env)
+	if err != nil {
+		return err
+	}
+	authorizer, err := service.NewAuthorizer(postgresCompanyMemberRepo, rolePermissions)
+	if err != nil {
+		return fmt.Errorf("error creating authorizer: %w", err)
+	}
 	universityService := service.NewUniversityService(
 		postgresUniversityRepo,
-		bcryptPasswordService,
-		jwtService,
+		passwordService,
+		passwordPolicy,
+		sessionService,
+		loginThrottler,
+		authorizer,
+		auditLog,
+		utcClock,
+	)
+	postgresAdminRepo := postgres.NewAdminRepo(queries)
+	postgresOneTimeTokenRepo := postgres.NewOneTimeTokenRepo(queries)
+	postgresTwoFactorRepo := postgres.NewTwoFactorRepo(db)
+	twoFactorService := service.NewTwoFactorService(service.TwoFactorServiceDeps{
+		TwoFactorRepo:   postgresTwoFactorRepo,
+		RequirementRepo: postgresTwoFactorRepo,
+		TokenRepo:       postgresOneTimeTokenRepo,
+		MemberRepo:      postgresCompanyMemberRepo,
+		AdminRepo:       postgresAdminRepo,
+		TOTP:            totp.NewTOTP(1),
+		SessionService:  sessionService,
+		LoginThrottler:  loginThrottler,
+		Authorizer:      authorizer,
+		AuditLog:        auditLog,
+		Clock:           utcClock,
+		Issuer:          env.TwoFactorIssuer,
+		ChallengeTTL:    env.TwoFactorChallengeTTL,
+	})
+	companyService := service.NewCompanyService(service.CompanyServiceDeps{
+		CompanyRepo:     postgresCompanyRepo,
+		MemberRepo:      postgresCompanyMemberRepo,
+		PasswordService: passwordService,
+		PasswordPolicy:  passwordPolicy,
+		SessionService:  sessionService,
+		LoginThrottler:  loginThrottler,
+		TwoFactor:       twoFactorService,
+		Authorizer:      authorizer,
+		AuditLog:        auditLog,
+		Clock:           utcClock,
+	})
+
+	postgresVacancyRepo := postgres.NewVacancyRepo(queries)
+	vacancyService := service.NewVacancyService(postgresVacancyRepo, postgresCompanyRepo, authorizer, auditLog, utcClock)
+	postgresPipelineRepo := postgres.NewPipelineRepo(db)
+	pipelineService := service.NewPipelineService(postgresPipelineRepo, postgresVacancyRepo, authorizer, auditLog, utcClock)
+	postgresResponseRepo := postgres.NewResponseRepo(db)
+	responseService := service.NewResponseService(
+		postgresResponseRepo,
+		postgresVacancyRepo,
+		postgresCompanyRepo,
+		postgresPipelineRepo,
+		authorizer,
+		auditLog,
 		utcClock,
 	)
+	auditService := service.NewAuditService(postgresAuditEventRepo, authorizer)
+
+	adminService := service.NewAdminService(service.AdminServiceDeps{
+		AdminRepo:       postgresAdminRepo,
+		PasswordService: passwordService,
+		PasswordPolicy:  passwordPolicy,
+		SessionService:  sessionService,
+		TwoFactor:       twoFactorService,
+		LoginThrottler:  loginThrottler,
+		AuditLog:        auditLog,
+		Clock:           utcClock,
+	})
 
 	validator := validator.New()
 	logger := slog.New(
@@ -52,11 +157,154 @@ func Run(env environment) error {
 		),
 	)
 
-	engine := gin.Default()
+	messageNotifier, err := newNotifier(env, logger)
+	if err != nil {
+		return err
+	}
+	passwordResetService := service.NewPasswordResetService(service.PasswordResetServiceDeps{
+		MemberRepo:      postgresCompanyMemberRepo,
+		UniversityRepo:  postgresUniversityRepo,
+		TokenRepo:       postgresOneTimeTokenRepo,
+		PasswordService: passwordService,
+		PasswordPolicy:  passwordPolicy,
+		SessionService:  sessionService,
+		Notifier:        messageNotifier,
+		AuditLog:        auditLog,
+		Clock:           utcClock,
+		TokenTTL:        env.PasswordResetTTL,
+		ResetURL:        env.PasswordResetURL,
+	})
+	emailVerificationService := service.NewEmailVerificationService(service.EmailVerificationServiceDeps{
+		CompanyRepo:    postgresCompanyRepo,
+		MemberRepo:     postgresCompanyMemberRepo,
+		UniversityRepo: postgresUniversityRepo,
+		TokenRepo:      postgresOneTimeTokenRepo,
+		Authorizer:     authorizer,
+		Notifier:       messageNotifier,
+		AuditLog:       auditLog,
+		Clock:          utcClock,
+		TokenTTL:       env.EmailVerifyTTL,
+		VerifyURL:      env.EmailVerifyURL,
+	})
+	companyMemberService := service.NewCompanyMemberService(service.CompanyMemberServiceDeps{
+		MemberRepo:      postgresCompanyMemberRepo,
+		InvitationRepo:  postgres.NewCompanyInvitationRepo(db),
+		CompanyRepo:     postgresCompanyRepo,
+		PasswordService: passwordService,
+		PasswordPolicy:  passwordPolicy,
+		SessionService:  sessionService,
+		Notifier:        messageNotifier,
+		Authorizer:      authorizer,
+		AuditLog:        auditLog,
+		Clock:           utcClock,
+		InviteTTL:       env.CompanyInviteTTL,
+		InviteURL:       env.CompanyInviteURL,
+	})
+
+	apiKeyService := service.NewAPIKeyService(service.APIKeyServiceDeps{
+		APIKeyRepo:     postgres.NewAPIKeyRepo(queries),
+		UniversityRepo: postgresUniversityRepo,
+		Authorizer:     authorizer,
+		AuditLog:       auditLog,
+		Clock:          utcClock,
+	})
+
+	// Без явного списка прокси ограничение попыток входа по IP и адрес в
+	// журнале аудита обходились бы подменой X-Forwarded-For
+	engine, err := ginhandler.NewEngine(env.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("error creating gin engine: %w", err)
+	}
+
+	authMiddleware := ginhandler.NewAuthMiddleware(
+		service.NewSessionTokenService(jwtService, postgresSessionRepo, utcClock),
+		apiKeyService,
+		twoFactorService,
+		logger,
+	)
+
+	ginhandler.RegisterAuthHandlers(
+		engine,
+		sessionService,
+		passwordResetService,
+		emailVerificationService,
+		jwtService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+
+	ginhandler.RegisterTwoFactorHandlers(
+		engine,
+		twoFactorService,
+		authMiddleware,
+		logger,
+		validator,
+	)
 
 	ginhandler.RegisterUniversityHandlers(
 		engine,
 		universityService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterAPIKeyHandlers(
+		engine,
+		apiKeyService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterCompanyHandlers(
+		engine,
+		companyService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterCompanyMemberHandlers(
+		engine,
+		companyMemberService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterVacancyHandlers(
+		engine,
+		vacancyService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterPipelineHandlers(
+		engine,
+		pipelineService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterResponseHandlers(
+		engine,
+		responseService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterAdminHandlers(
+		engine,
+		adminService,
+		companyService,
+		universityService,
+		vacancyService,
+		authMiddleware,
+		logger,
+		validator,
+	)
+	ginhandler.RegisterAuditHandlers(
+		engine,
+		auditService,
+		authMiddleware,
 		logger,
 		validator,
 	)
@@ -68,3 +316,109 @@ func Run(env environment) error {
 
 	return nil
 }
+
+// loadTokenKeys выбирает асимметричные ключи, если задан JWT_PRIVATE_KEY_FILE,
+// иначе HS256 с SECRET_KEY.
+func loadTokenKeys(env environment) (*jwt.KeySet, error) {
+	if env.JWTPrivateKeyFile == "" {
+		return jwt.NewHMACKeySet(env.SecretKey), nil
+	}
+
+	keys, err := jwt.LoadKeySet(env.JWTPrivateKeyFile, env.JWTPublicKeyFiles)
+	if err != nil {
+		return nil, fmt.Errorf("error loading jwt keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// loadRolePermissions накладывает матрицу прав из ROLE_PERMISSIONS_FILE на
+// матрицу по умолчанию.
+func loadRolePermissions(env environment) (service.RolePermissions, error) {
+	permissions := service.DefaultRolePermissions()
+	if env.RolePermissionsFile == "" {
+		return permissions, nil
+	}
+
+	data, err := os.ReadFile(env.RolePermissionsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading role permissions: %w", err)
+	}
+
+	var overrides service.RolePermissions
+	err = json.Unmarshal(data, &overrides)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing role permissions: %w", err)
+	}
+
+	for role, rolePermissions := range overrides {
+		permissions[role] = rolePermissions
+	}
+
+	return permissions, nil
+}
+
+func newNotifier(env environment, logger *slog.Logger) (port.Notifier, error) {
+	switch env.Notifier {
+	case "log":
+		return notifier.NewLogNotifier(logger), nil
+	case "file":
+		return notifier.NewFileNotifier(env.NotifierFile), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", env.Notifier)
+	}
+}
+
+func newLoginAttemptStore(env environment, queries *pgqueries.Queries, clock port.Clock) (port.LoginAttemptStore, error) {
+	switch env.LoginAttemptStore {
+	case "memory":
+		return memory.NewLoginAttemptStore(), nil
+	case "postgres":
+		store := postgres.NewLoginAttemptStore(queries)
+
+		// Устаревшие счётчики больше не влияют на вход, их можно удалять
+		go func() {
+			ticker := time.NewTicker(loginThrottlerConfig.Window)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				err := store.DeleteStale(context.Background(), clock.Now().Add(-loginThrottlerConfig.Window))
+				if err != nil {
+					slog.Error("error deleting stale login attempts", "err", err)
+				}
+			}
+		}()
+
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown login attempt store %q", env.LoginAttemptStore)
+	}
+}
+
+// newPasswordService хеширует новые пароли Argon2id (параметры по
+// рекомендации OWASP), а bcrypt-хеши принимает до пересчёта при входе.
+func newPasswordService() port.PasswordService {
+	return service.NewPasswordServiceChain(
+		argon2.NewArgon2idPasswordService(argon2.Params{
+			Memory:      19 * 1024,
+			Iterations:  2,
+			Parallelism: 1,
+			SaltLength:  16,
+			KeyLength:   32,
+		}),
+		bcrypt.NewBcryptPasswordService(12),
+	)
+}
+
+func newPasswordPolicy(env environment) port.PasswordPolicy {
+	return service.NewPasswordPolicy(service.PasswordPolicyConfig{
+		MinLength:     env.PasswordMinLength,
+		MaxLength:     env.PasswordMaxLength,
+		RequireLower:  env.PasswordRequireLower,
+		RequireUpper:  env.PasswordRequireUpper,
+		RequireDigit:  env.PasswordRequireDigit,
+		RequireSymbol: env.PasswordRequireSymbol,
+		RejectCommon:  env.PasswordRejectCommon,
+		RejectSimilar: env.PasswordRejectSimilar,
+	})
+}
@@ -0,0 +1,15 @@
+-- Up
+
+ALTER TABLE companies ADD COLUMN rejection_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE universities ADD COLUMN rejection_reason TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX universities_confirmed_idx ON universities(confirmed);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP INDEX IF EXISTS universities_confirmed_idx;
+
+ALTER TABLE universities DROP COLUMN IF EXISTS rejection_reason;
+ALTER TABLE companies DROP COLUMN IF EXISTS rejection_reason;
@@ -0,0 +1,15 @@
+-- Up
+
+CREATE TABLE admins (
+    id UUID PRIMARY KEY,
+    login VARCHAR(256) NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS admins;
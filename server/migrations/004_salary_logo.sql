@@ -0,0 +1,28 @@
+-- Up
+
+ALTER TABLE vacancies
+    ADD COLUMN salary_min INTEGER CHECK (salary_min >= 0),
+    ADD COLUMN salary_max INTEGER CHECK (salary_max >= 0),
+    ADD COLUMN salary_currency VARCHAR(3),
+    ADD COLUMN salary_gross BOOLEAN,
+    ADD CONSTRAINT vacancies_salary_range_check
+        CHECK (salary_min IS NULL OR salary_max IS NULL OR salary_min <= salary_max);
+
+CREATE INDEX vacancies_salary_idx ON vacancies(salary_min, salary_max);
+
+ALTER TABLE companies ADD COLUMN logo_url TEXT NOT NULL DEFAULT '';
+
+---- create above / drop below ----
+
+-- Down
+
+ALTER TABLE companies DROP COLUMN IF EXISTS logo_url;
+
+DROP INDEX IF EXISTS vacancies_salary_idx;
+
+ALTER TABLE vacancies
+    DROP CONSTRAINT IF EXISTS vacancies_salary_range_check,
+    DROP COLUMN IF EXISTS salary_gross,
+    DROP COLUMN IF EXISTS salary_currency,
+    DROP COLUMN IF EXISTS salary_max,
+    DROP COLUMN IF EXISTS salary_min;
@@ -0,0 +1,11 @@
+-- Up
+
+CREATE INDEX vacancies_created_idx ON vacancies(created_at DESC, id DESC);
+CREATE INDEX responses_vacancy_created_idx ON responses(vacancy_id, created_at DESC, id DESC);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP INDEX IF EXISTS responses_vacancy_created_idx;
+DROP INDEX IF EXISTS vacancies_created_idx;
@@ -0,0 +1,22 @@
+-- Up
+
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY,
+    family_id UUID NOT NULL,
+    subject_id UUID NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    refresh_token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    rotated_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX sessions_family_idx ON sessions(family_id);
+CREATE INDEX sessions_subject_idx ON sessions(subject_id);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS sessions;
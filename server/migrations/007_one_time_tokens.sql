@@ -0,0 +1,20 @@
+-- Up
+
+CREATE TABLE one_time_tokens (
+    id UUID PRIMARY KEY,
+    purpose VARCHAR(32) NOT NULL,
+    subject_id UUID NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX one_time_tokens_subject_idx ON one_time_tokens(subject_id, purpose);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS one_time_tokens;
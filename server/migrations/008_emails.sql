@@ -0,0 +1,22 @@
+-- Up
+
+-- Существующие аккаунты получают пустой неподтверждённый адрес
+ALTER TABLE companies
+    ADD COLUMN email VARCHAR(254) NOT NULL DEFAULT '',
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE universities
+    ADD COLUMN email VARCHAR(254) NOT NULL DEFAULT '',
+    ADD COLUMN email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+---- create above / drop below ----
+
+-- Down
+
+ALTER TABLE universities
+    DROP COLUMN IF EXISTS email_verified,
+    DROP COLUMN IF EXISTS email;
+
+ALTER TABLE companies
+    DROP COLUMN IF EXISTS email_verified,
+    DROP COLUMN IF EXISTS email;
@@ -0,0 +1,15 @@
+-- Up
+
+CREATE TABLE login_attempts (
+    key VARCHAR(320) PRIMARY KEY,
+    failures INT NOT NULL,
+    last_failure_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX login_attempts_last_failure_at_idx ON login_attempts(last_failure_at);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS login_attempts;
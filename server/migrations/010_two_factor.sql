@@ -0,0 +1,37 @@
+-- Up
+
+CREATE TABLE two_factors (
+    subject_id UUID PRIMARY KEY,
+    role VARCHAR(32) NOT NULL,
+    secret VARCHAR(64) NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE recovery_codes (
+    id UUID PRIMARY KEY,
+    subject_id UUID NOT NULL REFERENCES two_factors(subject_id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX recovery_codes_subject_idx ON recovery_codes(subject_id);
+
+-- Обязательность 2FA по ролям; включает и выключает администратор
+CREATE TABLE two_factor_requirements (
+    role VARCHAR(32) PRIMARY KEY,
+    required BOOLEAN NOT NULL,
+    updated_by UUID NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS two_factor_requirements;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS two_factors;
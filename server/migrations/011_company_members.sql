@@ -0,0 +1,85 @@
+-- Up
+
+CREATE TABLE company_members (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    role VARCHAR(32) NOT NULL,
+    name VARCHAR(256) NOT NULL DEFAULT '',
+    email VARCHAR(254) NOT NULL DEFAULT '',
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    login VARCHAR(256) NOT NULL UNIQUE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX company_members_company_idx ON company_members(company_id);
+
+-- Учётные данные компании становятся её владельцем. Владелец получает id
+-- компании, поэтому выданные ранее токены, сессии и настройки 2FA остаются
+-- действительными.
+INSERT INTO company_members (
+    id, company_id, role, email, email_verified, login, password_hash, created_at, updated_at
+)
+SELECT id, id, 'owner', email, email_verified, login, password_hash, created_at, updated_at
+FROM companies;
+
+CREATE TABLE company_invitations (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    email VARCHAR(254) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    invited_by UUID NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX company_invitations_company_idx ON company_invitations(company_id);
+
+ALTER TABLE sessions ADD COLUMN company_id UUID;
+UPDATE sessions SET company_id = subject_id WHERE role = 'company';
+CREATE INDEX sessions_company_idx ON sessions(company_id);
+
+DROP INDEX IF EXISTS companies_login_idx;
+DROP INDEX IF EXISTS companies_representative_idx;
+ALTER TABLE companies
+    DROP COLUMN representative_id,
+    DROP COLUMN login,
+    DROP COLUMN password_hash;
+
+---- create above / drop below ----
+
+-- Down
+
+ALTER TABLE companies
+    ADD COLUMN representative_id UUID,
+    ADD COLUMN login VARCHAR(256) UNIQUE,
+    ADD COLUMN password_hash TEXT;
+
+-- Компании возвращаются учётные данные самого раннего владельца
+UPDATE companies c
+SET representative_id = m.id, login = m.login, password_hash = m.password_hash
+FROM (
+    SELECT DISTINCT ON (company_id) id, company_id, login, password_hash
+    FROM company_members
+    WHERE role = 'owner'
+    ORDER BY company_id, created_at
+) m
+WHERE m.company_id = c.id;
+
+ALTER TABLE companies
+    ALTER COLUMN representative_id SET NOT NULL,
+    ALTER COLUMN login SET NOT NULL,
+    ALTER COLUMN password_hash SET NOT NULL;
+
+CREATE INDEX companies_login_idx ON companies(login);
+CREATE INDEX companies_representative_idx ON companies(representative_id);
+
+DROP INDEX IF EXISTS sessions_company_idx;
+ALTER TABLE sessions DROP COLUMN company_id;
+
+DROP TABLE IF EXISTS company_invitations;
+DROP TABLE IF EXISTS company_members;
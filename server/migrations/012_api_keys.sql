@@ -0,0 +1,22 @@
+-- Up
+
+-- Ключи интеграции вузов: открыт только префикс, секрет хранится хешем
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY,
+    university_id UUID NOT NULL REFERENCES universities(id) ON DELETE CASCADE,
+    name VARCHAR(128) NOT NULL,
+    prefix VARCHAR(32) NOT NULL UNIQUE,
+    secret_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX api_keys_university_idx ON api_keys(university_id);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS api_keys;
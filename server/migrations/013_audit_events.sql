@@ -0,0 +1,43 @@
+-- Up
+
+-- Журнал аудита: записи только добавляются, изменить или удалить их
+-- не даёт триггер
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    actor_id UUID,
+    actor_role VARCHAR(32) NOT NULL,
+    api_key_id UUID,
+    action VARCHAR(64) NOT NULL,
+    target_type VARCHAR(32) NOT NULL,
+    target_id UUID,
+    before JSONB NOT NULL,
+    after JSONB NOT NULL,
+    ip VARCHAR(64) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL
+);
+
+CREATE INDEX audit_events_occurred_idx ON audit_events(occurred_at DESC, id DESC);
+CREATE INDEX audit_events_actor_idx ON audit_events(actor_id, occurred_at DESC);
+CREATE INDEX audit_events_target_idx ON audit_events(target_type, target_id, occurred_at DESC);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update_delete
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
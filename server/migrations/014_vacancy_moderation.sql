@@ -0,0 +1,28 @@
+-- Up
+
+-- Вакансии проходят модерацию: is_active заменяется состоянием. Активные
+-- вакансии остаются в каталоге, неактивные становятся черновиками.
+ALTER TABLE vacancies
+    ADD COLUMN state VARCHAR(16) NOT NULL DEFAULT 'draft',
+    ADD COLUMN moderation_comment TEXT NOT NULL DEFAULT '';
+
+UPDATE vacancies SET state = 'published' WHERE is_active;
+
+DROP INDEX IF EXISTS vacancies_active_idx;
+ALTER TABLE vacancies DROP COLUMN is_active;
+
+CREATE INDEX vacancies_state_idx ON vacancies(state, created_at DESC);
+
+---- create above / drop below ----
+
+-- Down
+
+DROP INDEX IF EXISTS vacancies_state_idx;
+
+ALTER TABLE vacancies ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE vacancies SET is_active = (state = 'published');
+CREATE INDEX vacancies_active_idx ON vacancies(is_active);
+
+ALTER TABLE vacancies
+    DROP COLUMN IF EXISTS moderation_comment,
+    DROP COLUMN IF EXISTS state;
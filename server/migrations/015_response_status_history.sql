@@ -0,0 +1,39 @@
+-- Up
+
+-- Этапы отбора вместо прежних статусов: viewed становится screening,
+-- accepted — hired
+UPDATE responses SET status = 'screening' WHERE status = 'viewed';
+UPDATE responses SET status = 'hired' WHERE status = 'accepted';
+
+CREATE TABLE response_status_history (
+    id UUID PRIMARY KEY,
+    response_id UUID NOT NULL REFERENCES responses(id) ON DELETE CASCADE,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    actor_id UUID,
+    actor_role VARCHAR(32) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX response_status_history_response_idx ON response_status_history(response_id, changed_at);
+
+-- Для прежних откликов известны только создание и текущий этап; кто их
+-- перевёл, неизвестно
+INSERT INTO response_status_history (id, response_id, from_status, to_status, actor_id, actor_role, changed_at)
+SELECT gen_random_uuid(), id, '', 'new', NULL, '', created_at
+FROM responses;
+
+INSERT INTO response_status_history (id, response_id, from_status, to_status, actor_id, actor_role, changed_at)
+SELECT gen_random_uuid(), id, 'new', status, NULL, '', updated_at
+FROM responses
+WHERE status <> 'new';
+
+---- create above / drop below ----
+
+-- Down
+
+DROP TABLE IF EXISTS response_status_history;
+
+UPDATE responses SET status = 'viewed' WHERE status IN ('screening', 'interview', 'offer');
+UPDATE responses SET status = 'accepted' WHERE status = 'hired';
+UPDATE responses SET status = 'rejected' WHERE status = 'withdrawn';
@@ -0,0 +1,46 @@
+-- Up
+
+-- Конвейеры отбора компаний: по умолчанию для компании (vacancy_id пуст) или
+-- для вакансии. Правка сохраняется новой версией, прежняя помечается
+-- replaced_at и остаётся для откликов, поданных по ней.
+CREATE TABLE pipelines (
+    id UUID PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    vacancy_id UUID REFERENCES vacancies(id) ON DELETE CASCADE,
+    stages JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    replaced_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX pipelines_current_idx
+    ON pipelines(company_id, COALESCE(vacancy_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE replaced_at IS NULL;
+
+-- Отклик закреплён за версией конвейера; пустой pipeline_id — встроенный
+-- конвейер, ключи его этапов совпадают со статусами
+ALTER TABLE responses
+    ADD COLUMN pipeline_id UUID REFERENCES pipelines(id),
+    ADD COLUMN stage VARCHAR(32) NOT NULL DEFAULT '';
+
+UPDATE responses SET stage = status;
+ALTER TABLE responses ALTER COLUMN stage DROP DEFAULT;
+
+ALTER TABLE response_status_history
+    ADD COLUMN from_stage VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN to_stage VARCHAR(32) NOT NULL DEFAULT '';
+
+UPDATE response_status_history SET from_stage = from_status, to_stage = to_status;
+
+---- create above / drop below ----
+
+-- Down
+
+ALTER TABLE response_status_history
+    DROP COLUMN IF EXISTS to_stage,
+    DROP COLUMN IF EXISTS from_stage;
+
+ALTER TABLE responses
+    DROP COLUMN IF EXISTS stage,
+    DROP COLUMN IF EXISTS pipeline_id;
+
+DROP TABLE IF EXISTS pipelines;
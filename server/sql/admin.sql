@@ -0,0 +1,32 @@
+-- name: GetAdminByID :one
+SELECT
+    id,
+    login,
+    password_hash,
+    created_at,
+    updated_at
+FROM admins
+WHERE id = @id;
+
+-- name: GetAdminByLogin :one
+SELECT
+    id,
+    login,
+    password_hash,
+    created_at,
+    updated_at
+FROM admins
+WHERE login = @login;
+
+-- name: CreateAdmin :exec
+INSERT INTO admins (id, login, password_hash, created_at, updated_at)
+VALUES (@id, @login, @password_hash, @created_at, @updated_at);
+
+-- name: UpdateAdmin :exec
+UPDATE admins
+SET
+    login = @login,
+    password_hash = @password_hash,
+    created_at = @created_at,
+    updated_at = @updated_at
+WHERE id = @id;
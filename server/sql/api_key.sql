@@ -0,0 +1,59 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (
+    id, university_id, name, prefix, secret_hash, scopes, last_used_at, created_at, revoked_at
+) VALUES (
+    @id, @university_id, @name, @prefix, @secret_hash, @scopes, @last_used_at, @created_at, @revoked_at
+);
+
+-- name: GetAPIKeyByID :one
+SELECT
+    id,
+    university_id,
+    name,
+    prefix,
+    secret_hash,
+    scopes,
+    last_used_at,
+    created_at,
+    revoked_at
+FROM api_keys
+WHERE id = @id;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT
+    id,
+    university_id,
+    name,
+    prefix,
+    secret_hash,
+    scopes,
+    last_used_at,
+    created_at,
+    revoked_at
+FROM api_keys
+WHERE prefix = @prefix;
+
+-- name: ListAPIKeysByUniversity :many
+SELECT
+    id,
+    university_id,
+    name,
+    prefix,
+    secret_hash,
+    scopes,
+    last_used_at,
+    created_at,
+    revoked_at
+FROM api_keys
+WHERE university_id = @university_id
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = @revoked_at
+WHERE id = @id AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = @last_used_at
+WHERE id = @id;
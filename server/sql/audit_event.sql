@@ -0,0 +1,38 @@
+-- name: AppendAuditEvent :exec
+INSERT INTO audit_events (
+    id, occurred_at, actor_id, actor_role, api_key_id, action, target_type, target_id, before, after, ip, user_agent
+) VALUES (
+    @id, @occurred_at, @actor_id, @actor_role, @api_key_id, @action, @target_type, @target_id, @before, @after, @ip, @user_agent
+);
+
+-- name: ListAuditEvents :many
+SELECT
+    id,
+    occurred_at,
+    actor_id,
+    actor_role,
+    api_key_id,
+    action,
+    target_type,
+    target_id,
+    before,
+    after,
+    ip,
+    user_agent
+FROM audit_events
+WHERE (sqlc.narg('actor_id')::uuid IS NULL OR actor_id = sqlc.narg('actor_id')::uuid)
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type')::text)
+  AND (sqlc.narg('target_id')::uuid IS NULL OR target_id = sqlc.narg('target_id')::uuid)
+  AND (sqlc.narg('from')::timestamptz IS NULL OR occurred_at >= sqlc.narg('from')::timestamptz)
+  AND (sqlc.narg('to')::timestamptz IS NULL OR occurred_at < sqlc.narg('to')::timestamptz)
+ORDER BY occurred_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountAuditEvents :one
+SELECT count(*)
+FROM audit_events
+WHERE (sqlc.narg('actor_id')::uuid IS NULL OR actor_id = sqlc.narg('actor_id')::uuid)
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type')::text)
+  AND (sqlc.narg('target_id')::uuid IS NULL OR target_id = sqlc.narg('target_id')::uuid)
+  AND (sqlc.narg('from')::timestamptz IS NULL OR occurred_at >= sqlc.narg('from')::timestamptz)
+  AND (sqlc.narg('to')::timestamptz IS NULL OR occurred_at < sqlc.narg('to')::timestamptz);
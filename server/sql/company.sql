@@ -7,31 +7,15 @@ SELECT
     inn,
     address,
     approved,
-    representative_id,
-    login,
-    password_hash,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    logo_url,
+    email,
+    email_verified
 FROM companies
 WHERE id = @id;
 
--- name: GetCompanyByLogin :one
-SELECT
-    id,
-    title,
-    description,
-    contacts,
-    inn,
-    address,
-    approved,
-    representative_id,
-    login,
-    password_hash,
-    created_at,
-    updated_at
-FROM companies
-WHERE login = @login;
-
 -- name: GetCompanyByINN :one
 SELECT
     id,
@@ -41,19 +25,20 @@ SELECT
     inn,
     address,
     approved,
-    representative_id,
-    login,
-    password_hash,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    logo_url,
+    email,
+    email_verified
 FROM companies
 WHERE inn = @inn;
 
 -- name: CreateCompany :exec
 INSERT INTO companies (
-    id, title, description, contacts, inn, address, approved, representative_id, login, password_hash, created_at, updated_at
+    id, title, description, contacts, inn, address, approved, created_at, updated_at, rejection_reason, logo_url, email, email_verified
 ) VALUES (
-    @id, @title, @description, @contacts, @inn, @address, @approved, @representative_id, @login, @password_hash, @created_at, @updated_at
+    @id, @title, @description, @contacts, @inn, @address, @approved, @created_at, @updated_at, @rejection_reason, @logo_url, @email, @email_verified
 );
 
 -- name: UpdateCompany :exec
@@ -65,9 +50,40 @@ SET
     inn = @inn,
     address = @address,
     approved = @approved,
-    representative_id = @representative_id,
-    login = @login,
-    password_hash = @password_hash,
     created_at = @created_at,
-    updated_at = @updated_at
+    updated_at = @updated_at,
+    rejection_reason = @rejection_reason,
+    logo_url = @logo_url,
+    email = @email,
+    email_verified = @email_verified
 WHERE id = @id;
+
+-- name: ListCompanies :many
+SELECT
+    id,
+    title,
+    description,
+    contacts,
+    inn,
+    address,
+    approved,
+    created_at,
+    updated_at,
+    rejection_reason,
+    logo_url,
+    email,
+    email_verified
+FROM companies
+WHERE sqlc.narg('approved')::boolean IS NULL OR approved = sqlc.narg('approved')::boolean
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'title' THEN title END ASC,
+    CASE WHEN sqlc.arg('sort')::text = '-title' THEN title END DESC,
+    CASE WHEN sqlc.arg('sort')::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountCompanies :one
+SELECT count(*)
+FROM companies
+WHERE sqlc.narg('approved')::boolean IS NULL OR approved = sqlc.narg('approved')::boolean;
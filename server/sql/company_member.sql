@@ -0,0 +1,145 @@
+-- name: CreateCompanyMember :exec
+INSERT INTO company_members (
+    id, company_id, role, name, email, email_verified, login, password_hash, created_at, updated_at
+) VALUES (
+    @id, @company_id, @role, @name, @email, @email_verified, @login, @password_hash, @created_at, @updated_at
+);
+
+-- name: GetCompanyMemberByID :one
+SELECT
+    id,
+    company_id,
+    role,
+    name,
+    email,
+    email_verified,
+    login,
+    password_hash,
+    created_at,
+    updated_at
+FROM company_members
+WHERE id = @id;
+
+-- name: GetCompanyMemberByLogin :one
+SELECT
+    id,
+    company_id,
+    role,
+    name,
+    email,
+    email_verified,
+    login,
+    password_hash,
+    created_at,
+    updated_at
+FROM company_members
+WHERE login = @login;
+
+-- name: ListCompanyMembers :many
+SELECT
+    id,
+    company_id,
+    role,
+    name,
+    email,
+    email_verified,
+    login,
+    password_hash,
+    created_at,
+    updated_at
+FROM company_members
+WHERE company_id = @company_id
+ORDER BY created_at, id;
+
+-- name: UpdateCompanyMember :exec
+UPDATE company_members
+SET
+    role = @role,
+    name = @name,
+    email = @email,
+    email_verified = @email_verified,
+    login = @login,
+    password_hash = @password_hash,
+    updated_at = @updated_at
+WHERE id = @id;
+
+-- name: DeleteCompanyMember :exec
+DELETE FROM company_members
+WHERE id = @id;
+
+-- name: LockCompanyMembers :exec
+SELECT id
+FROM companies
+WHERE id = @company_id
+FOR UPDATE;
+
+-- name: CountCompanyOwners :one
+SELECT count(*)
+FROM company_members
+WHERE company_id = @company_id AND role = 'owner';
+
+-- name: CreateCompanyInvitation :exec
+INSERT INTO company_invitations (
+    id, company_id, email, role, token_hash, invited_by, expires_at, created_at, accepted_at, revoked_at
+) VALUES (
+    @id, @company_id, @email, @role, @token_hash, @invited_by, @expires_at, @created_at, @accepted_at, @revoked_at
+);
+
+-- name: GetCompanyInvitationByID :one
+SELECT
+    id,
+    company_id,
+    email,
+    role,
+    token_hash,
+    invited_by,
+    expires_at,
+    created_at,
+    accepted_at,
+    revoked_at
+FROM company_invitations
+WHERE id = @id;
+
+-- name: GetCompanyInvitationByTokenHash :one
+SELECT
+    id,
+    company_id,
+    email,
+    role,
+    token_hash,
+    invited_by,
+    expires_at,
+    created_at,
+    accepted_at,
+    revoked_at
+FROM company_invitations
+WHERE token_hash = @token_hash;
+
+-- name: ListPendingCompanyInvitations :many
+SELECT
+    id,
+    company_id,
+    email,
+    role,
+    token_hash,
+    invited_by,
+    expires_at,
+    created_at,
+    accepted_at,
+    revoked_at
+FROM company_invitations
+WHERE company_id = @company_id
+    AND accepted_at IS NULL
+    AND revoked_at IS NULL
+    AND expires_at > @now
+ORDER BY created_at DESC, id DESC;
+
+-- name: MarkCompanyInvitationAccepted :execrows
+UPDATE company_invitations
+SET accepted_at = @accepted_at
+WHERE id = @id AND accepted_at IS NULL AND revoked_at IS NULL;
+
+-- name: MarkCompanyInvitationRevoked :execrows
+UPDATE company_invitations
+SET revoked_at = @revoked_at
+WHERE id = @id AND accepted_at IS NULL AND revoked_at IS NULL;
@@ -0,0 +1,29 @@
+-- name: GetLoginAttempt :one
+SELECT key, failures, last_failure_at
+FROM login_attempts
+WHERE key = @key;
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_attempts (key, failures, last_failure_at)
+VALUES (@key, 1, @at)
+ON CONFLICT (key) DO UPDATE
+SET
+    failures = CASE
+        WHEN login_attempts.last_failure_at < @window_start THEN 1
+        ELSE login_attempts.failures + 1
+    END,
+    last_failure_at = EXCLUDED.last_failure_at
+RETURNING key, failures, last_failure_at;
+
+-- name: ReleaseLoginAttempt :exec
+UPDATE login_attempts
+SET failures = failures - 1
+WHERE key = @key AND failures > 0;
+
+-- name: DeleteLoginAttempt :exec
+DELETE FROM login_attempts
+WHERE key = @key;
+
+-- name: DeleteStaleLoginAttempts :exec
+DELETE FROM login_attempts
+WHERE last_failure_at < @before;
@@ -0,0 +1,58 @@
+-- name: CreateOneTimeToken :exec
+INSERT INTO one_time_tokens (
+    id,
+    purpose,
+    subject_id,
+    role,
+    token_hash,
+    expires_at,
+    created_at,
+    used_at
+) VALUES (
+    @id,
+    @purpose,
+    @subject_id,
+    @role,
+    @token_hash,
+    @expires_at,
+    @created_at,
+    @used_at
+);
+
+-- name: ConsumeOneTimeToken :one
+UPDATE one_time_tokens
+SET used_at = @now::timestamptz
+WHERE token_hash = @token_hash
+    AND purpose = @purpose
+    AND used_at IS NULL
+    AND expires_at > @now::timestamptz
+RETURNING
+    id,
+    purpose,
+    subject_id,
+    role,
+    token_hash,
+    expires_at,
+    created_at,
+    used_at;
+
+-- name: GetActiveOneTimeToken :one
+SELECT
+    id,
+    purpose,
+    subject_id,
+    role,
+    token_hash,
+    expires_at,
+    created_at,
+    used_at
+FROM one_time_tokens
+WHERE token_hash = @token_hash
+    AND purpose = @purpose
+    AND used_at IS NULL
+    AND expires_at > @now::timestamptz;
+
+-- name: InvalidateOneTimeTokens :exec
+UPDATE one_time_tokens
+SET used_at = @used_at
+WHERE subject_id = @subject_id AND purpose = @purpose AND used_at IS NULL;
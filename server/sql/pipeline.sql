@@ -0,0 +1,37 @@
+-- name: CreatePipeline :exec
+INSERT INTO pipelines (
+    id, company_id, vacancy_id, stages, created_at, replaced_at
+) VALUES (
+    @id, @company_id, @vacancy_id, @stages, @created_at, NULL
+);
+
+-- name: GetPipelineByID :one
+SELECT
+    id,
+    company_id,
+    vacancy_id,
+    stages,
+    created_at,
+    replaced_at
+FROM pipelines
+WHERE id = @id;
+
+-- name: GetCurrentPipeline :one
+SELECT
+    id,
+    company_id,
+    vacancy_id,
+    stages,
+    created_at,
+    replaced_at
+FROM pipelines
+WHERE company_id = @company_id
+    AND vacancy_id IS NOT DISTINCT FROM sqlc.narg('vacancy_id')::uuid
+    AND replaced_at IS NULL;
+
+-- name: ReplaceCurrentPipeline :execrows
+UPDATE pipelines
+SET replaced_at = @replaced_at
+WHERE company_id = @company_id
+    AND vacancy_id IS NOT DISTINCT FROM sqlc.narg('vacancy_id')::uuid
+    AND replaced_at IS NULL;
@@ -9,26 +9,12 @@ SELECT
     resume_url,
     status,
     created_at,
-    updated_at
+    updated_at,
+    pipeline_id,
+    stage
 FROM responses
 WHERE id = @id;
 
--- name: ListResponsesByVacancy :many
-SELECT
-    id,
-    vacancy_id,
-    full_name,
-    email,
-    phone,
-    cover_letter,
-    resume_url,
-    status,
-    created_at,
-    updated_at
-FROM responses
-WHERE vacancy_id = @vacancy_id
-ORDER BY created_at DESC;
-
 -- name: CreateResponse :exec
 INSERT INTO responses (
     id,
@@ -40,7 +26,9 @@ INSERT INTO responses (
     resume_url,
     status,
     created_at,
-    updated_at
+    updated_at,
+    pipeline_id,
+    stage
 ) VALUES (
     @id,
     @vacancy_id,
@@ -51,12 +39,48 @@ INSERT INTO responses (
     @resume_url,
     @status,
     @created_at,
-    @updated_at
+    @updated_at,
+    @pipeline_id,
+    @stage
 );
 
--- name: UpdateResponseStatus :exec
+-- name: UpdateResponseStatus :execrows
 UPDATE responses
 SET
     status = @status,
+    stage = @stage,
     updated_at = @updated_at
+WHERE id = @id AND stage = @from_stage;
+
+-- name: SearchResponses :many
+SELECT
+    id,
+    vacancy_id,
+    full_name,
+    email,
+    phone,
+    cover_letter,
+    resume_url,
+    status,
+    created_at,
+    updated_at,
+    pipeline_id,
+    stage
+FROM responses
+WHERE (sqlc.narg('vacancy_id')::uuid IS NULL OR vacancy_id = sqlc.narg('vacancy_id')::uuid)
+    AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text)
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountResponses :one
+SELECT count(*)
+FROM responses
+WHERE (sqlc.narg('vacancy_id')::uuid IS NULL OR vacancy_id = sqlc.narg('vacancy_id')::uuid)
+    AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status')::text);
+
+-- name: DeleteResponse :execrows
+DELETE FROM responses
 WHERE id = @id;
@@ -0,0 +1,37 @@
+-- name: CreateResponseStatusChange :exec
+INSERT INTO response_status_history (
+    id,
+    response_id,
+    from_status,
+    to_status,
+    actor_id,
+    actor_role,
+    changed_at,
+    from_stage,
+    to_stage
+) VALUES (
+    @id,
+    @response_id,
+    @from_status,
+    @to_status,
+    @actor_id,
+    @actor_role,
+    @changed_at,
+    @from_stage,
+    @to_stage
+);
+
+-- name: ListResponseStatusChanges :many
+SELECT
+    id,
+    response_id,
+    from_status,
+    to_status,
+    actor_id,
+    actor_role,
+    changed_at,
+    from_stage,
+    to_stage
+FROM response_status_history
+WHERE response_id = @response_id
+ORDER BY changed_at, id;
@@ -0,0 +1,69 @@
+-- name: CreateSession :exec
+INSERT INTO sessions (
+    id,
+    family_id,
+    subject_id,
+    role,
+    refresh_token_hash,
+    expires_at,
+    created_at,
+    rotated_at,
+    revoked_at,
+    company_id
+) VALUES (
+    @id,
+    @family_id,
+    @subject_id,
+    @role,
+    @refresh_token_hash,
+    @expires_at,
+    @created_at,
+    @rotated_at,
+    @revoked_at,
+    @company_id
+);
+
+-- name: GetSessionByRefreshTokenHash :one
+SELECT
+    id,
+    family_id,
+    subject_id,
+    role,
+    refresh_token_hash,
+    expires_at,
+    created_at,
+    rotated_at,
+    revoked_at,
+    company_id
+FROM sessions
+WHERE refresh_token_hash = @refresh_token_hash;
+
+-- name: MarkSessionRotated :execrows
+UPDATE sessions
+SET rotated_at = @rotated_at
+WHERE id = @id AND rotated_at IS NULL AND revoked_at IS NULL;
+
+-- name: IsSessionFamilyActive :one
+SELECT EXISTS (
+    SELECT 1
+    FROM sessions
+    WHERE family_id = @family_id
+        AND rotated_at IS NULL
+        AND revoked_at IS NULL
+        AND expires_at > @now
+);
+
+-- name: RevokeSessionFamily :exec
+UPDATE sessions
+SET revoked_at = @revoked_at
+WHERE family_id = @family_id AND revoked_at IS NULL;
+
+-- name: RevokeSessionsBySubject :exec
+UPDATE sessions
+SET revoked_at = @revoked_at
+WHERE subject_id = @subject_id AND revoked_at IS NULL;
+
+-- name: RevokeSessionsByCompany :exec
+UPDATE sessions
+SET revoked_at = @revoked_at
+WHERE company_id = @company_id AND revoked_at IS NULL;
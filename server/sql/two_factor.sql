@@ -0,0 +1,83 @@
+-- name: GetTwoFactor :one
+SELECT
+    subject_id,
+    role,
+    secret,
+    confirmed_at,
+    last_used_step,
+    created_at,
+    updated_at
+FROM two_factors
+WHERE subject_id = @subject_id;
+
+-- name: UpsertTwoFactor :exec
+INSERT INTO two_factors (
+    subject_id,
+    role,
+    secret,
+    confirmed_at,
+    last_used_step,
+    created_at,
+    updated_at
+) VALUES (
+    @subject_id,
+    @role,
+    @secret,
+    @confirmed_at,
+    @last_used_step,
+    @created_at,
+    @updated_at
+)
+ON CONFLICT (subject_id) DO UPDATE
+SET
+    secret = EXCLUDED.secret,
+    confirmed_at = EXCLUDED.confirmed_at,
+    last_used_step = EXCLUDED.last_used_step,
+    created_at = EXCLUDED.created_at,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: UseTwoFactorStep :execrows
+UPDATE two_factors
+SET
+    last_used_step = @last_used_step,
+    updated_at = @updated_at
+WHERE subject_id = @subject_id
+    AND last_used_step < @last_used_step;
+
+-- name: DeleteTwoFactor :exec
+DELETE FROM two_factors
+WHERE subject_id = @subject_id;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO recovery_codes (id, subject_id, code_hash, created_at)
+VALUES (@id, @subject_id, @code_hash, @created_at);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM recovery_codes
+WHERE subject_id = @subject_id;
+
+-- name: UseRecoveryCode :execrows
+UPDATE recovery_codes
+SET used_at = @used_at::timestamptz
+WHERE subject_id = @subject_id
+    AND code_hash = @code_hash
+    AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT count(*)
+FROM recovery_codes
+WHERE subject_id = @subject_id AND used_at IS NULL;
+
+-- name: GetTwoFactorRequirement :one
+SELECT required
+FROM two_factor_requirements
+WHERE role = @role;
+
+-- name: SetTwoFactorRequirement :exec
+INSERT INTO two_factor_requirements (role, required, updated_by, updated_at)
+VALUES (@role, @required, @updated_by, @updated_at)
+ON CONFLICT (role) DO UPDATE
+SET
+    required = EXCLUDED.required,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = EXCLUDED.updated_at;
@@ -9,7 +9,10 @@ SELECT
     title,
     confirmed,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    email,
+    email_verified
 FROM universities
 WHERE id = @id;
 
@@ -22,13 +25,16 @@ SELECT
     title,
     confirmed,
     created_at,
-    updated_at
+    updated_at,
+    rejection_reason,
+    email,
+    email_verified
 FROM universities
 WHERE login = @login;
 
 -- name: CreateUniversity :exec
-INSERT INTO universities (id, title, login, password_hash, inn, confirmed, created_at, updated_at)
-VALUES (@id, @title, @login, @password_hash, @inn, @confirmed, @created_at, @updated_at);
+INSERT INTO universities (id, title, login, password_hash, inn, confirmed, created_at, updated_at, rejection_reason, email, email_verified)
+VALUES (@id, @title, @login, @password_hash, @inn, @confirmed, @created_at, @updated_at, @rejection_reason, @email, @email_verified);
 
 -- name: UpdateUniversity :exec
 UPDATE universities
@@ -39,5 +45,36 @@ SET
     inn = @inn,
     confirmed = @confirmed,
     created_at = @created_at,
-    updated_at = @updated_at
+    updated_at = @updated_at,
+    rejection_reason = @rejection_reason,
+    email = @email,
+    email_verified = @email_verified
 WHERE id = @id;
+
+-- name: ListUniversities :many
+SELECT
+    id,
+    login,
+    password_hash,
+    inn,
+    title,
+    confirmed,
+    created_at,
+    updated_at,
+    rejection_reason,
+    email,
+    email_verified
+FROM universities
+WHERE sqlc.narg('confirmed')::boolean IS NULL OR confirmed = sqlc.narg('confirmed')::boolean
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'title' THEN title END ASC,
+    CASE WHEN sqlc.arg('sort')::text = '-title' THEN title END DESC,
+    CASE WHEN sqlc.arg('sort')::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUniversities :one
+SELECT count(*)
+FROM universities
+WHERE sqlc.narg('confirmed')::boolean IS NULL OR confirmed = sqlc.narg('confirmed')::boolean;
@@ -13,56 +13,17 @@ SELECT
     experience,
     education,
     location,
-    is_active,
     created_at,
-    updated_at
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
 FROM vacancies
 WHERE id = @id;
 
--- name: ListVacanciesByCompany :many
-SELECT
-    id,
-    company_id,
-    title,
-    description,
-    contacts,
-    requirements,
-    responsibilities,
-    conditions,
-    employment,
-    schedule,
-    experience,
-    education,
-    location,
-    is_active,
-    created_at,
-    updated_at
-FROM vacancies
-WHERE company_id = @company_id
-ORDER BY created_at DESC;
-
--- name: ListActiveVacancies :many
-SELECT
-    id,
-    company_id,
-    title,
-    description,
-    contacts,
-    requirements,
-    responsibilities,
-    conditions,
-    employment,
-    schedule,
-    experience,
-    education,
-    location,
-    is_active,
-    created_at,
-    updated_at
-FROM vacancies
-WHERE is_active = TRUE
-ORDER BY created_at DESC;
-
 -- name: CreateVacancy :exec
 INSERT INTO vacancies (
     id,
@@ -78,9 +39,14 @@ INSERT INTO vacancies (
     experience,
     education,
     location,
-    is_active,
     created_at,
-    updated_at
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
 ) VALUES (
     @id,
     @company_id,
@@ -95,9 +61,14 @@ INSERT INTO vacancies (
     @experience,
     @education,
     @location,
-    @is_active,
     @created_at,
-    @updated_at
+    @updated_at,
+    @salary_min,
+    @salary_max,
+    @salary_currency,
+    @salary_gross,
+    @state,
+    @moderation_comment
 );
 
 -- name: UpdateVacancy :exec
@@ -115,7 +86,157 @@ SET
     experience = @experience,
     education = @education,
     location = @location,
-    is_active = @is_active,
     created_at = @created_at,
-    updated_at = @updated_at
+    updated_at = @updated_at,
+    salary_min = @salary_min,
+    salary_max = @salary_max,
+    salary_currency = @salary_currency,
+    salary_gross = @salary_gross,
+    state = @state,
+    moderation_comment = @moderation_comment
+WHERE id = @id;
+
+-- name: SearchVacancies :many
+SELECT
+    id,
+    company_id,
+    title,
+    description,
+    contacts,
+    requirements,
+    responsibilities,
+    conditions,
+    employment,
+    schedule,
+    experience,
+    education,
+    location,
+    created_at,
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
+FROM vacancies
+WHERE (sqlc.narg('company_id')::uuid IS NULL OR company_id = sqlc.narg('company_id')::uuid)
+    AND (sqlc.narg('location')::text IS NULL OR location = sqlc.narg('location')::text)
+    AND (sqlc.narg('employment')::text IS NULL OR employment = sqlc.narg('employment')::text)
+    AND (sqlc.narg('schedule')::text IS NULL OR schedule = sqlc.narg('schedule')::text)
+    AND (sqlc.narg('experience')::text IS NULL OR experience = sqlc.narg('experience')::text)
+    AND (sqlc.narg('education')::text IS NULL OR education = sqlc.narg('education')::text)
+    AND (sqlc.narg('state')::text IS NULL OR state = sqlc.narg('state')::text)
+    AND (
+        sqlc.narg('salary')::integer IS NULL
+        OR (
+            (salary_min IS NOT NULL OR salary_max IS NOT NULL)
+            AND (salary_min IS NULL OR salary_min <= sqlc.narg('salary')::integer)
+            AND (salary_max IS NULL OR salary_max >= sqlc.narg('salary')::integer)
+        )
+    )
+    AND (sqlc.narg('salary_currency')::text IS NULL OR salary_currency = sqlc.narg('salary_currency')::text)
+    AND (
+        sqlc.narg('company_approved')::boolean IS NULL
+        OR EXISTS (
+            SELECT 1 FROM companies
+            WHERE companies.id = vacancies.company_id
+                AND companies.approved = sqlc.narg('company_approved')::boolean
+        )
+    )
+ORDER BY
+    CASE WHEN sqlc.arg('sort')::text = 'title' THEN title END ASC,
+    CASE WHEN sqlc.arg('sort')::text = '-title' THEN title END DESC,
+    CASE WHEN sqlc.arg('sort')::text = 'salary' THEN COALESCE(salary_min, salary_max) END ASC NULLS LAST,
+    CASE WHEN sqlc.arg('sort')::text = '-salary' THEN COALESCE(salary_max, salary_min) END DESC NULLS LAST,
+    CASE WHEN sqlc.arg('sort')::text = 'created_at' THEN created_at END ASC,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: SearchVacanciesByCursor :many
+SELECT
+    id,
+    company_id,
+    title,
+    description,
+    contacts,
+    requirements,
+    responsibilities,
+    conditions,
+    employment,
+    schedule,
+    experience,
+    education,
+    location,
+    created_at,
+    updated_at,
+    salary_min,
+    salary_max,
+    salary_currency,
+    salary_gross,
+    state,
+    moderation_comment
+FROM vacancies
+WHERE (sqlc.narg('company_id')::uuid IS NULL OR company_id = sqlc.narg('company_id')::uuid)
+    AND (sqlc.narg('location')::text IS NULL OR location = sqlc.narg('location')::text)
+    AND (sqlc.narg('employment')::text IS NULL OR employment = sqlc.narg('employment')::text)
+    AND (sqlc.narg('schedule')::text IS NULL OR schedule = sqlc.narg('schedule')::text)
+    AND (sqlc.narg('experience')::text IS NULL OR experience = sqlc.narg('experience')::text)
+    AND (sqlc.narg('education')::text IS NULL OR education = sqlc.narg('education')::text)
+    AND (sqlc.narg('state')::text IS NULL OR state = sqlc.narg('state')::text)
+    AND (
+        sqlc.narg('salary')::integer IS NULL
+        OR (
+            (salary_min IS NOT NULL OR salary_max IS NOT NULL)
+            AND (salary_min IS NULL OR salary_min <= sqlc.narg('salary')::integer)
+            AND (salary_max IS NULL OR salary_max >= sqlc.narg('salary')::integer)
+        )
+    )
+    AND (sqlc.narg('salary_currency')::text IS NULL OR salary_currency = sqlc.narg('salary_currency')::text)
+    AND (
+        sqlc.narg('company_approved')::boolean IS NULL
+        OR EXISTS (
+            SELECT 1 FROM companies
+            WHERE companies.id = vacancies.company_id
+                AND companies.approved = sqlc.narg('company_approved')::boolean
+        )
+    )
+    AND (
+        sqlc.narg('after_created_at')::timestamptz IS NULL
+        OR (created_at, id) < (sqlc.narg('after_created_at')::timestamptz, sqlc.narg('after_id')::uuid)
+    )
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: CountVacancies :one
+SELECT count(*)
+FROM vacancies
+WHERE (sqlc.narg('company_id')::uuid IS NULL OR company_id = sqlc.narg('company_id')::uuid)
+    AND (sqlc.narg('location')::text IS NULL OR location = sqlc.narg('location')::text)
+    AND (sqlc.narg('employment')::text IS NULL OR employment = sqlc.narg('employment')::text)
+    AND (sqlc.narg('schedule')::text IS NULL OR schedule = sqlc.narg('schedule')::text)
+    AND (sqlc.narg('experience')::text IS NULL OR experience = sqlc.narg('experience')::text)
+    AND (sqlc.narg('education')::text IS NULL OR education = sqlc.narg('education')::text)
+    AND (sqlc.narg('state')::text IS NULL OR state = sqlc.narg('state')::text)
+    AND (
+        sqlc.narg('salary')::integer IS NULL
+        OR (
+            (salary_min IS NOT NULL OR salary_max IS NOT NULL)
+            AND (salary_min IS NULL OR salary_min <= sqlc.narg('salary')::integer)
+            AND (salary_max IS NULL OR salary_max >= sqlc.narg('salary')::integer)
+        )
+    )
+    AND (sqlc.narg('salary_currency')::text IS NULL OR salary_currency = sqlc.narg('salary_currency')::text)
+    AND (
+        sqlc.narg('company_approved')::boolean IS NULL
+        OR EXISTS (
+            SELECT 1 FROM companies
+            WHERE companies.id = vacancies.company_id
+                AND companies.approved = sqlc.narg('company_approved')::boolean
+        )
+    );
+
+-- name: DeleteVacancy :execrows
+DELETE FROM vacancies
 WHERE id = @id;